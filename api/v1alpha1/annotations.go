@@ -0,0 +1,38 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PausedAnnotation, when set to "true" on a Submariner or ServiceDiscovery resource, stops the operator from
+// reconciling the resources it manages until the annotation is removed. This allows them to be debugged and modified
+// manually without the operator reverting the changes. Deleting a paused resource still uninstalls the components.
+const PausedAnnotation = "submariner.io/paused"
+
+// RediscoverNetworkAnnotation, when set on the ClusterNetwork resource, makes the operator discover the cluster network
+// again instead of reusing the recorded results, e.g. after changing the CNI or the cluster CIDRs. The operator removes
+// it once the network has been discovered again.
+const RediscoverNetworkAnnotation = "submariner.io/rediscover-network"
+
+// IsPaused returns true if the reconciliation of the given resource is paused.
+func IsPaused(obj metav1.Object) bool {
+	return obj.GetAnnotations()[PausedAnnotation] == "true"
+}
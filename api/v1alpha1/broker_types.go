@@ -19,6 +19,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -57,17 +58,246 @@ type BrokerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Globalnet"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	GlobalnetEnabled bool `json:"globalnetEnabled,omitempty"`
+
+	// The number of objects of a single kind (Endpoints, ServiceImports, EndpointSlices) in the broker namespace above
+	// which a warning is raised. Defaults to 5000.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Object Count Warning Threshold"
+	//nolint:lll // Markers can't be wrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +kubebuilder:validation:Minimum=0
+	ObjectCountWarningThreshold int `json:"objectCountWarningThreshold,omitempty"`
+
+	// Rotate the service account tokens the joined clusters use to access the broker. The joined clusters pick up their
+	// new token through their broker secret syncer, while the previous tokens remain valid for the overlap period.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Token Rotation"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ClusterTokenRotation *ClusterTokenRotation `json:"clusterTokenRotation,omitempty"`
+
+	// Delete the service accounts, role bindings, tokens and EndpointSlices left on the broker by clusters which have left
+	// the clusterset. Requires the connectivity component, whose gateways register the clusters.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Stale Cluster Cleanup"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	StaleClusterCleanup *StaleClusterCleanup `json:"staleClusterCleanup,omitempty"`
+
+	// Users and groups granted the same access to the broker as the joined clusters' service accounts, typically
+	// identities from an OIDC provider federated with the broker cluster. Clusters can then access the broker with
+	// short-lived credentials issued by the provider instead of long-lived service account tokens. Only User and Group
+	// subjects are supported; they can't be used with cluster isolation or cluster enrollment.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Federated Cluster Subjects"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	FederatedClusterSubjects []rbacv1.Subject `json:"federatedClusterSubjects,omitempty"`
+
+	// Read-only observers of the broker, such as dashboards or fleet inventory tools. Each observer is given a service
+	// account bound to the submariner-k8s-broker-observer role, which can list and watch the broker resources but not
+	// modify them, and a token recorded in the Broker status. Observer names must be DNS labels.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Observers"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +listType=set
+	// +optional
+	Observers []string `json:"observers,omitempty"`
+
+	// Require the enrollment of the clusters joining the broker to be approved, so that the broker connection
+	// information alone isn't enough to join the clusterset. Until a cluster is approved, the role bindings of its
+	// service account are removed and it's listed in the pendingClusters status. Clusters which joined before
+	// enrollment approval was required must be approved too, or they lose their access. Federated cluster subjects
+	// aren't clusters which can be approved, so they can't be used with cluster enrollment.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Enrollment"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ClusterEnrollment *ClusterEnrollment `json:"clusterEnrollment,omitempty"`
+
+	// Isolate the joined clusters from each other on the broker, so that a compromised cluster can't tamper with its
+	// peers' Cluster and Endpoint resources. Each cluster service account is bound to its own role, which only allows
+	// modifying or deleting the Cluster and Endpoint resources of that cluster. The resources of a cluster are identified
+	// by the cluster ID in their spec, which is set by the clusters themselves, and creating resources isn't restricted, so
+	// a compromised cluster can still create resources claiming a peer's cluster ID. Service discovery resources aren't
+	// isolated. Federated cluster subjects can't be isolated, so they can't be used with cluster isolation.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Isolation"
+	//nolint:lll // Markers can't be wrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ClusterIsolation bool `json:"clusterIsolation,omitempty"`
+}
+
+// ClusterEnrollment defines which clusters are allowed to join the broker.
+type ClusterEnrollment struct {
+	// The IDs of the clusters approved by an administrator.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Approved Clusters"
+	// +listType=set
+	// +optional
+	ApprovedClusters []string `json:"approvedClusters,omitempty"`
+
+	// Patterns of the cluster IDs approved automatically, using shell file name syntax, e.g. "staging-*".
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Auto-Approved Clusters"
+	// +listType=set
+	// +optional
+	AutoApprovePatterns []string `json:"autoApprovePatterns,omitempty"`
+}
+
+// StaleClusterCleanup defines when the broker resources of departed clusters are deleted.
+type StaleClusterCleanup struct {
+	// How long the resources of a cluster are kept once they are found without a Cluster resource, which gives joining
+	// clusters time to register; defaults to 24h. Clusters pending enrollment approval are kept.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Grace Period"
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// ClusterTokenRotation defines when the cluster tokens are rotated.
+type ClusterTokenRotation struct {
+	// The maximum age of the tokens; tokens aren't rotated periodically if unset.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Interval"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+
+	// Rotate the tokens issued before this time, for example after a token leak. These tokens are deleted as soon as
+	// their replacements have been issued, without an overlap period; the joined clusters which haven't picked up their
+	// new token by then must rejoin.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Rotate Tokens Issued Before"
+	// +optional
+	RotateIssuedBefore *metav1.Time `json:"rotateIssuedBefore,omitempty"`
+
+	// The IDs of the clusters whose tokens are rotated; all the clusters' tokens are rotated if unset.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster IDs"
+	// +listType=set
+	// +optional
+	ClusterIDs []string `json:"clusterIDs,omitempty"`
+
+	// How long the previous tokens remain valid after a rotation, which gives the joined clusters time to pick up the new
+	// token; the previous tokens are only deleted once the new token has been issued. Tokens issued before
+	// rotateIssuedBefore have no overlap period. Defaults to 1h.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Overlap Period"
+	// +optional
+	OverlapPeriod *metav1.Duration `json:"overlapPeriod,omitempty"`
 }
 
 // BrokerStatus defines the observed state of Broker.
 type BrokerStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+
+	// The number of objects of each monitored kind in the broker namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Object Counts"
+	ObjectCounts map[string]int `json:"objectCounts,omitempty"`
+
+	// The warnings raised for the monitored kinds whose object count exceeds the threshold.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Object Count Warnings"
+	ObjectCountWarnings []string `json:"objectCountWarnings,omitempty"`
+
+	// The global CIDRs allocated to each cluster, as recorded in the globalnet ConfigMap.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Globalnet Allocations"
+	// +listType=map
+	// +listMapKey=clusterID
+	GlobalnetAllocations []GlobalnetAllocation `json:"globalnetAllocations,omitempty"`
+
+	// The conflicts detected between the allocated global CIDRs, or with the Globalnet CIDR range.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Globalnet Conflicts"
+	GlobalnetConflicts []string `json:"globalnetConflicts,omitempty"`
+
+	// The current token of each joined cluster.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster Tokens"
+	// +listType=map
+	// +listMapKey=clusterID
+	ClusterTokens []ClusterToken `json:"clusterTokens,omitempty"`
+
+	// The service accounts of clusters which no longer have a Cluster resource, and haven't been cleaned up.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Stale Cluster Service Accounts"
+	// +listType=set
+	StaleClusterServiceAccounts []string `json:"staleClusterServiceAccounts,omitempty"`
+
+	// The EndpointSlices exported by clusters which no longer have a Cluster resource, and haven't been cleaned up. The
+	// services they back remain imported in the clusterset until they're removed.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Stale Cluster EndpointSlices"
+	// +listType=set
+	StaleClusterEndpointSlices []string `json:"staleClusterEndpointSlices,omitempty"`
+
+	// The IDs of the clusters waiting for their enrollment to be approved.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Pending Clusters"
+	// +listType=set
+	PendingClusters []string `json:"pendingClusters,omitempty"`
+
+	// The most recent repair of the broker service accounts, roles and role bindings, after they were deleted or modified.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Last RBAC Repair"
+	LastRBACRepair *BrokerRBACRepair `json:"lastRBACRepair,omitempty"`
+
+	// The token of each broker observer.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Observer Tokens"
+	// +listType=map
+	// +listMapKey=name
+	ObserverTokens []ObserverToken `json:"observerTokens,omitempty"`
+
+	// The readiness of the broker: whether its CRDs are installed, its namespace is usable, its RBAC is in place and
+	// Globalnet is configured. Clusters can join once all the conditions are true.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions"
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:io.kubernetes.conditions"}
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// The Broker status condition types.
+const (
+	// The broker CRDs are installed.
+	BrokerCRDsInstalled = "CRDsInstalled"
+	// The broker namespace exists and isn't being deleted.
+	BrokerNamespaceReady = "NamespaceReady"
+	// The broker service accounts, roles and role bindings are in place.
+	BrokerRBACReady = "RBACReady"
+	// The globalnet ConfigMap is configured, whether or not Globalnet is enabled.
+	BrokerGlobalnetConfigured = "GlobalnetConfigured"
+)
+
+// BrokerRBACRepair describes a repair of the broker RBAC.
+type BrokerRBACRepair struct {
+	// When the repair happened.
+	Time metav1.Time `json:"time"`
+
+	// The problems which were found and fixed.
+	Problems []string `json:"problems"`
+}
+
+// ObserverToken describes the read-only token of a broker observer.
+type ObserverToken struct {
+	// The name of the observer.
+	Name string `json:"name"`
+
+	// The Secret, in the broker namespace, holding the token.
+	SecretName string `json:"secretName"`
+}
+
+// ClusterToken describes the current service account token of a joined cluster.
+type ClusterToken struct {
+	// The ID of the cluster.
+	ClusterID string `json:"clusterID"`
+
+	// The Secret, in the broker namespace, holding the token.
+	SecretName string `json:"secretName"`
+
+	// When the token was issued.
+	IssueTime metav1.Time `json:"issueTime"`
+}
+
+// GlobalnetAllocation describes the global CIDRs allocated to a cluster.
+type GlobalnetAllocation struct {
+	// The ID of the cluster.
+	ClusterID string `json:"clusterID"`
+
+	// The global CIDRs allocated to the cluster.
+	GlobalCIDRs []string `json:"globalCIDRs,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
-//+kubebuilder:resource:path=brokers,scope=Namespaced
+//+kubebuilder:resource:path=brokers,scope=Namespaced,categories=submariner-io
+//+kubebuilder:printcolumn:name="Components",type="string",JSONPath=".spec.components"
+//+kubebuilder:printcolumn:name="Globalnet",type="boolean",JSONPath=".spec.globalnetEnabled"
+//+kubebuilder:printcolumn:name="Global CIDR Range",type="string",JSONPath=".spec.globalnetCIDRRange"
+//+kubebuilder:printcolumn:name="Object Count Warnings",type="string",JSONPath=".status.objectCountWarnings",priority=1
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Broker is the Schema for the brokers API.
 // +operator-sdk:csv:customresourcedefinitions:displayName="Submariner Broker",resources={{Deployment,v1,submariner-operator}}
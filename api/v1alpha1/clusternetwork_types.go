@@ -0,0 +1,79 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// ClusterNetworkStatus holds the network details discovered in the cluster.
+type ClusterNetworkStatus struct {
+	// The network plugin (CNI) detected in the cluster.
+	NetworkPlugin string `json:"networkPlugin,omitempty"`
+
+	// The pod CIDRs detected in the cluster.
+	PodCIDRs []string `json:"podCIDRs,omitempty"`
+
+	// The service CIDRs detected in the cluster.
+	ServiceCIDRs []string `json:"serviceCIDRs,omitempty"`
+
+	// The global CIDR configured in the cluster, if any.
+	GlobalCIDR string `json:"globalCIDR,omitempty"`
+
+	// The kube-proxy mode detected in the cluster, if any.
+	KubeProxyMode string `json:"kubeProxyMode,omitempty"`
+
+	// Plugin-specific settings detected in the cluster.
+	PluginSettings map[string]string `json:"pluginSettings,omitempty"`
+
+	// When the network was discovered. The results are discovered again once they are older than a day, or when the
+	// submariner.io/rediscover-network annotation is set.
+	DiscoveryTime metav1.Time `json:"discoveryTime,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=clusternetworks,scope=Namespaced,categories=submariner-io
+//+kubebuilder:printcolumn:name="Plugin",type="string",JSONPath=".status.networkPlugin"
+//+kubebuilder:printcolumn:name="Pod CIDRs",type="string",JSONPath=".status.podCIDRs"
+//+kubebuilder:printcolumn:name="Service CIDRs",type="string",JSONPath=".status.serviceCIDRs"
+
+// ClusterNetwork records the results of the cluster network discovery performed by the operator so they can be
+// reused instead of being discovered again. They are refreshed daily, or on demand with the
+// submariner.io/rediscover-network annotation.
+// +operator-sdk:csv:customresourcedefinitions:displayName="Cluster Network"
+type ClusterNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status ClusterNetworkStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterNetworkList contains a list of ClusterNetwork.
+type ClusterNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterNetwork `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterNetwork{}, &ClusterNetworkList{})
+}
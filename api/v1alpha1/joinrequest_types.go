@@ -0,0 +1,154 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+type JoinRequestPhase string
+
+const (
+	// The join is waiting for its prerequisites, such as the broker credentials Secret or a gateway node.
+	JoinRequestPending JoinRequestPhase = "Pending"
+	// The Submariner resource was created; the join continues as for any Submariner deployment.
+	JoinRequestJoined JoinRequestPhase = "Joined"
+	// The join can't proceed, e.g. because the cluster already joined with another configuration.
+	JoinRequestFailed JoinRequestPhase = "Failed"
+)
+
+// JoinRequestSpec defines how the cluster joins the clusterset.
+type JoinRequestSpec struct {
+	// The ID identifying the cluster in the clusterset; must be a DNS label.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster ID"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	ClusterID string `json:"clusterID"`
+
+	// The broker API URL.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Broker API Server"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	BrokerK8sApiServer string `json:"brokerK8sApiServer"`
+
+	// The broker namespace.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Broker Remote Namespace"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	BrokerK8sRemoteNamespace string `json:"brokerK8sRemoteNamespace"`
+
+	// The Secret, in the JoinRequest's namespace, holding the broker credentials: the broker service account token and
+	// CA in its token and ca.crt entries, and the IPsec Pre-Shared Key in its psk entry. The join waits for the Pre-Shared
+	// Key unless the cable driver is vxlan or wireguard.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Broker Secret"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	BrokerSecret string `json:"brokerSecret"`
+
+	// The cluster CIDR; detected if unset.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster CIDR"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +optional
+	ClusterCIDR string `json:"clusterCIDR,omitempty"`
+
+	// The service CIDR; detected if unset.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service CIDR"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +optional
+	ServiceCIDR string `json:"serviceCIDR,omitempty"`
+
+	// Cable driver implementation - any of [libreswan, wireguard, vxlan].
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cable Driver"
+	//nolint:lll // Markers can't be wrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:select:libreswan","urn:alm:descriptor:com.tectonic.ui:select:vxlan","urn:alm:descriptor:com.tectonic.ui:select:wireguard"}
+	// +optional
+	CableDriver string `json:"cableDriver,omitempty"`
+
+	// Enable NAT between clusters.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable NAT"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +optional
+	NatEnabled bool `json:"natEnabled,omitempty"`
+
+	// Enable support for Service Discovery (Lighthouse).
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Service Discovery"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +optional
+	ServiceDiscoveryEnabled bool `json:"serviceDiscoveryEnabled,omitempty"`
+
+	// Selects the nodes to label as gateways. If unset, the nodes already labeled as gateways are used, or the first
+	// worker node is labeled if there are none.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Node Selector"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	GatewayNodeSelector *metav1.LabelSelector `json:"gatewayNodeSelector,omitempty"`
+}
+
+// JoinRequestStatus defines the observed state of JoinRequest.
+type JoinRequestStatus struct {
+	// The phase of the join.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Phase"
+	// +optional
+	Phase JoinRequestPhase `json:"phase,omitempty"`
+
+	// The nodes labeled as gateways.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Gateway Nodes"
+	// +optional
+	GatewayNodes []string `json:"gatewayNodes,omitempty"`
+
+	// What the join is waiting for, or why it failed.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Message"
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=joinrequests,scope=Namespaced,categories=submariner-io
+//+kubebuilder:printcolumn:name="Cluster ID",type="string",JSONPath=".spec.clusterID"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// JoinRequest makes the operator join the cluster to a clusterset, as subctl join does: it labels the gateway nodes and
+// creates the Submariner resource, whose controller then detects the network settings. This lets cluster provisioners
+// onboard clusters declaratively, by creating a JoinRequest along with the broker credentials Secret. Once joined, the
+// deployment is managed through the Submariner resource. Unlike subctl join, a JoinRequest doesn't allocate a global
+// CIDR from the broker's globalnet ConfigMap, which the cluster credentials can't update: clusters joining a broker with
+// Globalnet enabled must be joined with subctl.
+// +operator-sdk:csv:customresourcedefinitions:displayName="Join Request"
+type JoinRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   JoinRequestSpec   `json:"spec,omitempty"`
+	Status JoinRequestStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// JoinRequestList contains a list of JoinRequest.
+type JoinRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []JoinRequest `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&JoinRequest{}, &JoinRequestList{})
+}
@@ -32,24 +32,31 @@ type ServiceDiscoverySpec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 
-	BrokerK8sApiServer       string               `json:"brokerK8sApiServer"`
-	BrokerK8sApiServerToken  string               `json:"brokerK8sApiServerToken,omitempty"`
-	BrokerK8sCA              string               `json:"brokerK8sCA,omitempty"`
-	BrokerK8sSecret          string               `json:"brokerK8sSecret,omitempty"`
-	BrokerK8sRemoteNamespace string               `json:"brokerK8sRemoteNamespace"`
-	ClusterID                string               `json:"clusterID"`
-	Namespace                string               `json:"namespace"`
-	Repository               string               `json:"repository,omitempty"`
-	Version                  string               `json:"version,omitempty"`
-	Debug                    bool                 `json:"debug"`
-	GlobalnetEnabled         bool                 `json:"globalnetEnabled,omitempty"`
-	BrokerK8sInsecure        bool                 `json:"brokerK8sInsecure,omitempty"`
-	HaltOnCertificateError   bool                 `json:"haltOnCertificateError,omitempty"`
-	CoreDNSCustomConfig      *CoreDNSCustomConfig `json:"coreDNSCustomConfig,omitempty"`
+	BrokerK8sApiServer           string               `json:"brokerK8sApiServer"`
+	BrokerK8sApiServerToken      string               `json:"brokerK8sApiServerToken,omitempty"`
+	BrokerK8sCA                  string               `json:"brokerK8sCA,omitempty"`
+	BrokerK8sSecret              string               `json:"brokerK8sSecret,omitempty"`
+	BrokerK8sSecretProviderClass string               `json:"brokerK8sSecretProviderClass,omitempty"`
+	BrokerK8sRemoteNamespace     string               `json:"brokerK8sRemoteNamespace"`
+	ClusterID                    string               `json:"clusterID"`
+	Namespace                    string               `json:"namespace"`
+	Repository                   string               `json:"repository,omitempty"`
+	Version                      string               `json:"version,omitempty"`
+	Debug                        bool                 `json:"debug"`
+	GlobalnetEnabled             bool                 `json:"globalnetEnabled,omitempty"`
+	BrokerK8sInsecure            bool                 `json:"brokerK8sInsecure,omitempty"`
+	HaltOnCertificateError       bool                 `json:"haltOnCertificateError,omitempty"`
+	CoreDNSCustomConfig          *CoreDNSCustomConfig `json:"coreDNSCustomConfig,omitempty"`
 	// +listType=set
 	CustomDomains  []string          `json:"customDomains,omitempty"`
 	ImageOverrides map[string]string `json:"imageOverrides,omitempty"`
 	// +optional
+	UpdateStrategies map[string]ComponentUpdateStrategy `json:"updateStrategies,omitempty"`
+	// +optional
+	ExternalDNS *ExternalDNSConfig `json:"externalDNS,omitempty"`
+	// +optional
+	IstioServiceEntries *IstioServiceEntriesConfig `json:"istioServiceEntries,omitempty"`
+	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
@@ -61,11 +68,22 @@ type ServiceDiscoveryStatus struct {
 	// Important: Run "make" to regenerate code after modifying this file
 
 	DeploymentInfo DeploymentInfo `json:"deploymentInfo,omitempty"`
+
+	// The number of consecutive failed reconciles of this resource.
+	ReconcileFailures int32 `json:"reconcileFailures,omitempty"`
+
+	// Whether reconciliation is paused by the submariner.io/paused annotation.
+	Paused bool `json:"paused,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
-//+kubebuilder:resource:path=servicediscoveries,scope=Namespaced
+//+kubebuilder:resource:path=servicediscoveries,scope=Namespaced,categories=submariner-io
+//+kubebuilder:printcolumn:name="Cluster ID",type="string",JSONPath=".spec.clusterID"
+//+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+//+kubebuilder:printcolumn:name="Globalnet",type="boolean",JSONPath=".spec.globalnetEnabled"
+//+kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".status.paused",priority=1
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ServiceDiscovery is the Schema for the servicediscoveries API.
 type ServiceDiscovery struct {
@@ -99,6 +117,26 @@ type CoreDNSCustomConfig struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CoreDNS Custom Config Namespace"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	Namespace string `json:"namespace,omitempty"`
+
+	// Additional server blocks written to the custom CoreDNS configmap after the Lighthouse ones, each forwarding a domain
+	// to its own upstream resolvers, e.g. for internal DNS zones. Their domains must not overlap the clusterset domains.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CoreDNS Custom Server Blocks"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +listType=map
+	// +listMapKey=domain
+	// +optional
+	ServerBlocks []CoreDNSServerBlock `json:"serverBlocks,omitempty"`
+}
+
+// CoreDNSServerBlock forwards the queries for a domain to upstream resolvers.
+type CoreDNSServerBlock struct {
+	// The domain served by the block.
+	Domain string `json:"domain"`
+
+	// The resolvers the queries are forwarded to, as IP addresses with an optional port, e.g. 10.0.0.10 or 10.0.0.10:5353,
+	// in order of preference.
+	// +kubebuilder:validation:MinItems=1
+	Upstreams []string `json:"upstreams"`
 }
 
 func (sd *ServiceDiscovery) UnmarshalJSON(data []byte) error {
@@ -117,3 +155,35 @@ func (sd *ServiceDiscovery) UnmarshalJSON(data []byte) error {
 
 	return nil
 }
+
+// ExternalDNSConfig configures the publication of the clusterset DNS records of exported services as external-dns
+// DNSEndpoint resources, so that clients outside the clusterset can resolve them. Only services with a clusterset IP
+// are published.
+type ExternalDNSConfig struct {
+	// The labels selecting the ServiceImports to publish; all ServiceImports are published if unset.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Import Selector"
+	// +optional
+	ServiceImportSelector *metav1.LabelSelector `json:"serviceImportSelector,omitempty"`
+
+	// The TTL of the published records, in seconds; the provider's default is used if unset.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Record TTL"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RecordTTL int64 `json:"recordTTL,omitempty"`
+}
+
+// IstioServiceEntriesConfig configures the mirroring of imported services as Istio ServiceEntry resources, so that mesh
+// workloads can route to the services exported by the other clusters through their mesh configuration.
+type IstioServiceEntriesConfig struct {
+	// The labels selecting the ServiceImports to mirror; all ServiceImports are mirrored if unset.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Import Selector"
+	// +optional
+	ServiceImportSelector *metav1.LabelSelector `json:"serviceImportSelector,omitempty"`
+
+	// The namespaces the ServiceEntries are visible in; they are visible in all namespaces if unset.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Export To"
+	// +listType=set
+	// +optional
+	ExportTo []string `json:"exportTo,omitempty"`
+}
@@ -55,8 +55,24 @@ type SubmarinerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:password"}
 	BrokerK8sCA string `json:"brokerK8sCA,omitempty"`
 
+	// A PEM bundle of the certificate authorities to trust for the broker API server, overriding the broker cluster's
+	// service account CA. Needed when brokerK8sApiServer is an endpoint presenting another certificate, such as a private
+	// endpoint, a load balancer or a bastion. The bundle is injected into the broker secret synced from the broker.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Broker API CA Bundle"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	BrokerK8sCABundle string `json:"brokerK8sCABundle,omitempty"`
+
 	BrokerK8sSecret string `json:"brokerK8sSecret,omitempty"`
 
+	// The name of a Secrets Store CSI driver SecretProviderClass supplying the broker credentials from an external secret
+	// manager. When set, the credentials are mounted from the CSI driver instead of the brokerK8sSecret Secret, and aren't
+	// synced from the broker; brokerK8sSecret is still required to name them.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Broker Secret Provider Class"
+	//nolint:lll // Markers can't be wrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	BrokerK8sSecretProviderClass string `json:"brokerK8sSecretProviderClass,omitempty"`
+
 	// The Broker namespace.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Broker Remote Namespace"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
@@ -85,8 +101,27 @@ type SubmarinerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	ClusterID string `json:"clusterID"`
 
+	// Deprecated: use clusterMetadata instead. The color codes are still passed to the gateway.
 	ColorCodes string `json:"colorCodes,omitempty"`
 
+	// Metadata describing the cluster in the clusterset, such as its display name, region and environment. It is
+	// attached to the local Endpoint as submariner.io/cluster-* annotations, which are synchronized to the broker along
+	// with the Endpoint so the other clusters and the clusterset tooling can show it.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Metadata"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ClusterMetadata *ClusterMetadata `json:"clusterMetadata,omitempty"`
+
+	// Candidate addresses of the gateway, for the remote clusters to try in order of priority when the public IP alone
+	// isn't reachable from all of them, e.g. in hybrid topologies. They are advertised on the local Endpoint, in the
+	// submariner.io/gateway-addresses annotation.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Addresses"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +listType=map
+	// +listMapKey=address
+	// +optional
+	GatewayAddresses []GatewayAddress `json:"gatewayAddresses,omitempty"`
+
 	// The image repository.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Repository"
 	//nolint:lll // Markers can't be wrapped
@@ -174,6 +209,34 @@ type SubmarinerSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	HaltOnCertificateError bool `json:"haltOnCertificateError,omitempty"`
 
+	// Hand the active gateway role over to a standby gateway when the node of the active gateway is cordoned for
+	// maintenance, before the node is drained.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Drain"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	GatewayDrain *GatewayDrain `json:"gatewayDrain,omitempty"`
+
+	// Protect the gateways, and optionally the route agents, from voluntary disruptions such as node drains and
+	// cluster-autoscaler scale-downs.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Eviction Protection"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	EvictionProtection *EvictionProtection `json:"evictionProtection,omitempty"`
+
+	// Record the availability of the connections to the remote clusters over rolling windows, in metrics and in the
+	// status, by sampling the gateways' connection health checks.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Connectivity SLO"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ConnectivitySLO *ConnectivitySLO `json:"connectivitySLO,omitempty"`
+
+	// Labels and annotations to attach to the local Endpoint, e.g. region, environment or cost center. They are
+	// synchronized to the broker along with the Endpoint, so they are visible from the other clusters.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint Metadata"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	EndpointMetadata *EndpointMetadata `json:"endpointMetadata,omitempty"`
+
 	// Name of the custom CoreDNS configmap to configure forwarding to Lighthouse.
 	// It should be in <namespace>/<name> format where <namespace> is optional and defaults to kube-system.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CoreDNS Custom Config"
@@ -186,19 +249,80 @@ type SubmarinerSpec struct {
 	// +listType=set
 	CustomDomains []string `json:"customDomains,omitempty"`
 
+	// Publish the clusterset DNS records of exported services to an external DNS provider, through external-dns.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="External DNS"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	ExternalDNS *ExternalDNSConfig `json:"externalDNS,omitempty"`
+
+	// Mirror the imported services as Istio ServiceEntry resources, so that mesh workloads can route to them.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Istio Service Entries"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	IstioServiceEntries *IstioServiceEntriesConfig `json:"istioServiceEntries,omitempty"`
+
 	// Override component images.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Overrides"
 	//nolint:lll // Markers can't be wrapped
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden","urn:alm:descriptor:com.tectonic.ui:advanced"}
 	ImageOverrides map[string]string `json:"imageOverrides,omitempty"`
 
+	// Override component images on the nodes of specific architectures, for registries which publish separate images per
+	// architecture instead of multi-architecture images. Keys are architectures, as in the kubernetes.io/arch node label,
+	// mapped to image overrides by component. Each overridden architecture is deployed using its own DaemonSets.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Architecture Image Overrides"
+	//nolint:lll // Markers can't be wrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ArchitectureImageOverrides map[string]map[string]string `json:"architectureImageOverrides,omitempty"`
+
+	// Override the update strategy of components, keyed by component as for image overrides. DaemonSet strategies
+	// apply to the gateway, route agent, globalnet and metrics proxy components, Deployment strategies to the
+	// Lighthouse agent and CoreDNS components. The OnDelete DaemonSet strategy allows dataplane updates to be
+	// staged node by node, by deleting the pods manually.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Update Strategies"
+	//nolint:lll // Markers can't be wrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:hidden","urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	UpdateStrategies map[string]ComponentUpdateStrategy `json:"updateStrategies,omitempty"`
+
+	// The policy used to reference component images.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Policy"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	ImagePolicy *ImagePolicy `json:"imagePolicy,omitempty"`
+
 	// The gateway connection health check.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Connection Health Check"
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
 	// +optional
 	ConnectionHealthCheck *HealthCheckSpec `json:"connectionHealthCheck,omitempty"`
+
+	// Secure the metrics proxies: metrics are then only served over HTTPS, to authenticated and authorized clients.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Proxy Authentication"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	MetricsProxyAuth *MetricsProxyAuth `json:"metricsProxyAuth,omitempty"`
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Node placement defaults applied to all the components which don't need to run on every node, for example
+	// to confine an installation to an infrastructure node pool. The nodeSelector and tolerations fields take
+	// precedence for service discovery.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Placement"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	NodePlacement *NodePlacement `json:"nodePlacement,omitempty"`
+}
+
+// NodePlacement constrains the nodes the pods are scheduled on.
+type NodePlacement struct {
+	// The node labels the pods require, in addition to those required by each component.
 	// +optional
 	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations added to the pods.
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
@@ -217,6 +341,9 @@ type SubmarinerStatus struct {
 
 	ColorCodes string `json:"colorCodes,omitempty"`
 
+	// The current cluster metadata.
+	ClusterMetadata *ClusterMetadata `json:"clusterMetadata,omitempty"`
+
 	// The current cluster ID.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster ID"
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
@@ -262,6 +389,14 @@ type SubmarinerStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Gateways"
 	Gateways *[]submv1.GatewayStatus `json:"gateways,omitempty"`
 
+	// The failover history of the gateways in the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Gateway Failover"
+	GatewayFailover *GatewayFailoverStatus `json:"gatewayFailover,omitempty"`
+
+	// The drain of the gateway on the last cordoned gateway node.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Gateway Drain"
+	GatewayDrain *GatewayDrainStatus `json:"gatewayDrain,omitempty"`
+
 	// Information about the deployment.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Deployment Information"
 	DeploymentInfo DeploymentInfo `json:"deploymentInfo,omitempty"`
@@ -269,11 +404,39 @@ type SubmarinerStatus struct {
 	// The image version in use by the various Submariner DaemonSets and Deployments.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Version"
 	Version string `json:"version,omitempty"`
+
+	// The availability of the connections to the remote clusters, over the rolling windows of the connectivity SLO.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Connection Availability"
+	ConnectionAvailability []ConnectionAvailability `json:"connectionAvailability,omitempty"`
+
+	// The number of consecutive failed reconciles of this resource.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Reconcile Failures"
+	ReconcileFailures int32 `json:"reconcileFailures,omitempty"`
+
+	// The architectures of the cluster's nodes.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Node Architectures"
+	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	NodeArchitectures []string `json:"nodeArchitectures,omitempty"`
+
+	// Whether reconciliation is paused by the submariner.io/paused annotation.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Paused"
+	Paused bool `json:"paused,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
-//+kubebuilder:resource:path=submariners,scope=Namespaced
+//+kubebuilder:resource:path=submariners,scope=Namespaced,categories=submariner-io
+//+kubebuilder:printcolumn:name="Cluster ID",type="string",JSONPath=".status.clusterID"
+//+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+//+kubebuilder:printcolumn:name="Global CIDR",type="string",JSONPath=".status.globalCIDR"
+//nolint:lll // Markers can't be wrapped
+//+kubebuilder:printcolumn:name="Connected Clusters",type="string",JSONPath=`.status.gateways[?(@.haStatus=="active")].connections[*].endpoint.cluster_id`
+//nolint:lll // Markers can't be wrapped
+//+kubebuilder:printcolumn:name="Connection Status",type="string",JSONPath=`.status.gateways[?(@.haStatus=="active")].connections[*].status`,priority=1
+//+kubebuilder:printcolumn:name="Active Gateway",type="string",JSONPath=".status.gatewayFailover.activeGateway",priority=1
+//+kubebuilder:printcolumn:name="Network Plugin",type="string",JSONPath=".status.networkPlugin",priority=1
+//+kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".status.paused",priority=1
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Submariner is the Schema for the submariners API.
 // +operator-sdk:csv:customresourcedefinitions:displayName="Submariner",resources={{Deployment,v1,submariner-operator}}
@@ -316,6 +479,43 @@ type DeploymentInfo struct {
 	CloudProvider         CloudProvider  `json:"cloudProvider,omitempty"`
 }
 
+type GatewayFailoverStatus struct {
+	// The host name of the currently active gateway.
+	ActiveGateway string `json:"activeGateway,omitempty"`
+
+	// The number of times the active gateway changed.
+	FailoverCount int32 `json:"failoverCount,omitempty"`
+
+	// When the active gateway last changed.
+	LastFailoverTime *metav1.Time `json:"lastFailoverTime,omitempty"`
+
+	// The reason recorded for the last change of the active gateway.
+	LastFailoverReason string `json:"lastFailoverReason,omitempty"`
+}
+
+// GatewayDrainPhase is the phase of the drain of a gateway.
+type GatewayDrainPhase string
+
+const (
+	// The gateway is removed from the cordoned node, waiting for a standby gateway to take over.
+	GatewayDraining GatewayDrainPhase = "Draining"
+	// A standby gateway took over and re-established all its connections; the node can be drained.
+	GatewayDrained GatewayDrainPhase = "Drained"
+	// No standby gateway took over within the timeout; the gateway is restored on the cordoned node.
+	GatewayDrainTimedOut GatewayDrainPhase = "TimedOut"
+)
+
+type GatewayDrainStatus struct {
+	// The cordoned node.
+	Node string `json:"node"`
+
+	// The phase of the drain.
+	Phase GatewayDrainPhase `json:"phase"`
+
+	// When the drain started.
+	StartTime metav1.Time `json:"startTime"`
+}
+
 type HealthCheckSpec struct {
 	// Enable the connection health check.
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Connection Health Checks"
@@ -335,9 +535,183 @@ type HealthCheckSpec struct {
 	MaxPacketLossCount uint64 `json:"maxPacketLossCount,omitempty"`
 }
 
+// ComponentUpdateStrategy configures how a component is updated. Only the strategy matching the kind of workload
+// used for the component is applied.
+type ComponentUpdateStrategy struct {
+	// The update strategy for components deployed as DaemonSets.
+	// +optional
+	DaemonSet *appsv1.DaemonSetUpdateStrategy `json:"daemonSet,omitempty"`
+
+	// The strategy for components deployed as Deployments.
+	// +optional
+	Deployment *appsv1.DeploymentStrategy `json:"deployment,omitempty"`
+}
+
+type ImagePolicy struct {
+	// How component images are referenced - any of [Tag, Digest]. With Tag, images follow the configured version.
+	// With Digest, images are pinned to the digests listed in the digest ConfigMap. Resolving digests through an external
+	// resolver webhook isn't supported; an external resolver can maintain the digest ConfigMap instead.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Policy Mode"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Enum=Tag;Digest
+	// +optional
+	Mode ImagePolicyMode `json:"mode,omitempty"`
+
+	// Name of the ConfigMap, in the operator namespace, mapping component names to image digests. Values can also be
+	// complete image references. Changes to the ConfigMap roll out the affected components.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image Digest ConfigMap"
+	//nolint:lll // Markers can't be wrapped
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text","urn:alm:descriptor:com.tectonic.ui:fieldDependency:imagePolicy.mode:Digest"}
+	// +optional
+	DigestConfigMap string `json:"digestConfigMap,omitempty"`
+}
+
+// EvictionProtection configures the protection of Submariner pods against voluntary disruptions. Protected pods are
+// marked as not safe to evict for the cluster autoscaler, and covered by PodDisruptionBudgets: at least one gateway
+// must remain available, so the last gateway can only be evicted once another one is available.
+type EvictionProtection struct {
+	// Also protect the route agents; only one route agent can then be evicted at a time.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Protect Route Agents"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +optional
+	RouteAgents bool `json:"routeAgents,omitempty"`
+}
+
+// EndpointMetadata holds custom metadata for the local Endpoint. Keys in the submariner.io and submariner-io domains are
+// reserved and ignored.
+type EndpointMetadata struct {
+	// Labels to attach to the local Endpoint.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint Labels"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations to attach to the local Endpoint.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Endpoint Annotations"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ClusterMetadata describes a cluster to the operators of the clusterset, beyond its cluster ID.
+type ClusterMetadata struct {
+	// The name shown for the cluster.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Display Name"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// The region the cluster runs in, e.g. eu-west-1.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Region"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// The environment the cluster belongs to, e.g. production or staging.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Environment"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Environment string `json:"environment,omitempty"`
+}
+
+// GatewayAddress is a candidate address advertised for the gateway.
+type GatewayAddress struct {
+	// The IP address.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Address"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	Address string `json:"address"`
+
+	// The kind of address - any of [Public, Private, LoadBalancer, Secondary].
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Address Type"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +kubebuilder:validation:Enum=Public;Private;LoadBalancer;Secondary
+	Type GatewayAddressType `json:"type"`
+
+	// The priority of the address; addresses with lower values are tried first. Addresses with the same priority are
+	// tried in the order they are listed.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Address Priority"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+}
+
+// GatewayDrain configures the drain of the active gateway when its node is cordoned. The gateway is only drained if a
+// healthy standby gateway is available on another node; it is then removed from the cordoned node, which is labeled
+// submariner.io/gateway-draining=true, and the drain completes once the standby gateway is active and connected to all
+// the remote clusters. The progress is reported in the gatewayDrain status, which maintenance tooling can wait on before
+// draining the node. The gateway is scheduled on the node again once the node is uncordoned.
+type GatewayDrain struct {
+	// How long to wait for the standby gateway to take over and re-establish its connections before giving up and
+	// restoring the gateway on the cordoned node. Defaults to 2m.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Gateway Drain Timeout"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// ConnectivitySLO configures the recording of the availability of the connections to the remote clusters. The
+// connections are sampled from the health checks performed by the active gateway; a connection is available when it
+// is connected. Samples are kept in memory by the operator, so the history restarts with the operator.
+type ConnectivitySLO struct {
+	// The interval between samples. Defaults to 30s.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Sample Interval"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +optional
+	SampleInterval *metav1.Duration `json:"sampleInterval,omitempty"`
+
+	// The rolling windows over which the availability is computed. Defaults to 1h and 24h.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Windows"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	// +optional
+	Windows []metav1.Duration `json:"windows,omitempty"`
+}
+
+type ConnectionAvailability struct {
+	// The ID of the remote cluster.
+	ClusterID string `json:"clusterID"`
+
+	// The rolling window.
+	Window metav1.Duration `json:"window"`
+
+	// The percentage of samples in the window in which the connection was available, with two decimals.
+	Availability string `json:"availability"`
+
+	// The average round-trip time measured by the health checks over the window, if any.
+	// +optional
+	AverageLatency string `json:"averageLatency,omitempty"`
+
+	// The number of samples in the window.
+	Samples int32 `json:"samples"`
+}
+
+// MetricsProxyAuth configures the authentication of metrics clients. Clients authenticate using bearer tokens, validated
+// with TokenReviews, and must be allowed to get the /metrics non-resource URL.
+type MetricsProxyAuth struct {
+	// Name of a kubernetes.io/tls Secret, in the Submariner namespace, holding the serving certificate of the metrics
+	// proxies, e.g. as issued by a cert-manager Certificate. Rotated certificates are picked up without restarting the
+	// proxies. If empty, the proxies generate a self-signed certificate when they start.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Proxy TLS Secret"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	// +optional
+	TLSSecret string `json:"tlsSecret,omitempty"`
+
+	// Also authenticate clients presenting a certificate signed by the CA in the ca.crt entry of the TLS secret (mutual
+	// TLS). Requires a TLS secret.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics Proxy Client Certificates"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	// +optional
+	ClientCertificates bool `json:"clientCertificates,omitempty"`
+}
+
 type (
-	KubernetesType string
-	CloudProvider  string
+	KubernetesType     string
+	CloudProvider      string
+	ImagePolicyMode    string
+	GatewayAddressType string
 )
 
 const (
@@ -354,6 +728,18 @@ const (
 	Openstack                            = "openstack"
 )
 
+const (
+	ImagePolicyTag    ImagePolicyMode = "Tag"
+	ImagePolicyDigest ImagePolicyMode = "Digest"
+)
+
+const (
+	GatewayAddressPublic       GatewayAddressType = "Public"
+	GatewayAddressPrivate      GatewayAddressType = "Private"
+	GatewayAddressLoadBalancer GatewayAddressType = "LoadBalancer"
+	GatewayAddressSecondary    GatewayAddressType = "Secondary"
+)
+
 func (s *Submariner) UnmarshalJSON(data []byte) error {
 	type submarinerAlias Submariner
 
@@ -0,0 +1,135 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
+
+// +kubebuilder:validation:Enum=Connectivity;ServiceDiscovery;GatewayFailover
+type VerificationSuite string
+
+const (
+	// VerificationConnectivity verifies the connectivity between pods and services of both clusters.
+	VerificationConnectivity VerificationSuite = "Connectivity"
+	// VerificationServiceDiscovery verifies the discovery of the services exported by the remote cluster.
+	VerificationServiceDiscovery VerificationSuite = "ServiceDiscovery"
+	// VerificationGatewayFailover verifies the connectivity across a gateway failover; this disrupts the connections.
+	VerificationGatewayFailover VerificationSuite = "GatewayFailover"
+)
+
+type VerificationRunPhase string
+
+const (
+	VerificationRunRunning   VerificationRunPhase = "Running"
+	VerificationRunSucceeded VerificationRunPhase = "Succeeded"
+	VerificationRunFailed    VerificationRunPhase = "Failed"
+)
+
+// VerificationRunSpec defines the verification suites to run against a remote cluster.
+type VerificationRunSpec struct {
+	// The verification suites to run.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Suites"
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	Suites []VerificationSuite `json:"suites"`
+
+	// The Secret, in the VerificationRun's namespace, containing the kubeconfig of the remote cluster in its
+	// kubeconfig entry.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remote Kubeconfig Secret"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:io.kubernetes:Secret"}
+	RemoteKubeConfigSecret string `json:"remoteKubeConfigSecret"`
+
+	// The service account the verification Job runs as. It must be allowed to deploy the test workloads in the local
+	// cluster.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Service Account"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:io.kubernetes:ServiceAccount"}
+	ServiceAccountName string `json:"serviceAccountName"`
+
+	// The subctl image running the verification; defaults to the image matching the deployed Submariner version.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image"
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced"}
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// The maximum duration of the run, after which it fails.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Timeout"
+	// +kubebuilder:default="30m"
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// VerificationRunStatus defines the observed state of VerificationRun.
+type VerificationRunStatus struct {
+	// The phase of the run.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Phase"
+	// +optional
+	Phase VerificationRunPhase `json:"phase,omitempty"`
+
+	// The Job running the verification.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Job"
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// When the run started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// When the run succeeded or failed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// The reason of a failure, including the last lines of the verification logs.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Message"
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=verificationruns,scope=Namespaced,categories=submariner-io
+//+kubebuilder:printcolumn:name="Suites",type="string",JSONPath=".spec.suites"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VerificationRun runs the Submariner verification suites against a remote cluster, as a Job in the local cluster, and
+// records their outcome. Verifications can be scheduled, for example after upgrades, by creating VerificationRuns.
+// +operator-sdk:csv:customresourcedefinitions:displayName="Verification Run"
+type VerificationRun struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VerificationRunSpec   `json:"spec,omitempty"`
+	Status VerificationRunStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// VerificationRunList contains a list of VerificationRun.
+type VerificationRunList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VerificationRun `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VerificationRun{}, &VerificationRunList{})
+}
@@ -26,7 +26,9 @@ package v1alpha1
 import (
 	submariner_iov1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	appsv1 "k8s.io/api/apps/v1"
-	"k8s.io/api/core/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -36,21 +38,773 @@ func (in *Broker) DeepCopyInto(out *Broker) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Broker.
+func (in *Broker) DeepCopy() *Broker {
+	if in == nil {
+		return nil
+	}
+	out := new(Broker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Broker) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerList) DeepCopyInto(out *BrokerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Broker, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerList.
+func (in *BrokerList) DeepCopy() *BrokerList {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BrokerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerRBACRepair) DeepCopyInto(out *BrokerRBACRepair) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.Problems != nil {
+		in, out := &in.Problems, &out.Problems
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerRBACRepair.
+func (in *BrokerRBACRepair) DeepCopy() *BrokerRBACRepair {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerRBACRepair)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerSpec) DeepCopyInto(out *BrokerSpec) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultCustomDomains != nil {
+		in, out := &in.DefaultCustomDomains, &out.DefaultCustomDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterTokenRotation != nil {
+		in, out := &in.ClusterTokenRotation, &out.ClusterTokenRotation
+		*out = new(ClusterTokenRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaleClusterCleanup != nil {
+		in, out := &in.StaleClusterCleanup, &out.StaleClusterCleanup
+		*out = new(StaleClusterCleanup)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FederatedClusterSubjects != nil {
+		in, out := &in.FederatedClusterSubjects, &out.FederatedClusterSubjects
+		*out = make([]v1.Subject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Observers != nil {
+		in, out := &in.Observers, &out.Observers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterEnrollment != nil {
+		in, out := &in.ClusterEnrollment, &out.ClusterEnrollment
+		*out = new(ClusterEnrollment)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerSpec.
+func (in *BrokerSpec) DeepCopy() *BrokerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BrokerStatus) DeepCopyInto(out *BrokerStatus) {
+	*out = *in
+	if in.ObjectCounts != nil {
+		in, out := &in.ObjectCounts, &out.ObjectCounts
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ObjectCountWarnings != nil {
+		in, out := &in.ObjectCountWarnings, &out.ObjectCountWarnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.GlobalnetAllocations != nil {
+		in, out := &in.GlobalnetAllocations, &out.GlobalnetAllocations
+		*out = make([]GlobalnetAllocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GlobalnetConflicts != nil {
+		in, out := &in.GlobalnetConflicts, &out.GlobalnetConflicts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterTokens != nil {
+		in, out := &in.ClusterTokens, &out.ClusterTokens
+		*out = make([]ClusterToken, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StaleClusterServiceAccounts != nil {
+		in, out := &in.StaleClusterServiceAccounts, &out.StaleClusterServiceAccounts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaleClusterEndpointSlices != nil {
+		in, out := &in.StaleClusterEndpointSlices, &out.StaleClusterEndpointSlices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingClusters != nil {
+		in, out := &in.PendingClusters, &out.PendingClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastRBACRepair != nil {
+		in, out := &in.LastRBACRepair, &out.LastRBACRepair
+		*out = new(BrokerRBACRepair)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObserverTokens != nil {
+		in, out := &in.ObserverTokens, &out.ObserverTokens
+		*out = make([]ObserverToken, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerStatus.
+func (in *BrokerStatus) DeepCopy() *BrokerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BrokerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEnrollment) DeepCopyInto(out *ClusterEnrollment) {
+	*out = *in
+	if in.ApprovedClusters != nil {
+		in, out := &in.ApprovedClusters, &out.ApprovedClusters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutoApprovePatterns != nil {
+		in, out := &in.AutoApprovePatterns, &out.AutoApprovePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEnrollment.
+func (in *ClusterEnrollment) DeepCopy() *ClusterEnrollment {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterEnrollment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterMetadata) DeepCopyInto(out *ClusterMetadata) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterMetadata.
+func (in *ClusterMetadata) DeepCopy() *ClusterMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetwork) DeepCopyInto(out *ClusterNetwork) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetwork.
+func (in *ClusterNetwork) DeepCopy() *ClusterNetwork {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetwork)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNetwork) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkList) DeepCopyInto(out *ClusterNetworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterNetwork, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkList.
+func (in *ClusterNetworkList) DeepCopy() *ClusterNetworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterNetworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNetworkStatus) DeepCopyInto(out *ClusterNetworkStatus) {
+	*out = *in
+	if in.PodCIDRs != nil {
+		in, out := &in.PodCIDRs, &out.PodCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceCIDRs != nil {
+		in, out := &in.ServiceCIDRs, &out.ServiceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PluginSettings != nil {
+		in, out := &in.PluginSettings, &out.PluginSettings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.DiscoveryTime.DeepCopyInto(&out.DiscoveryTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNetworkStatus.
+func (in *ClusterNetworkStatus) DeepCopy() *ClusterNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterToken) DeepCopyInto(out *ClusterToken) {
+	*out = *in
+	in.IssueTime.DeepCopyInto(&out.IssueTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterToken.
+func (in *ClusterToken) DeepCopy() *ClusterToken {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTokenRotation) DeepCopyInto(out *ClusterTokenRotation) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RotateIssuedBefore != nil {
+		in, out := &in.RotateIssuedBefore, &out.RotateIssuedBefore
+		*out = (*in).DeepCopy()
+	}
+	if in.ClusterIDs != nil {
+		in, out := &in.ClusterIDs, &out.ClusterIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OverlapPeriod != nil {
+		in, out := &in.OverlapPeriod, &out.OverlapPeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTokenRotation.
+func (in *ClusterTokenRotation) DeepCopy() *ClusterTokenRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTokenRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentUpdateStrategy) DeepCopyInto(out *ComponentUpdateStrategy) {
+	*out = *in
+	if in.DaemonSet != nil {
+		in, out := &in.DaemonSet, &out.DaemonSet
+		*out = new(appsv1.DaemonSetUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(appsv1.DeploymentStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentUpdateStrategy.
+func (in *ComponentUpdateStrategy) DeepCopy() *ComponentUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionAvailability) DeepCopyInto(out *ConnectionAvailability) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionAvailability.
+func (in *ConnectionAvailability) DeepCopy() *ConnectionAvailability {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionAvailability)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectivitySLO) DeepCopyInto(out *ConnectivitySLO) {
+	*out = *in
+	if in.SampleInterval != nil {
+		in, out := &in.SampleInterval, &out.SampleInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]metav1.Duration, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectivitySLO.
+func (in *ConnectivitySLO) DeepCopy() *ConnectivitySLO {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectivitySLO)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSCustomConfig) DeepCopyInto(out *CoreDNSCustomConfig) {
+	*out = *in
+	if in.ServerBlocks != nil {
+		in, out := &in.ServerBlocks, &out.ServerBlocks
+		*out = make([]CoreDNSServerBlock, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSCustomConfig.
+func (in *CoreDNSCustomConfig) DeepCopy() *CoreDNSCustomConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSCustomConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoreDNSServerBlock) DeepCopyInto(out *CoreDNSServerBlock) {
+	*out = *in
+	if in.Upstreams != nil {
+		in, out := &in.Upstreams, &out.Upstreams
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSServerBlock.
+func (in *CoreDNSServerBlock) DeepCopy() *CoreDNSServerBlock {
+	if in == nil {
+		return nil
+	}
+	out := new(CoreDNSServerBlock)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DaemonSetStatusWrapper) DeepCopyInto(out *DaemonSetStatusWrapper) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(appsv1.DaemonSetStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NonReadyContainerStates != nil {
+		in, out := &in.NonReadyContainerStates, &out.NonReadyContainerStates
+		*out = new([]corev1.ContainerState)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]corev1.ContainerState, len(*in))
+			for i := range *in {
+				(*in)[i].DeepCopyInto(&(*out)[i])
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetStatusWrapper.
+func (in *DaemonSetStatusWrapper) DeepCopy() *DaemonSetStatusWrapper {
+	if in == nil {
+		return nil
+	}
+	out := new(DaemonSetStatusWrapper)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentInfo) DeepCopyInto(out *DeploymentInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentInfo.
+func (in *DeploymentInfo) DeepCopy() *DeploymentInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EndpointMetadata) DeepCopyInto(out *EndpointMetadata) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EndpointMetadata.
+func (in *EndpointMetadata) DeepCopy() *EndpointMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(EndpointMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EvictionProtection) DeepCopyInto(out *EvictionProtection) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EvictionProtection.
+func (in *EvictionProtection) DeepCopy() *EvictionProtection {
+	if in == nil {
+		return nil
+	}
+	out := new(EvictionProtection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalDNSConfig) DeepCopyInto(out *ExternalDNSConfig) {
+	*out = *in
+	if in.ServiceImportSelector != nil {
+		in, out := &in.ServiceImportSelector, &out.ServiceImportSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDNSConfig.
+func (in *ExternalDNSConfig) DeepCopy() *ExternalDNSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalDNSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayAddress) DeepCopyInto(out *GatewayAddress) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAddress.
+func (in *GatewayAddress) DeepCopy() *GatewayAddress {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayAddress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayDrain) DeepCopyInto(out *GatewayDrain) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDrain.
+func (in *GatewayDrain) DeepCopy() *GatewayDrain {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayDrain)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayDrainStatus) DeepCopyInto(out *GatewayDrainStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayDrainStatus.
+func (in *GatewayDrainStatus) DeepCopy() *GatewayDrainStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayDrainStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayFailoverStatus) DeepCopyInto(out *GatewayFailoverStatus) {
+	*out = *in
+	if in.LastFailoverTime != nil {
+		in, out := &in.LastFailoverTime, &out.LastFailoverTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayFailoverStatus.
+func (in *GatewayFailoverStatus) DeepCopy() *GatewayFailoverStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayFailoverStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GlobalnetAllocation) DeepCopyInto(out *GlobalnetAllocation) {
+	*out = *in
+	if in.GlobalCIDRs != nil {
+		in, out := &in.GlobalCIDRs, &out.GlobalCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GlobalnetAllocation.
+func (in *GlobalnetAllocation) DeepCopy() *GlobalnetAllocation {
+	if in == nil {
+		return nil
+	}
+	out := new(GlobalnetAllocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckSpec) DeepCopyInto(out *HealthCheckSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSpec.
+func (in *HealthCheckSpec) DeepCopy() *HealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePolicy) DeepCopyInto(out *ImagePolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePolicy.
+func (in *ImagePolicy) DeepCopy() *ImagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePolicy)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Broker.
-func (in *Broker) DeepCopy() *Broker {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IstioServiceEntriesConfig) DeepCopyInto(out *IstioServiceEntriesConfig) {
+	*out = *in
+	if in.ServiceImportSelector != nil {
+		in, out := &in.ServiceImportSelector, &out.ServiceImportSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExportTo != nil {
+		in, out := &in.ExportTo, &out.ExportTo
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IstioServiceEntriesConfig.
+func (in *IstioServiceEntriesConfig) DeepCopy() *IstioServiceEntriesConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(Broker)
+	out := new(IstioServiceEntriesConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JoinRequest) DeepCopyInto(out *JoinRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinRequest.
+func (in *JoinRequest) DeepCopy() *JoinRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(JoinRequest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Broker) DeepCopyObject() runtime.Object {
+func (in *JoinRequest) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -58,31 +812,31 @@ func (in *Broker) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BrokerList) DeepCopyInto(out *BrokerList) {
+func (in *JoinRequestList) DeepCopyInto(out *JoinRequestList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Broker, len(*in))
+		*out = make([]JoinRequest, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerList.
-func (in *BrokerList) DeepCopy() *BrokerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinRequestList.
+func (in *JoinRequestList) DeepCopy() *JoinRequestList {
 	if in == nil {
 		return nil
 	}
-	out := new(BrokerList)
+	out := new(JoinRequestList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *BrokerList) DeepCopyObject() runtime.Object {
+func (in *JoinRequestList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -90,137 +844,120 @@ func (in *BrokerList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BrokerSpec) DeepCopyInto(out *BrokerSpec) {
+func (in *JoinRequestSpec) DeepCopyInto(out *JoinRequestSpec) {
 	*out = *in
-	if in.Components != nil {
-		in, out := &in.Components, &out.Components
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.DefaultCustomDomains != nil {
-		in, out := &in.DefaultCustomDomains, &out.DefaultCustomDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.GatewayNodeSelector != nil {
+		in, out := &in.GatewayNodeSelector, &out.GatewayNodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerSpec.
-func (in *BrokerSpec) DeepCopy() *BrokerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinRequestSpec.
+func (in *JoinRequestSpec) DeepCopy() *JoinRequestSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BrokerSpec)
+	out := new(JoinRequestSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BrokerStatus) DeepCopyInto(out *BrokerStatus) {
+func (in *JoinRequestStatus) DeepCopyInto(out *JoinRequestStatus) {
 	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BrokerStatus.
-func (in *BrokerStatus) DeepCopy() *BrokerStatus {
-	if in == nil {
-		return nil
+	if in.GatewayNodes != nil {
+		in, out := &in.GatewayNodes, &out.GatewayNodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	out := new(BrokerStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CoreDNSCustomConfig) DeepCopyInto(out *CoreDNSCustomConfig) {
-	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreDNSCustomConfig.
-func (in *CoreDNSCustomConfig) DeepCopy() *CoreDNSCustomConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JoinRequestStatus.
+func (in *JoinRequestStatus) DeepCopy() *JoinRequestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CoreDNSCustomConfig)
+	out := new(JoinRequestStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DaemonSetStatusWrapper) DeepCopyInto(out *DaemonSetStatusWrapper) {
+func (in *LoadBalancerStatusWrapper) DeepCopyInto(out *LoadBalancerStatusWrapper) {
 	*out = *in
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
-		*out = new(appsv1.DaemonSetStatus)
+		*out = new(corev1.LoadBalancerStatus)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.NonReadyContainerStates != nil {
-		in, out := &in.NonReadyContainerStates, &out.NonReadyContainerStates
-		*out = new([]v1.ContainerState)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]v1.ContainerState, len(*in))
-			for i := range *in {
-				(*in)[i].DeepCopyInto(&(*out)[i])
-			}
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DaemonSetStatusWrapper.
-func (in *DaemonSetStatusWrapper) DeepCopy() *DaemonSetStatusWrapper {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerStatusWrapper.
+func (in *LoadBalancerStatusWrapper) DeepCopy() *LoadBalancerStatusWrapper {
 	if in == nil {
 		return nil
 	}
-	out := new(DaemonSetStatusWrapper)
+	out := new(LoadBalancerStatusWrapper)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentInfo) DeepCopyInto(out *DeploymentInfo) {
+func (in *MetricsProxyAuth) DeepCopyInto(out *MetricsProxyAuth) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentInfo.
-func (in *DeploymentInfo) DeepCopy() *DeploymentInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsProxyAuth.
+func (in *MetricsProxyAuth) DeepCopy() *MetricsProxyAuth {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentInfo)
+	out := new(MetricsProxyAuth)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HealthCheckSpec) DeepCopyInto(out *HealthCheckSpec) {
+func (in *NodePlacement) DeepCopyInto(out *NodePlacement) {
 	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSpec.
-func (in *HealthCheckSpec) DeepCopy() *HealthCheckSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePlacement.
+func (in *NodePlacement) DeepCopy() *NodePlacement {
 	if in == nil {
 		return nil
 	}
-	out := new(HealthCheckSpec)
+	out := new(NodePlacement)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LoadBalancerStatusWrapper) DeepCopyInto(out *LoadBalancerStatusWrapper) {
+func (in *ObserverToken) DeepCopyInto(out *ObserverToken) {
 	*out = *in
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(v1.LoadBalancerStatus)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerStatusWrapper.
-func (in *LoadBalancerStatusWrapper) DeepCopy() *LoadBalancerStatusWrapper {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObserverToken.
+func (in *ObserverToken) DeepCopy() *ObserverToken {
 	if in == nil {
 		return nil
 	}
-	out := new(LoadBalancerStatusWrapper)
+	out := new(ObserverToken)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -290,7 +1027,7 @@ func (in *ServiceDiscoverySpec) DeepCopyInto(out *ServiceDiscoverySpec) {
 	if in.CoreDNSCustomConfig != nil {
 		in, out := &in.CoreDNSCustomConfig, &out.CoreDNSCustomConfig
 		*out = new(CoreDNSCustomConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.CustomDomains != nil {
 		in, out := &in.CustomDomains, &out.CustomDomains
@@ -304,6 +1041,23 @@ func (in *ServiceDiscoverySpec) DeepCopyInto(out *ServiceDiscoverySpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.UpdateStrategies != nil {
+		in, out := &in.UpdateStrategies, &out.UpdateStrategies
+		*out = make(map[string]ComponentUpdateStrategy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(ExternalDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IstioServiceEntries != nil {
+		in, out := &in.IstioServiceEntries, &out.IstioServiceEntries
+		*out = new(IstioServiceEntriesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -313,7 +1067,7 @@ func (in *ServiceDiscoverySpec) DeepCopyInto(out *ServiceDiscoverySpec) {
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -346,6 +1100,26 @@ func (in *ServiceDiscoveryStatus) DeepCopy() *ServiceDiscoveryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StaleClusterCleanup) DeepCopyInto(out *StaleClusterCleanup) {
+	*out = *in
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StaleClusterCleanup.
+func (in *StaleClusterCleanup) DeepCopy() *StaleClusterCleanup {
+	if in == nil {
+		return nil
+	}
+	out := new(StaleClusterCleanup)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Submariner) DeepCopyInto(out *Submariner) {
 	*out = *in
@@ -408,16 +1182,56 @@ func (in *SubmarinerList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubmarinerSpec) DeepCopyInto(out *SubmarinerSpec) {
 	*out = *in
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(ClusterMetadata)
+		**out = **in
+	}
+	if in.GatewayAddresses != nil {
+		in, out := &in.GatewayAddresses, &out.GatewayAddresses
+		*out = make([]GatewayAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.GatewayDrain != nil {
+		in, out := &in.GatewayDrain, &out.GatewayDrain
+		*out = new(GatewayDrain)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EvictionProtection != nil {
+		in, out := &in.EvictionProtection, &out.EvictionProtection
+		*out = new(EvictionProtection)
+		**out = **in
+	}
+	if in.ConnectivitySLO != nil {
+		in, out := &in.ConnectivitySLO, &out.ConnectivitySLO
+		*out = new(ConnectivitySLO)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndpointMetadata != nil {
+		in, out := &in.EndpointMetadata, &out.EndpointMetadata
+		*out = new(EndpointMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CoreDNSCustomConfig != nil {
 		in, out := &in.CoreDNSCustomConfig, &out.CoreDNSCustomConfig
 		*out = new(CoreDNSCustomConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.CustomDomains != nil {
 		in, out := &in.CustomDomains, &out.CustomDomains
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExternalDNS != nil {
+		in, out := &in.ExternalDNS, &out.ExternalDNS
+		*out = new(ExternalDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IstioServiceEntries != nil {
+		in, out := &in.IstioServiceEntries, &out.IstioServiceEntries
+		*out = new(IstioServiceEntriesConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ImageOverrides != nil {
 		in, out := &in.ImageOverrides, &out.ImageOverrides
 		*out = make(map[string]string, len(*in))
@@ -425,11 +1239,46 @@ func (in *SubmarinerSpec) DeepCopyInto(out *SubmarinerSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.ArchitectureImageOverrides != nil {
+		in, out := &in.ArchitectureImageOverrides, &out.ArchitectureImageOverrides
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.UpdateStrategies != nil {
+		in, out := &in.UpdateStrategies, &out.UpdateStrategies
+		*out = make(map[string]ComponentUpdateStrategy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.ImagePolicy != nil {
+		in, out := &in.ImagePolicy, &out.ImagePolicy
+		*out = new(ImagePolicy)
+		**out = **in
+	}
 	if in.ConnectionHealthCheck != nil {
 		in, out := &in.ConnectionHealthCheck, &out.ConnectionHealthCheck
 		*out = new(HealthCheckSpec)
 		**out = **in
 	}
+	if in.MetricsProxyAuth != nil {
+		in, out := &in.MetricsProxyAuth, &out.MetricsProxyAuth
+		*out = new(MetricsProxyAuth)
+		**out = **in
+	}
 	if in.NodeSelector != nil {
 		in, out := &in.NodeSelector, &out.NodeSelector
 		*out = make(map[string]string, len(*in))
@@ -439,11 +1288,16 @@ func (in *SubmarinerSpec) DeepCopyInto(out *SubmarinerSpec) {
 	}
 	if in.Tolerations != nil {
 		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
+		*out = make([]corev1.Toleration, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodePlacement != nil {
+		in, out := &in.NodePlacement, &out.NodePlacement
+		*out = new(NodePlacement)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubmarinerSpec.
@@ -459,6 +1313,11 @@ func (in *SubmarinerSpec) DeepCopy() *SubmarinerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubmarinerStatus) DeepCopyInto(out *SubmarinerStatus) {
 	*out = *in
+	if in.ClusterMetadata != nil {
+		in, out := &in.ClusterMetadata, &out.ClusterMetadata
+		*out = new(ClusterMetadata)
+		**out = **in
+	}
 	in.GatewayDaemonSetStatus.DeepCopyInto(&out.GatewayDaemonSetStatus)
 	in.RouteAgentDaemonSetStatus.DeepCopyInto(&out.RouteAgentDaemonSetStatus)
 	in.GlobalnetDaemonSetStatus.DeepCopyInto(&out.GlobalnetDaemonSetStatus)
@@ -474,7 +1333,27 @@ func (in *SubmarinerStatus) DeepCopyInto(out *SubmarinerStatus) {
 			}
 		}
 	}
+	if in.GatewayFailover != nil {
+		in, out := &in.GatewayFailover, &out.GatewayFailover
+		*out = new(GatewayFailoverStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GatewayDrain != nil {
+		in, out := &in.GatewayDrain, &out.GatewayDrain
+		*out = new(GatewayDrainStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	out.DeploymentInfo = in.DeploymentInfo
+	if in.ConnectionAvailability != nil {
+		in, out := &in.ConnectionAvailability, &out.ConnectionAvailability
+		*out = make([]ConnectionAvailability, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeArchitectures != nil {
+		in, out := &in.NodeArchitectures, &out.NodeArchitectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubmarinerStatus.
@@ -486,3 +1365,110 @@ func (in *SubmarinerStatus) DeepCopy() *SubmarinerStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationRun) DeepCopyInto(out *VerificationRun) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationRun.
+func (in *VerificationRun) DeepCopy() *VerificationRun {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VerificationRun) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationRunList) DeepCopyInto(out *VerificationRunList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VerificationRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationRunList.
+func (in *VerificationRunList) DeepCopy() *VerificationRunList {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationRunList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VerificationRunList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationRunSpec) DeepCopyInto(out *VerificationRunSpec) {
+	*out = *in
+	if in.Suites != nil {
+		in, out := &in.Suites, &out.Suites
+		*out = make([]VerificationSuite, len(*in))
+		copy(*out, *in)
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationRunSpec.
+func (in *VerificationRunSpec) DeepCopy() *VerificationRunSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationRunSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VerificationRunStatus) DeepCopyInto(out *VerificationRunStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VerificationRunStatus.
+func (in *VerificationRunStatus) DeepCopy() *VerificationRunStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VerificationRunStatus)
+	in.DeepCopyInto(out)
+	return out
+}
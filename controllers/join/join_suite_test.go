@@ -0,0 +1,139 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package join_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/log/kzerolog"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/controllers/join"
+	"github.com/submariner-io/submariner-operator/controllers/test"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	joinRequestName     = "join"
+	brokerSecretName    = "broker-credentials"
+	submarinerNamespace = "test-ns"
+)
+
+var _ = BeforeSuite(func() {
+	Expect(v1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+})
+
+var _ = Describe("", func() {
+	kzerolog.InitK8sLogging()
+})
+
+func TestJoin(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Join Test Suite")
+}
+
+type testDriver struct {
+	test.Driver
+	joinRequest *v1alpha1.JoinRequest
+}
+
+func newTestDriver() *testDriver {
+	t := &testDriver{
+		Driver: test.Driver{
+			Namespace:    submarinerNamespace,
+			ResourceName: joinRequestName,
+		},
+	}
+
+	BeforeEach(func() {
+		t.BeforeEach()
+		t.joinRequest = &v1alpha1.JoinRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      joinRequestName,
+				Namespace: submarinerNamespace,
+			},
+			Spec: v1alpha1.JoinRequestSpec{
+				ClusterID:                "east",
+				BrokerK8sApiServer:       "https://broker.example.com:6443",
+				BrokerK8sRemoteNamespace: "submariner-k8s-broker",
+				BrokerSecret:             brokerSecretName,
+				CableDriver:              "libreswan",
+				ServiceDiscoveryEnabled:  true,
+			},
+		}
+		t.InitScopedClientObjs = []controllerClient.Object{t.joinRequest}
+	})
+
+	JustBeforeEach(func() {
+		t.JustBeforeEach()
+
+		t.Controller = &join.Reconciler{
+			ScopedClient:  t.ScopedClient,
+			GeneralClient: t.GeneralClient,
+		}
+	})
+
+	return t
+}
+
+func (t *testDriver) getJoinRequest(ctx context.Context) *v1alpha1.JoinRequest {
+	joinRequest := &v1alpha1.JoinRequest{}
+	Expect(t.ScopedClient.Get(ctx, controllerClient.ObjectKeyFromObject(t.joinRequest), joinRequest)).To(Succeed())
+
+	return joinRequest
+}
+
+func (t *testDriver) getSubmariner(ctx context.Context) (*v1alpha1.Submariner, error) {
+	submariner := &v1alpha1.Submariner{}
+	err := t.ScopedClient.Get(ctx, types.NamespacedName{Namespace: submarinerNamespace, Name: "submariner"}, submariner)
+
+	return submariner, err
+}
+
+func (t *testDriver) getNode(ctx context.Context, name string) *corev1.Node {
+	node := &corev1.Node{}
+	Expect(t.GeneralClient.Get(ctx, types.NamespacedName{Name: name}, node)).To(Succeed())
+
+	return node
+}
+
+func newBrokerSecret(data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      brokerSecretName,
+			Namespace: submarinerNamespace,
+		},
+		Data: data,
+	}
+}
+
+func newNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: labels,
+		},
+	}
+}
@@ -0,0 +1,380 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package join
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	submarinerv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var log = logf.Log.WithName("controller_join")
+
+const (
+	gatewayNodeLabel = "submariner.io/gateway"
+
+	// The entry holding the IPsec Pre-Shared Key in the broker credentials Secret.
+	pskSecretKey = "psk"
+
+	// The IPsec ports used by subctl join.
+	defaultIKEPort  = 500
+	defaultNATTPort = 4500
+
+	// Nodes aren't watched, so the join is retried periodically while waiting for a gateway node.
+	gatewayNodeRetryInterval = time.Minute
+)
+
+// The entries expected in the broker credentials Secret, see the broker secret syncer.
+var brokerSecretKeys = []string{corev1.ServiceAccountTokenKey, corev1.ServiceAccountRootCAKey}
+
+// The cable drivers which don't use the IPsec Pre-Shared Key; the default libreswan driver does.
+var cableDriversWithoutPSK = sets.New("vxlan", "wireguard")
+
+// The labels of the control plane nodes, which aren't picked as gateways by default.
+var controlPlaneNodeLabels = []string{"node-role.kubernetes.io/control-plane", "node-role.kubernetes.io/master"}
+
+// Reconciler reconciles a JoinRequest object.
+type Reconciler struct {
+	// This client is scoped to the operator namespace intended to only be used for resources created and maintained by this
+	// controller. Also it's a split client that reads objects from the cache and writes to the apiserver.
+	ScopedClient controllerClient.Client
+	// This client can be used to access any other resource not in the operator namespace, here the nodes.
+	GeneralClient controllerClient.Client
+}
+
+//+kubebuilder:rbac:groups=submariner.io,resources=joinrequests,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=submariner.io,resources=joinrequests/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=submariner.io,resources=submariners,verbs=get;create
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;patch
+
+// Reconcile joins the cluster as requested by a JoinRequest, once, and records the outcome in its status.
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.V(2).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling JoinRequest")
+
+	instance := &submarinerv1alpha1.JoinRequest{}
+
+	err := r.ScopedClient.Get(ctx, request.NamespacedName, instance)
+	if apierrors.IsNotFound(err) {
+		// The Submariner resource outlives the JoinRequest
+		return reconcile.Result{}, nil
+	}
+
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "error retrieving JoinRequest resource")
+	}
+
+	if instance.Status.Phase == submarinerv1alpha1.JoinRequestJoined ||
+		instance.Status.Phase == submarinerv1alpha1.JoinRequestFailed {
+		return reconcile.Result{}, nil
+	}
+
+	status := instance.Status.DeepCopy()
+
+	waitingForNodes, err := r.join(ctx, instance, status)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	var result reconcile.Result
+	if waitingForNodes {
+		result.RequeueAfter = gatewayNodeRetryInterval
+	}
+
+	if reflect.DeepEqual(status, &instance.Status) {
+		return result, nil
+	}
+
+	reqLogger.Info("Join progressed", "phase", status.Phase, "message", status.Message)
+
+	instance.Status = *status
+
+	return result, errors.Wrap(r.ScopedClient.Status().Update(ctx, instance), "error updating the JoinRequest status")
+}
+
+// join checks the prerequisites of the join, labels the gateway nodes and creates the Submariner resource, recording
+// the progress in the given status. It returns true if the join is waiting for a gateway node.
+func (r *Reconciler) join(ctx context.Context, instance *submarinerv1alpha1.JoinRequest,
+	status *submarinerv1alpha1.JoinRequestStatus,
+) (bool, error) {
+	joined, err := r.alreadyJoined(ctx, instance, status)
+	if joined || err != nil {
+		return false, err
+	}
+
+	secret := &corev1.Secret{}
+
+	err = r.ScopedClient.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.BrokerSecret}, secret)
+	if apierrors.IsNotFound(err) {
+		setPending(status, fmt.Sprintf("Waiting for the broker credentials Secret %q", instance.Spec.BrokerSecret))
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrapf(err, "error retrieving the broker credentials Secret %q", instance.Spec.BrokerSecret)
+	}
+
+	for _, key := range brokerSecretKeys {
+		if len(secret.Data[key]) == 0 {
+			setPending(status, fmt.Sprintf("The broker credentials Secret %q has no %s entry", secret.Name, key))
+			return false, nil
+		}
+	}
+
+	withPSK := len(secret.Data[pskSecretKey]) > 0
+	if !withPSK && !cableDriversWithoutPSK.Has(instance.Spec.CableDriver) {
+		setPending(status, fmt.Sprintf("The broker credentials Secret %q has no %s entry, which the IPsec cable driver requires",
+			secret.Name, pskSecretKey))
+		return false, nil
+	}
+
+	nodeSelector := labels.Everything()
+	if instance.Spec.GatewayNodeSelector != nil {
+		nodeSelector, err = metav1.LabelSelectorAsSelector(instance.Spec.GatewayNodeSelector)
+		if err != nil {
+			status.Phase = submarinerv1alpha1.JoinRequestFailed
+			status.Message = fmt.Sprintf("Invalid gateway node selector: %v", err)
+
+			return false, nil
+		}
+	}
+
+	status.GatewayNodes, err = r.labelGatewayNodes(ctx, nodeSelector, instance.Spec.GatewayNodeSelector == nil)
+	if err != nil {
+		return false, err
+	}
+
+	if len(status.GatewayNodes) == 0 {
+		setPending(status, "Waiting for a node to label as gateway")
+		return true, nil
+	}
+
+	submariner := newSubmariner(instance, withPSK)
+
+	err = r.ScopedClient.Create(ctx, submariner)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return false, errors.Wrap(err, "error creating the Submariner resource")
+	}
+
+	log.Info("Joined the cluster to the clusterset", "clusterID", instance.Spec.ClusterID, "gatewayNodes", status.GatewayNodes)
+
+	status.Phase = submarinerv1alpha1.JoinRequestJoined
+	status.Message = ""
+
+	return false, nil
+}
+
+// alreadyJoined checks whether the cluster has already joined, with the same configuration or not.
+func (r *Reconciler) alreadyJoined(ctx context.Context, instance *submarinerv1alpha1.JoinRequest,
+	status *submarinerv1alpha1.JoinRequestStatus,
+) (bool, error) {
+	existing := &submarinerv1alpha1.Submariner{}
+
+	err := r.ScopedClient.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: opnames.SubmarinerCrName}, existing)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, errors.Wrap(err, "error retrieving the Submariner resource")
+	}
+
+	if existing.Spec.ClusterID != instance.Spec.ClusterID || existing.Spec.BrokerK8sApiServer != instance.Spec.BrokerK8sApiServer ||
+		existing.Spec.BrokerK8sRemoteNamespace != instance.Spec.BrokerK8sRemoteNamespace {
+		status.Phase = submarinerv1alpha1.JoinRequestFailed
+		status.Message = fmt.Sprintf("The cluster has already joined as %q, with broker %s in namespace %q", existing.Spec.ClusterID,
+			existing.Spec.BrokerK8sApiServer, existing.Spec.BrokerK8sRemoteNamespace)
+	} else {
+		status.Phase = submarinerv1alpha1.JoinRequestJoined
+		status.Message = ""
+	}
+
+	return true, nil
+}
+
+// labelGatewayNodes labels the nodes matching the given selector as gateways. When the selector is the default one,
+// the nodes already labeled as gateways are used if any, otherwise the first worker node is labeled.
+func (r *Reconciler) labelGatewayNodes(ctx context.Context, selector labels.Selector, defaultSelector bool) ([]string, error) {
+	nodeList := &corev1.NodeList{}
+
+	err := r.GeneralClient.List(ctx, nodeList)
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing the nodes")
+	}
+
+	nodes := nodeList.Items
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].Name < nodes[j].Name
+	})
+
+	var gateways []*corev1.Node
+
+	for i := range nodes {
+		if defaultSelector && nodes[i].Labels[gatewayNodeLabel] != "true" {
+			continue
+		}
+
+		if selector.Matches(labels.Set(nodes[i].Labels)) {
+			gateways = append(gateways, &nodes[i])
+		}
+	}
+
+	if defaultSelector && len(gateways) == 0 {
+		if node := firstWorkerNode(nodes); node != nil {
+			gateways = append(gateways, node)
+		}
+	}
+
+	names := make([]string, len(gateways))
+
+	for i, node := range gateways {
+		names[i] = node.Name
+
+		if node.Labels[gatewayNodeLabel] == "true" {
+			continue
+		}
+
+		original := node.DeepCopy()
+
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+
+		node.Labels[gatewayNodeLabel] = "true"
+
+		if err := r.GeneralClient.Patch(ctx, node, controllerClient.MergeFrom(original)); err != nil {
+			return nil, errors.Wrapf(err, "error labeling the gateway node %q", node.Name)
+		}
+
+		log.Info("Labeled the gateway node", "node", node.Name)
+	}
+
+	return names, nil
+}
+
+// firstWorkerNode returns the first of the given nodes which isn't a control plane node, or the first node if they all
+// are, as on single node clusters.
+func firstWorkerNode(nodes []corev1.Node) *corev1.Node {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	for i := range nodes {
+		if !isControlPlaneNode(&nodes[i]) {
+			return &nodes[i]
+		}
+	}
+
+	return &nodes[0]
+}
+
+func isControlPlaneNode(node *corev1.Node) bool {
+	for _, label := range controlPlaneNodeLabels {
+		if _, ok := node.Labels[label]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func setPending(status *submarinerv1alpha1.JoinRequestStatus, message string) {
+	status.Phase = submarinerv1alpha1.JoinRequestPending
+	status.Message = message
+}
+
+// newSubmariner returns the Submariner resource joining the cluster as requested; the Submariner controller detects the
+// CIDRs which aren't specified.
+func newSubmariner(instance *submarinerv1alpha1.JoinRequest, withPSK bool) *submarinerv1alpha1.Submariner {
+	submariner := &submarinerv1alpha1.Submariner{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      opnames.SubmarinerCrName,
+		},
+		Spec: submarinerv1alpha1.SubmarinerSpec{
+			Broker:                   "k8s",
+			BrokerK8sApiServer:       instance.Spec.BrokerK8sApiServer,
+			BrokerK8sSecret:          instance.Spec.BrokerSecret,
+			BrokerK8sRemoteNamespace: instance.Spec.BrokerK8sRemoteNamespace,
+			CableDriver:              instance.Spec.CableDriver,
+			CeIPSecIKEPort:           defaultIKEPort,
+			CeIPSecNATTPort:          defaultNATTPort,
+			ClusterCIDR:              instance.Spec.ClusterCIDR,
+			ClusterID:                instance.Spec.ClusterID,
+			ServiceCIDR:              instance.Spec.ServiceCIDR,
+			Namespace:                instance.Namespace,
+			NatEnabled:               instance.Spec.NatEnabled,
+			ServiceDiscoveryEnabled:  instance.Spec.ServiceDiscoveryEnabled,
+			Repository:               submarinerv1alpha1.DefaultRepo,
+			Version:                  submarinerv1alpha1.DefaultSubmarinerVersion,
+		},
+	}
+
+	if withPSK {
+		submariner.Spec.CeIPSecPSKSecret = instance.Spec.BrokerSecret
+	}
+
+	return submariner
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("joinrequest-controller").
+		For(&submarinerv1alpha1.JoinRequest{}).
+		// Joins wait for their broker credentials Secret
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.joinRequestsForSecret)).
+		Complete(r)
+}
+
+func (r *Reconciler) joinRequestsForSecret(ctx context.Context, object controllerClient.Object) []reconcile.Request {
+	joinRequests := &submarinerv1alpha1.JoinRequestList{}
+
+	err := r.ScopedClient.List(ctx, joinRequests, controllerClient.InNamespace(object.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Error listing the JoinRequest resources")
+		return nil
+	}
+
+	var requests []reconcile.Request
+
+	for i := range joinRequests.Items {
+		if joinRequests.Items[i].Spec.BrokerSecret == object.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: controllerClient.ObjectKeyFromObject(&joinRequests.Items[i])})
+		}
+	}
+
+	return requests
+}
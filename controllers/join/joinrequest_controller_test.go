@@ -0,0 +1,198 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package join_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("JoinRequest controller", func() {
+	t := newTestDriver()
+
+	When("the broker credentials Secret doesn't exist", func() {
+		It("should wait for it", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			status := t.getJoinRequest(ctx).Status
+			Expect(status.Phase).To(Equal(v1alpha1.JoinRequestPending))
+			Expect(status.Message).To(ContainSubstring(brokerSecretName))
+
+			_, err := t.getSubmariner(ctx)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("the broker credentials Secret exists", func() {
+		BeforeEach(func() {
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newBrokerSecret(map[string][]byte{
+				"token":  []byte("broker-token"),
+				"ca.crt": []byte("broker-ca"),
+				"psk":    []byte("secret"),
+			}))
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs,
+				newNode("control-1", map[string]string{"node-role.kubernetes.io/control-plane": ""}),
+				newNode("worker-1", map[string]string{"topology.kubernetes.io/zone": "a"}),
+				newNode("worker-2", map[string]string{"topology.kubernetes.io/zone": "b"}))
+		})
+
+		It("should label the first worker node as gateway and create the Submariner resource", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			status := t.getJoinRequest(ctx).Status
+			Expect(status.Phase).To(Equal(v1alpha1.JoinRequestJoined))
+			Expect(status.GatewayNodes).To(Equal([]string{"worker-1"}))
+
+			Expect(t.getNode(ctx, "worker-1").Labels).To(HaveKeyWithValue("submariner.io/gateway", "true"))
+			Expect(t.getNode(ctx, "worker-2").Labels).ToNot(HaveKey("submariner.io/gateway"))
+			Expect(t.getNode(ctx, "control-1").Labels).ToNot(HaveKey("submariner.io/gateway"))
+
+			submariner, err := t.getSubmariner(ctx)
+			Expect(err).To(Succeed())
+			Expect(submariner.Spec.ClusterID).To(Equal("east"))
+			Expect(submariner.Spec.BrokerK8sApiServer).To(Equal(t.joinRequest.Spec.BrokerK8sApiServer))
+			Expect(submariner.Spec.BrokerK8sRemoteNamespace).To(Equal(t.joinRequest.Spec.BrokerK8sRemoteNamespace))
+			Expect(submariner.Spec.BrokerK8sSecret).To(Equal(brokerSecretName))
+			Expect(submariner.Spec.CeIPSecPSKSecret).To(Equal(brokerSecretName))
+			Expect(submariner.Spec.CableDriver).To(Equal("libreswan"))
+			Expect(submariner.Spec.ServiceDiscoveryEnabled).To(BeTrue())
+			Expect(submariner.Spec.Namespace).To(Equal(submarinerNamespace))
+			Expect(submariner.Spec.ClusterCIDR).To(BeEmpty())
+		})
+
+		Context("without the IPsec Pre-Shared Key", func() {
+			BeforeEach(func() {
+				delete(t.InitScopedClientObjs[1].(*corev1.Secret).Data, "psk")
+			})
+
+			It("should wait for it", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				status := t.getJoinRequest(ctx).Status
+				Expect(status.Phase).To(Equal(v1alpha1.JoinRequestPending))
+				Expect(status.Message).To(ContainSubstring("psk"))
+
+				_, err := t.getSubmariner(ctx)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			Context("and a cable driver which doesn't use it", func() {
+				BeforeEach(func() {
+					t.joinRequest.Spec.CableDriver = "wireguard"
+				})
+
+				It("should create the Submariner resource without it", func(ctx SpecContext) {
+					t.AssertReconcileSuccess(ctx)
+
+					submariner, err := t.getSubmariner(ctx)
+					Expect(err).To(Succeed())
+					Expect(submariner.Spec.CeIPSecPSKSecret).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("and a node is already labeled as gateway", func() {
+			BeforeEach(func() {
+				t.InitGeneralClientObjs[2].SetLabels(map[string]string{"submariner.io/gateway": "true"})
+			})
+
+			It("should use it", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				Expect(t.getJoinRequest(ctx).Status.GatewayNodes).To(Equal([]string{"worker-2"}))
+				Expect(t.getNode(ctx, "worker-1").Labels).ToNot(HaveKey("submariner.io/gateway"))
+			})
+		})
+
+		Context("and a gateway node selector is specified", func() {
+			BeforeEach(func() {
+				t.joinRequest.Spec.GatewayNodeSelector = &metav1.LabelSelector{
+					MatchExpressions: []metav1.LabelSelectorRequirement{{
+						Key:      "topology.kubernetes.io/zone",
+						Operator: metav1.LabelSelectorOpExists,
+					}},
+				}
+			})
+
+			It("should label the matching nodes", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				Expect(t.getJoinRequest(ctx).Status.GatewayNodes).To(Equal([]string{"worker-1", "worker-2"}))
+				Expect(t.getNode(ctx, "worker-2").Labels).To(HaveKeyWithValue("submariner.io/gateway", "true"))
+			})
+
+			Context("which doesn't match any node", func() {
+				BeforeEach(func() {
+					t.joinRequest.Spec.GatewayNodeSelector.MatchExpressions[0].Key = "gpu"
+				})
+
+				It("should wait for one", func(ctx SpecContext) {
+					t.AssertReconcileRequeue(ctx)
+
+					Expect(t.getJoinRequest(ctx).Status.Phase).To(Equal(v1alpha1.JoinRequestPending))
+
+					_, err := t.getSubmariner(ctx)
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+		})
+
+		Context("and the cluster has already joined with another cluster ID", func() {
+			BeforeEach(func() {
+				t.InitScopedClientObjs = append(t.InitScopedClientObjs, &v1alpha1.Submariner{
+					ObjectMeta: metav1.ObjectMeta{Name: "submariner", Namespace: submarinerNamespace},
+					Spec: v1alpha1.SubmarinerSpec{
+						ClusterID:                "west",
+						BrokerK8sApiServer:       t.joinRequest.Spec.BrokerK8sApiServer,
+						BrokerK8sRemoteNamespace: t.joinRequest.Spec.BrokerK8sRemoteNamespace,
+					},
+				})
+			})
+
+			It("should fail", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				status := t.getJoinRequest(ctx).Status
+				Expect(status.Phase).To(Equal(v1alpha1.JoinRequestFailed))
+				Expect(status.Message).To(ContainSubstring("west"))
+
+				submariner, err := t.getSubmariner(ctx)
+				Expect(err).To(Succeed())
+				Expect(submariner.Spec.ClusterID).To(Equal("west"))
+			})
+		})
+	})
+
+	When("the JoinRequest has already joined", func() {
+		BeforeEach(func() {
+			t.joinRequest.Status.Phase = v1alpha1.JoinRequestJoined
+		})
+
+		It("should not do anything", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			_, err := t.getSubmariner(ctx)
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,118 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package requeue
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	DefaultBaseDelay = time.Millisecond * 100
+	DefaultMaxDelay  = time.Second * 10
+)
+
+var reconcileFailuresGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "submariner_operator_reconcile_failures",
+		Help: "Number of consecutive failed reconciles (by controller and resource)",
+	},
+	[]string{
+		"controller",
+		"namespace",
+		"name",
+	},
+)
+
+func init() {
+	metrics.Registry.MustRegister(reconcileFailuresGauge)
+}
+
+// Backoff tracks the consecutive requeues and failures of the resources reconciled by a controller. Requeue delays
+// increase exponentially from the base delay up to the maximum delay, and failures are reported as metrics.
+type Backoff struct {
+	controller string
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+	mutex      sync.Mutex
+	attempts   map[types.NamespacedName]uint
+	failures   map[types.NamespacedName]int32
+}
+
+func NewBackoff(controller string, baseDelay, maxDelay time.Duration) *Backoff {
+	return &Backoff{
+		controller: controller,
+		baseDelay:  baseDelay,
+		maxDelay:   maxDelay,
+		attempts:   map[types.NamespacedName]uint{},
+		failures:   map[types.NamespacedName]int32{},
+	}
+}
+
+// After returns the delay before the given resource should be requeued, doubling it on each consecutive call.
+func (b *Backoff) After(key types.NamespacedName) time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	attempt := b.attempts[key]
+
+	delay := b.baseDelay << attempt
+	if delay <= 0 || delay >= b.maxDelay {
+		return b.maxDelay
+	}
+
+	b.attempts[key] = attempt + 1
+
+	return delay
+}
+
+// Failed records a failed reconcile of the given resource and returns the number of consecutive failures.
+func (b *Backoff) Failed(key types.NamespacedName) int32 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.failures[key]++
+	reconcileFailuresGauge.WithLabelValues(b.controller, key.Namespace, key.Name).Set(float64(b.failures[key]))
+
+	return b.failures[key]
+}
+
+// Failures returns the number of consecutive failed reconciles of the given resource.
+func (b *Backoff) Failures(key types.NamespacedName) int32 {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.failures[key]
+}
+
+// Reset forgets the requeues and failures recorded for the given resource.
+func (b *Backoff) Reset(key types.NamespacedName) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	delete(b.attempts, key)
+
+	if _, found := b.failures[key]; found {
+		delete(b.failures, key)
+		reconcileFailuresGauge.DeleteLabelValues(b.controller, key.Namespace, key.Name)
+	}
+}
@@ -0,0 +1,76 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package requeue_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/controllers/requeue"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("Backoff", func() {
+	const (
+		baseDelay = time.Millisecond * 100
+		maxDelay  = time.Millisecond * 500
+	)
+
+	var (
+		backoff *requeue.Backoff
+		key     = types.NamespacedName{Namespace: "test-ns", Name: "test"}
+	)
+
+	BeforeEach(func() {
+		backoff = requeue.NewBackoff("test-controller", baseDelay, maxDelay)
+	})
+
+	It("should double the requeue delay up to the maximum delay", func() {
+		Expect(backoff.After(key)).To(Equal(baseDelay))
+		Expect(backoff.After(key)).To(Equal(baseDelay * 2))
+		Expect(backoff.After(key)).To(Equal(baseDelay * 4))
+		Expect(backoff.After(key)).To(Equal(maxDelay))
+		Expect(backoff.After(key)).To(Equal(maxDelay))
+	})
+
+	It("should track the requeue delay per resource", func() {
+		Expect(backoff.After(key)).To(Equal(baseDelay))
+		Expect(backoff.After(types.NamespacedName{Namespace: "test-ns", Name: "other"})).To(Equal(baseDelay))
+	})
+
+	It("should count consecutive failures", func() {
+		Expect(backoff.Failed(key)).To(Equal(int32(1)))
+		Expect(backoff.Failed(key)).To(Equal(int32(2)))
+		Expect(backoff.Failures(key)).To(Equal(int32(2)))
+	})
+
+	When("reset", func() {
+		It("should start over", func() {
+			backoff.After(key)
+			backoff.After(key)
+			backoff.Failed(key)
+
+			backoff.Reset(key)
+
+			Expect(backoff.After(key)).To(Equal(baseDelay))
+			Expect(backoff.Failures(key)).To(BeZero())
+		})
+	})
+})
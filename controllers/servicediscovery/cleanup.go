@@ -20,7 +20,6 @@ package servicediscovery
 
 import (
 	"context"
-	"time"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/finalizer"
@@ -35,6 +34,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -94,7 +94,7 @@ func (r *Reconciler) doCleanup(ctx context.Context, instance *operatorv1alpha1.S
 	}
 
 	if requeue {
-		return reconcile.Result{RequeueAfter: time.Millisecond * 500}, nil
+		return reconcile.Result{RequeueAfter: r.requeueBackoff().After(client.ObjectKeyFromObject(instance))}, nil
 	}
 
 	return reconcile.Result{}, r.removeFinalizer(ctx, instance)
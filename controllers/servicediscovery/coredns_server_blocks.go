@@ -0,0 +1,102 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicediscovery
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+	submarinerv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// validateCoreDNSServerBlocks checks that the custom server blocks forward valid domains, distinct from each other and
+// from the domains handled by Lighthouse, to IP upstreams.
+func validateCoreDNSServerBlocks(blocks []submarinerv1alpha1.CoreDNSServerBlock, lighthouseDomains []string) error {
+	domains := map[string]bool{}
+	for _, domain := range lighthouseDomains {
+		domains[domain] = true
+	}
+
+	for i := range blocks {
+		block := &blocks[i]
+
+		if errs := validation.IsDNS1123Subdomain(block.Domain); len(errs) > 0 {
+			return fmt.Errorf("invalid CoreDNS server block domain %q: %s", block.Domain, strings.Join(errs, ", "))
+		}
+
+		if domains[block.Domain] {
+			return fmt.Errorf("the CoreDNS server block domain %q is already served", block.Domain)
+		}
+
+		domains[block.Domain] = true
+
+		if len(block.Upstreams) == 0 {
+			return fmt.Errorf("the CoreDNS server block for %q has no upstreams", block.Domain)
+		}
+
+		for _, upstream := range block.Upstreams {
+			if !isIPUpstream(upstream) {
+				return fmt.Errorf("invalid upstream %q in the CoreDNS server block for %q, an IP address with an optional port"+
+					" is expected", upstream, block.Domain)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isIPUpstream(upstream string) bool {
+	if net.ParseIP(upstream) != nil {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(upstream)
+
+	return err == nil && net.ParseIP(host) != nil
+}
+
+// coreDNSServerBlocks returns the server blocks forwarding the Lighthouse domains to the given Lighthouse IP, followed
+// by the custom server blocks.
+func coreDNSServerBlocks(cr *submarinerv1alpha1.ServiceDiscovery, port, lighthouseIP string) (string, error) {
+	lighthouseDomains := append([]string{"clusterset.local"}, cr.Spec.CustomDomains...)
+
+	var blocks []submarinerv1alpha1.CoreDNSServerBlock
+	if cr.Spec.CoreDNSCustomConfig != nil {
+		blocks = cr.Spec.CoreDNSCustomConfig.ServerBlocks
+	}
+
+	if err := validateCoreDNSServerBlocks(blocks, lighthouseDomains); err != nil {
+		return "", errors.Wrap(err, "invalid CoreDNS custom configuration")
+	}
+
+	coreFile := ""
+	for _, domain := range lighthouseDomains {
+		coreFile = fmt.Sprintf("%s%s:%s {\n    forward . %s\n}\n", coreFile, domain, port, lighthouseIP)
+	}
+
+	for i := range blocks {
+		coreFile = fmt.Sprintf("%s%s:%s {\n    forward . %s\n}\n", coreFile, blocks[i].Domain, port,
+			strings.Join(blocks[i].Upstreams, " "))
+	}
+
+	return coreFile, nil
+}
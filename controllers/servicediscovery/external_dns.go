@@ -0,0 +1,160 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicediscovery
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	submarinerv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const externalDNSLabel = "submariner.io/external-dns"
+
+var dnsEndpointGVK = schema.GroupVersionKind{Group: "externaldns.k8s.io", Version: "v1alpha1", Kind: "DNSEndpoint"}
+
+//+kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;create;update;delete;deletecollection
+
+// reconcileExternalDNS publishes an external-dns DNSEndpoint, in the ServiceDiscovery's namespace, for each selected
+// ServiceImport with clusterset IPs, and removes the DNSEndpoints which are no longer needed.
+func (r *Reconciler) reconcileExternalDNS(ctx context.Context, instance *submarinerv1alpha1.ServiceDiscovery) error {
+	if instance.Spec.ExternalDNS == nil {
+		err := r.ScopedClient.DeleteAllOf(ctx, newDNSEndpoint(), controllerClient.InNamespace(instance.Namespace),
+			controllerClient.MatchingLabels{externalDNSLabel: instance.Name})
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "error deleting the DNSEndpoints")
+	}
+
+	serviceImports, err := r.selectedServiceImports(ctx, instance, instance.Spec.ExternalDNS.ServiceImportSelector)
+	if err != nil {
+		return err
+	}
+
+	published := map[string]bool{}
+
+	for i := range serviceImports {
+		serviceImport := &serviceImports[i]
+
+		ips, _, _ := unstructured.NestedStringSlice(serviceImport.Object, "spec", "ips")
+		if len(ips) == 0 {
+			continue
+		}
+
+		dnsEndpoint, err := r.publishDNSEndpoint(ctx, instance, serviceImport, ips)
+		if meta.IsNoMatchError(err) {
+			log.Info("The external-dns DNSEndpoint CRD isn't installed, the clusterset DNS records can't be published")
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		published[dnsEndpoint] = true
+	}
+
+	dnsEndpoints := &unstructured.UnstructuredList{}
+	dnsEndpoints.SetGroupVersionKind(dnsEndpointGVK.GroupVersion().WithKind(dnsEndpointGVK.Kind + "List"))
+
+	err = r.ScopedClient.List(ctx, dnsEndpoints, controllerClient.InNamespace(instance.Namespace),
+		controllerClient.MatchingLabels{externalDNSLabel: instance.Name})
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "error listing the DNSEndpoints")
+	}
+
+	for i := range dnsEndpoints.Items {
+		if published[dnsEndpoints.Items[i].GetName()] {
+			continue
+		}
+
+		err = r.ScopedClient.Delete(ctx, &dnsEndpoints.Items[i])
+		if err != nil {
+			return errors.Wrapf(err, "error deleting DNSEndpoint %q", dnsEndpoints.Items[i].GetName())
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) publishDNSEndpoint(ctx context.Context, instance *submarinerv1alpha1.ServiceDiscovery,
+	serviceImport *unstructured.Unstructured, ips []string,
+) (string, error) {
+	dnsEndpoint := newDNSEndpoint()
+	dnsEndpoint.SetName(serviceImportObjectName(serviceImport))
+	dnsEndpoint.SetNamespace(instance.Namespace)
+
+	targets := toInterfaces(ips)
+
+	var endpoints []interface{}
+
+	for _, domain := range append([]string{"clusterset.local"}, instance.Spec.CustomDomains...) {
+		endpoint := map[string]interface{}{
+			"dnsName":    fmt.Sprintf("%s.%s.svc.%s", serviceImport.GetName(), serviceImport.GetNamespace(), domain),
+			"recordType": "A",
+			"targets":    targets,
+		}
+
+		if instance.Spec.ExternalDNS.RecordTTL > 0 {
+			endpoint["recordTTL"] = instance.Spec.ExternalDNS.RecordTTL
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.ScopedClient, dnsEndpoint, func() error {
+		dnsEndpoint.SetLabels(map[string]string{externalDNSLabel: instance.Name})
+
+		if err := unstructured.SetNestedSlice(dnsEndpoint.Object, endpoints, "spec", "endpoints"); err != nil {
+			return err //nolint:wrapcheck // No need to wrap here
+		}
+
+		return controllerutil.SetControllerReference(instance, dnsEndpoint, r.Scheme)
+	})
+	if meta.IsNoMatchError(err) {
+		return "", err //nolint:wrapcheck // The caller checks for this error
+	}
+
+	return dnsEndpoint.GetName(), errors.Wrapf(err, "error publishing DNSEndpoint %q", dnsEndpoint.GetName())
+}
+
+// serviceImportObjectName returns the name of the objects derived from the given ServiceImport. Service and namespace
+// names can't contain dots, so the name is unique across namespaces.
+func serviceImportObjectName(serviceImport *unstructured.Unstructured) string {
+	return serviceImport.GetName() + "." + serviceImport.GetNamespace()
+}
+
+func newDNSEndpoint() *unstructured.Unstructured {
+	dnsEndpoint := &unstructured.Unstructured{}
+	dnsEndpoint.SetGroupVersionKind(dnsEndpointGVK)
+
+	return dnsEndpoint
+}
@@ -0,0 +1,204 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicediscovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	submarinerv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const istioServiceEntriesLabel = "submariner.io/istio-service-entries"
+
+var serviceEntryGVK = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "ServiceEntry"}
+
+//+kubebuilder:rbac:groups=networking.istio.io,resources=serviceentries,verbs=get;list;create;update;delete;deletecollection
+
+// reconcileIstioServiceEntries mirrors each selected ServiceImport as an Istio ServiceEntry, in the ServiceDiscovery's
+// namespace, and removes the ServiceEntries which are no longer needed.
+func (r *Reconciler) reconcileIstioServiceEntries(ctx context.Context, instance *submarinerv1alpha1.ServiceDiscovery) error {
+	if instance.Spec.IstioServiceEntries == nil {
+		err := r.ScopedClient.DeleteAllOf(ctx, newServiceEntry(), controllerClient.InNamespace(instance.Namespace),
+			controllerClient.MatchingLabels{istioServiceEntriesLabel: instance.Name})
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "error deleting the ServiceEntries")
+	}
+
+	serviceImports, err := r.selectedServiceImports(ctx, instance, instance.Spec.IstioServiceEntries.ServiceImportSelector)
+	if err != nil {
+		return err
+	}
+
+	mirrored := map[string]bool{}
+
+	for i := range serviceImports {
+		// A ServiceEntry requires at least one port
+		ports := serviceEntryPorts(&serviceImports[i])
+		if len(ports) == 0 {
+			continue
+		}
+
+		serviceEntry, err := r.mirrorServiceEntry(ctx, instance, &serviceImports[i], ports)
+		if meta.IsNoMatchError(err) {
+			log.Info("The Istio ServiceEntry CRD isn't installed, the imported services can't be mirrored")
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		mirrored[serviceEntry] = true
+	}
+
+	serviceEntries := &unstructured.UnstructuredList{}
+	serviceEntries.SetGroupVersionKind(serviceEntryGVK.GroupVersion().WithKind(serviceEntryGVK.Kind + "List"))
+
+	err = r.ScopedClient.List(ctx, serviceEntries, controllerClient.InNamespace(instance.Namespace),
+		controllerClient.MatchingLabels{istioServiceEntriesLabel: instance.Name})
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "error listing the ServiceEntries")
+	}
+
+	for i := range serviceEntries.Items {
+		if mirrored[serviceEntries.Items[i].GetName()] {
+			continue
+		}
+
+		err = r.ScopedClient.Delete(ctx, &serviceEntries.Items[i])
+		if err != nil {
+			return errors.Wrapf(err, "error deleting ServiceEntry %q", serviceEntries.Items[i].GetName())
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) mirrorServiceEntry(ctx context.Context, instance *submarinerv1alpha1.ServiceDiscovery,
+	serviceImport *unstructured.Unstructured, ports []interface{},
+) (string, error) {
+	serviceEntry := newServiceEntry()
+	serviceEntry.SetName(serviceImportObjectName(serviceImport))
+	serviceEntry.SetNamespace(instance.Namespace)
+
+	spec := map[string]interface{}{
+		"location": "MESH_INTERNAL",
+		"ports":    ports,
+	}
+
+	var hosts []interface{}
+
+	for _, domain := range append([]string{"clusterset.local"}, instance.Spec.CustomDomains...) {
+		hosts = append(hosts, fmt.Sprintf("%s.%s.svc.%s", serviceImport.GetName(), serviceImport.GetNamespace(), domain))
+	}
+
+	spec["hosts"] = hosts
+
+	// Traffic to a clusterset IP is forwarded as is; headless services are resolved through Lighthouse
+	ips, _, _ := unstructured.NestedStringSlice(serviceImport.Object, "spec", "ips")
+	if len(ips) > 0 {
+		spec["addresses"] = toInterfaces(ips)
+		spec["resolution"] = "NONE"
+	} else {
+		spec["resolution"] = "DNS"
+	}
+
+	if len(instance.Spec.IstioServiceEntries.ExportTo) > 0 {
+		spec["exportTo"] = toInterfaces(instance.Spec.IstioServiceEntries.ExportTo)
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.ScopedClient, serviceEntry, func() error {
+		serviceEntry.SetLabels(map[string]string{istioServiceEntriesLabel: instance.Name})
+		serviceEntry.Object["spec"] = spec
+
+		return controllerutil.SetControllerReference(instance, serviceEntry, r.Scheme)
+	})
+	if meta.IsNoMatchError(err) {
+		return "", err //nolint:wrapcheck // The caller checks for this error
+	}
+
+	return serviceEntry.GetName(), errors.Wrapf(err, "error mirroring ServiceEntry %q", serviceEntry.GetName())
+}
+
+// serviceEntryPorts converts the ServiceImport's ports to ServiceEntry ports; the application protocol, if any, is used
+// as the Istio protocol.
+func serviceEntryPorts(serviceImport *unstructured.Unstructured) []interface{} {
+	importPorts, _, _ := unstructured.NestedSlice(serviceImport.Object, "spec", "ports")
+	ports := make([]interface{}, 0, len(importPorts))
+
+	for _, p := range importPorts {
+		importPort, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		number, _, _ := unstructured.NestedInt64(importPort, "port")
+		name, _, _ := unstructured.NestedString(importPort, "name")
+		protocol, _, _ := unstructured.NestedString(importPort, "appProtocol")
+
+		if protocol == "" {
+			protocol, _, _ = unstructured.NestedString(importPort, "protocol")
+		}
+
+		if protocol == "" {
+			protocol = "TCP"
+		}
+
+		protocol = strings.ToUpper(protocol)
+
+		if name == "" {
+			name = fmt.Sprintf("%s-%d", strings.ToLower(protocol), number)
+		}
+
+		ports = append(ports, map[string]interface{}{"number": number, "name": name, "protocol": protocol})
+	}
+
+	return ports
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i := range values {
+		result[i] = values[i]
+	}
+
+	return result
+}
+
+func newServiceEntry() *unstructured.Unstructured {
+	serviceEntry := &unstructured.Unstructured{}
+	serviceEntry.SetGroupVersionKind(serviceEntryGVK)
+
+	return serviceEntry
+}
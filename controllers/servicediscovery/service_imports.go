@@ -0,0 +1,74 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicediscovery
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	submarinerv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ServiceImports across all namespaces aren't watched, the resources derived from them are refreshed periodically instead.
+const serviceImportSyncInterval = time.Minute
+
+var serviceImportGVK = schema.GroupVersionKind{Group: "multicluster.x-k8s.io", Version: "v1alpha1", Kind: "ServiceImport"}
+
+//+kubebuilder:rbac:groups=multicluster.x-k8s.io,resources=serviceimports,verbs=list
+
+// selectedServiceImports returns the ServiceImports matching the given selector, or all of them if it's nil.
+func (r *Reconciler) selectedServiceImports(ctx context.Context, instance *submarinerv1alpha1.ServiceDiscovery,
+	labelSelector *metav1.LabelSelector,
+) ([]unstructured.Unstructured, error) {
+	selector := labels.Everything()
+
+	if labelSelector != nil {
+		var err error
+
+		selector, err = metav1.LabelSelectorAsSelector(labelSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid ServiceImport selector")
+		}
+	}
+
+	serviceImports := &unstructured.UnstructuredList{}
+	serviceImports.SetGroupVersionKind(serviceImportGVK.GroupVersion().WithKind(serviceImportGVK.Kind + "List"))
+
+	err := r.GeneralClient.List(ctx, serviceImports, controllerClient.MatchingLabelsSelector{Selector: selector})
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing the ServiceImports")
+	}
+
+	selected := make([]unstructured.Unstructured, 0, len(serviceImports.Items))
+
+	for i := range serviceImports.Items {
+		// Lighthouse keeps its per-cluster copies of the ServiceImports in this namespace
+		if serviceImports.Items[i].GetNamespace() != instance.Namespace {
+			selected = append(selected, serviceImports.Items[i])
+		}
+	}
+
+	return selected, nil
+}
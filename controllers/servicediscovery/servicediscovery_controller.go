@@ -27,6 +27,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/go-logr/logr"
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -39,6 +40,7 @@ import (
 	submarinerv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/controllers/apply"
 	"github.com/submariner-io/submariner-operator/controllers/metrics"
+	"github.com/submariner-io/submariner-operator/controllers/requeue"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
 	appsv1 "k8s.io/api/apps/v1"
@@ -69,6 +71,7 @@ const (
 	microshiftDNSNamespace        = "openshift-dns"
 	microshiftDNSConfigMap        = "dns-default"
 	coreDNSDefaultPort            = "53"
+	secretsStoreCSIDriver         = "secrets-store.csi.k8s.io"
 )
 
 // Reconciler reconciles a ServiceDiscovery object.
@@ -80,6 +83,9 @@ type Reconciler struct {
 	GeneralClient controllerClient.Client
 	Scheme        *runtime.Scheme
 	RestConfig    *rest.Config
+
+	backoffOnce sync.Once
+	backoff     *requeue.Backoff
 }
 
 // blank assignment to verify that Reconciler implements reconcile.Reconciler.
@@ -95,6 +101,19 @@ var _ reconcile.Reconciler = &Reconciler{}
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	result, err := r.doReconcile(ctx, request)
+	if err != nil {
+		r.recordReconcileFailures(ctx, request.NamespacedName, r.requeueBackoff().Failed(request.NamespacedName))
+	} else if result.IsZero() || result.RequeueAfter == serviceImportSyncInterval {
+		// Only the periodic ServiceImport sync is a success, other requeues back off
+		r.requeueBackoff().Reset(request.NamespacedName)
+		r.recordReconcileFailures(ctx, request.NamespacedName, 0)
+	}
+
+	return result, err
+}
+
+func (r *Reconciler) doReconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := log.V(2).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling ServiceDiscovery")
 
@@ -127,6 +146,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return r.doCleanup(ctx, instance)
 	}
 
+	paused := submarinerv1alpha1.IsPaused(instance)
+	if paused != instance.Status.Paused {
+		if err := r.updatePausedStatus(ctx, instance, paused); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if paused {
+		return reconcile.Result{}, nil
+	}
+
 	err = r.ensureLightHouseAgent(ctx, instance, reqLogger)
 	if err != nil {
 		return reconcile.Result{}, err
@@ -149,6 +179,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, err
 	}
 
+	err = r.reconcileExternalDNS(ctx, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	err = r.reconcileIstioServiceEntries(ctx, instance)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	result := reconcile.Result{}
+	if instance.Spec.ExternalDNS != nil || instance.Spec.IstioServiceEntries != nil {
+		result.RequeueAfter = serviceImportSyncInterval
+	}
+
 	if instance.Spec.CoreDNSCustomConfig != nil && instance.Spec.CoreDNSCustomConfig.ConfigMapName != "" {
 		err = r.updateDNSCustomConfigMap(ctx, instance, reqLogger)
 		if err != nil {
@@ -161,10 +206,50 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 
 	if apierrors.IsNotFound(err) {
 		// Try to update Openshift-DNS
-		return reconcile.Result{}, r.configureOpenshiftClusterDNSOperator(ctx, instance)
+		return result, r.configureOpenshiftClusterDNSOperator(ctx, instance)
+	}
+
+	return result, err
+}
+
+func (r *Reconciler) requeueBackoff() *requeue.Backoff {
+	r.backoffOnce.Do(func() {
+		r.backoff = requeue.NewBackoff("servicediscovery-controller", requeue.DefaultBaseDelay, requeue.DefaultMaxDelay)
+	})
+
+	return r.backoff
+}
+
+// recordReconcileFailures records the consecutive failed reconciles in the ServiceDiscovery status so persistent failures
+// are visible; a successful reconcile resets the count to 0.
+func (r *Reconciler) recordReconcileFailures(ctx context.Context, key types.NamespacedName, failures int32) {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance, err := r.getServiceDiscovery(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if instance.Status.ReconcileFailures == failures {
+			return nil
+		}
+
+		instance.Status.ReconcileFailures = failures
+
+		return r.ScopedClient.Status().Update(ctx, instance)
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "Error recording the reconcile failure count", "failures", failures)
 	}
+}
 
-	return reconcile.Result{}, err
+func (r *Reconciler) updatePausedStatus(ctx context.Context, instance *submarinerv1alpha1.ServiceDiscovery, paused bool) error {
+	if paused {
+		log.Info("Reconciliation of the ServiceDiscovery resource is paused", "Namespace", instance.Namespace, "Name", instance.Name)
+	}
+
+	instance.Status.Paused = paused
+
+	return errors.Wrap(r.ScopedClient.Status().Update(ctx, instance), "failed to update the ServiceDiscovery status")
 }
 
 func (r *Reconciler) getServiceDiscovery(ctx context.Context, key types.NamespacedName) (*submarinerv1alpha1.ServiceDiscovery, error) {
@@ -216,7 +301,7 @@ func newLighthouseAgent(cr *submarinerv1alpha1.ServiceDiscovery, name string) *a
 
 		volumes = append(volumes, corev1.Volume{
 			Name:         "brokersecret",
-			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: cr.Spec.BrokerK8sSecret}},
+			VolumeSource: brokerSecretVolumeSource(cr.Spec.BrokerK8sSecret, cr.Spec.BrokerK8sSecretProviderClass),
 		})
 	}
 
@@ -231,6 +316,7 @@ func newLighthouseAgent(cr *submarinerv1alpha1.ServiceDiscovery, name string) *a
 				MatchLabels: matchLabels,
 			},
 			Replicas: ptr.To(int32(1)),
+			Strategy: deploymentStrategy(cr, names.ServiceDiscoveryComponent),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
@@ -269,6 +355,15 @@ func newLighthouseAgent(cr *submarinerv1alpha1.ServiceDiscovery, name string) *a
 	}
 }
 
+// deploymentStrategy returns the configured strategy for the given component, or the default (empty) strategy.
+func deploymentStrategy(cr *submarinerv1alpha1.ServiceDiscovery, component string) appsv1.DeploymentStrategy {
+	if strategy := cr.Spec.UpdateStrategies[component].Deployment; strategy != nil {
+		return *strategy
+	}
+
+	return appsv1.DeploymentStrategy{}
+}
+
 func newLighthouseDNSConfigMap(cr *submarinerv1alpha1.ServiceDiscovery) *corev1.ConfigMap {
 	labels := map[string]string{
 		"app":       names.LighthouseCoreDNSComponent,
@@ -328,6 +423,7 @@ func newLighthouseCoreDNSDeployment(cr *submarinerv1alpha1.ServiceDiscovery) *ap
 				MatchLabels: matchLabels,
 			},
 			Replicas: ptr.To(int32(2)),
+			Strategy: deploymentStrategy(cr, names.LighthouseCoreDNSComponent),
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: labels,
@@ -440,10 +536,9 @@ func (r *Reconciler) updateDNSCustomConfigMap(ctx context.Context, cr *submarine
 			reqLogger.Info("Overwriting existing lighthouse.server data in " + configMap.Name)
 		}
 
-		coreFile := ""
-		for _, domain := range append([]string{"clusterset.local"}, cr.Spec.CustomDomains...) {
-			coreFile = fmt.Sprintf("%s%s:53 {\n    forward . %s\n}\n",
-				coreFile, domain, lighthouseClusterIP)
+		coreFile, err := coreDNSServerBlocks(cr, "53", lighthouseClusterIP)
+		if err != nil {
+			return err
 		}
 
 		log.Info("Updating coredns-custom ConfigMap for lighthouse.server: " + coreFile)
@@ -741,3 +836,17 @@ func (r *Reconciler) ensureLighthouseCoreDNSService(ctx context.Context, instanc
 
 	return nil
 }
+
+// brokerSecretVolumeSource returns the source of the broker credentials: the named Secret, or the Secrets Store CSI driver
+// if a SecretProviderClass is specified.
+func brokerSecretVolumeSource(secretName, secretProviderClass string) corev1.VolumeSource {
+	if secretProviderClass != "" {
+		return corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{
+			Driver:           secretsStoreCSIDriver,
+			ReadOnly:         ptr.To(true),
+			VolumeAttributes: map[string]string{"secretProviderClass": secretProviderClass},
+		}}
+	}
+
+	return corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}}
+}
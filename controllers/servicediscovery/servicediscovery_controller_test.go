@@ -23,11 +23,16 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/fake"
 	"github.com/submariner-io/admiral/pkg/names"
 	submariner_v1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("Service discovery controller", func() {
@@ -46,6 +51,72 @@ func testReconciliation() {
 		t.awaitFinalizer()
 	})
 
+	When("reconciliation is paused", func() {
+		BeforeEach(func() {
+			t.serviceDiscovery.Annotations = map[string]string{submariner_v1.PausedAnnotation: "true"}
+		})
+
+		It("should not reconcile the managed resources and report it in the Status", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+			t.AssertNoDeployment(ctx, names.ServiceDiscoveryComponent)
+
+			serviceDiscovery := &submariner_v1.ServiceDiscovery{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(t.serviceDiscovery), serviceDiscovery)).To(Succeed())
+			Expect(serviceDiscovery.Status.Paused).To(BeTrue())
+		})
+	})
+
+	When("Deployment creation fails and then succeeds", func() {
+		var failCreate bool
+
+		BeforeEach(func() {
+			failCreate = true
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newDNSService(clusterIP))
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newCoreDNSConfigMap(coreDNSCorefileData("")))
+			t.ScopedClient = fake.NewReactingClient(t.NewScopedClient()).AddReactor(fake.Create, &appsv1.Deployment{},
+				func(_ interface{}) (bool, error) {
+					if failCreate {
+						return true, errors.New("mock error")
+					}
+
+					return false, nil
+				})
+		})
+
+		It("should record the consecutive failures in the Status and reset them on success", func(ctx SpecContext) {
+			t.AssertReconcileError(ctx)
+			t.AssertReconcileError(ctx)
+			Expect(t.getServiceDiscovery(ctx).Status.ReconcileFailures).To(Equal(int32(2)))
+
+			failCreate = false
+
+			t.AssertReconcileSuccess(ctx)
+			Expect(t.getServiceDiscovery(ctx).Status.ReconcileFailures).To(BeZero())
+		})
+	})
+
+	When("a Deployment strategy is configured for a component", func() {
+		recreate := appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+
+		BeforeEach(func() {
+			t.serviceDiscovery.Spec.UpdateStrategies = map[string]submariner_v1.ComponentUpdateStrategy{
+				names.LighthouseCoreDNSComponent: {Deployment: &recreate},
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newDNSService(clusterIP))
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newCoreDNSConfigMap(coreDNSCorefileData("")))
+		})
+
+		It("should apply it to the component's Deployment", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			deployment, err := t.GetDeployment(ctx, names.LighthouseCoreDNSComponent)
+			Expect(err).To(Succeed())
+			Expect(deployment.Spec.Strategy).To(Equal(recreate))
+			Expect(t.AssertDeployment(ctx, names.ServiceDiscoveryComponent).Spec.Strategy).To(Equal(appsv1.DeploymentStrategy{}))
+		})
+	})
+
 	When("the openshift DNS config exists", func() {
 		Context("and the lighthouse config isn't present", func() {
 			BeforeEach(func() {
@@ -180,6 +251,51 @@ func testReconciliation() {
 			})
 		})
 
+		Context("with custom server blocks", func() {
+			BeforeEach(func() {
+				t.serviceDiscovery.Spec.CoreDNSCustomConfig.ServerBlocks = []submariner_v1.CoreDNSServerBlock{
+					{Domain: "corp.example.com", Upstreams: []string{"10.0.0.10", "10.0.0.11:5353"}},
+					{Domain: "lab.example.com", Upstreams: []string{"10.1.0.10"}},
+				}
+
+				t.InitScopedClientObjs = append(t.InitScopedClientObjs, newDNSService(clusterIP))
+			})
+
+			It("should add them after the lighthouse server blocks", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				Expect(strings.TrimSpace(t.assertConfigMap(ctx, t.serviceDiscovery.Spec.CoreDNSCustomConfig.ConfigMapName,
+					t.serviceDiscovery.Spec.CoreDNSCustomConfig.Namespace).Data["lighthouse.server"])).To(Equal(
+					strings.ReplaceAll(lighthouseDNSConfigFormat, "$IP", clusterIP) + `
+corp.example.com:53 {
+    forward . 10.0.0.10 10.0.0.11:5353
+}
+lab.example.com:53 {
+    forward . 10.1.0.10
+}`))
+			})
+
+			Context("and one of them overlaps a clusterset domain", func() {
+				BeforeEach(func() {
+					t.serviceDiscovery.Spec.CoreDNSCustomConfig.ServerBlocks[1].Domain = "supercluster.local"
+				})
+
+				It("should fail", func(ctx SpecContext) {
+					t.AssertReconcileError(ctx)
+				})
+			})
+
+			Context("and one of them has an invalid upstream", func() {
+				BeforeEach(func() {
+					t.serviceDiscovery.Spec.CoreDNSCustomConfig.ServerBlocks[0].Upstreams = []string{"dns.example.com"}
+				})
+
+				It("should fail", func(ctx SpecContext) {
+					t.AssertReconcileError(ctx)
+				})
+			})
+		})
+
 		Context("and the lighthouse DNS service doesn't exist", func() {
 			It("should create the service and the custom coredns ConfigMap", func(ctx SpecContext) {
 				t.AssertReconcileError(ctx)
@@ -194,6 +310,153 @@ func testReconciliation() {
 			})
 		})
 	})
+
+	When("publishing to external DNS is enabled", func() {
+		BeforeEach(func() {
+			t.serviceDiscovery.Spec.ExternalDNS = &submariner_v1.ExternalDNSConfig{RecordTTL: 30}
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newDNSService(clusterIP))
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newCoreDNSConfigMap(coreDNSCorefileData("")),
+				newServiceImport("nginx", "default", "243.1.0.1"),
+				newServiceImport("headless", "default"),
+				newServiceImport("nginx-east", submarinerNamespace, "10.1.0.1"))
+		})
+
+		It("should publish a DNSEndpoint for each ServiceImport with clusterset IPs", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			dnsEndpoints := t.getDNSEndpoints(ctx)
+			Expect(dnsEndpoints).To(HaveLen(1))
+			Expect(dnsEndpoints[0].GetName()).To(Equal("nginx.default"))
+
+			endpoints, _, _ := unstructured.NestedSlice(dnsEndpoints[0].Object, "spec", "endpoints")
+			Expect(endpoints).To(ConsistOf(
+				HaveKeyWithValue("dnsName", "nginx.default.svc.clusterset.local"),
+				HaveKeyWithValue("dnsName", "nginx.default.svc.supercluster.local")))
+			Expect(endpoints[0]).To(HaveKeyWithValue("targets", ConsistOf("243.1.0.1")))
+			Expect(endpoints[0]).To(HaveKeyWithValue("recordTTL", BeNumerically("==", 30)))
+		})
+
+		Context("and the names of ServiceImports in different namespaces overlap", func() {
+			BeforeEach(func() {
+				t.InitGeneralClientObjs = append(t.InitGeneralClientObjs,
+					newServiceImport("a-b", "c", "243.1.0.2"),
+					newServiceImport("a", "b-c", "243.1.0.3"))
+			})
+
+			It("should publish a DNSEndpoint for each of them", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				names := []string{}
+				for _, dnsEndpoint := range t.getDNSEndpoints(ctx) {
+					names = append(names, dnsEndpoint.GetName())
+				}
+
+				Expect(names).To(ConsistOf("nginx.default", "a-b.c", "a.b-c"))
+			})
+		})
+
+		Context("and a ServiceImport is removed", func() {
+			It("should delete its DNSEndpoint", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+				Expect(t.GeneralClient.Delete(ctx, newServiceImport("nginx", "default"))).To(Succeed())
+
+				t.AssertReconcileRequeue(ctx)
+				Expect(t.getDNSEndpoints(ctx)).To(BeEmpty())
+			})
+		})
+
+		Context("and a ServiceImport selector is specified", func() {
+			BeforeEach(func() {
+				t.serviceDiscovery.Spec.ExternalDNS.ServiceImportSelector = &metav1.LabelSelector{
+					MatchLabels: map[string]string{"publish": "true"},
+				}
+			})
+
+			It("should only publish the selected ServiceImports", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+				Expect(t.getDNSEndpoints(ctx)).To(BeEmpty())
+			})
+		})
+
+		Context("and is then disabled", func() {
+			It("should delete the DNSEndpoints", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+				Expect(t.getDNSEndpoints(ctx)).To(HaveLen(1))
+
+				serviceDiscovery := &submariner_v1.ServiceDiscovery{}
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(t.serviceDiscovery), serviceDiscovery)).To(Succeed())
+				serviceDiscovery.Spec.ExternalDNS = nil
+				Expect(t.ScopedClient.Update(ctx, serviceDiscovery)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+				Expect(t.getDNSEndpoints(ctx)).To(BeEmpty())
+			})
+		})
+	})
+
+	When("mirroring to Istio ServiceEntries is enabled", func() {
+		BeforeEach(func() {
+			t.serviceDiscovery.Spec.IstioServiceEntries = &submariner_v1.IstioServiceEntriesConfig{ExportTo: []string{"."}}
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newDNSService(clusterIP))
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs, newCoreDNSConfigMap(coreDNSCorefileData("")),
+				withServiceImportPorts(newServiceImport("nginx", "default", "243.1.0.1"),
+					map[string]interface{}{"port": int64(80), "protocol": "TCP", "appProtocol": "http"}),
+				withServiceImportPorts(newServiceImport("headless", "default"),
+					map[string]interface{}{"name": "db", "port": int64(5432), "protocol": "TCP"}),
+				newServiceImport("no-ports", "default", "243.1.0.2"))
+		})
+
+		It("should mirror each ServiceImport with ports", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			serviceEntries := map[string]map[string]interface{}{}
+			for _, serviceEntry := range t.getServiceEntries(ctx) {
+				serviceEntries[serviceEntry.GetName()], _, _ = unstructured.NestedMap(serviceEntry.Object, "spec")
+			}
+
+			Expect(serviceEntries).To(HaveLen(2))
+
+			Expect(serviceEntries["nginx.default"]).To(SatisfyAll(
+				HaveKeyWithValue("hosts", ConsistOf("nginx.default.svc.clusterset.local", "nginx.default.svc.supercluster.local")),
+				HaveKeyWithValue("addresses", ConsistOf("243.1.0.1")),
+				HaveKeyWithValue("resolution", "NONE"),
+				HaveKeyWithValue("exportTo", ConsistOf(".")),
+				HaveKeyWithValue("ports", ConsistOf(SatisfyAll(
+					HaveKeyWithValue("name", "http-80"),
+					HaveKeyWithValue("protocol", "HTTP"),
+					HaveKeyWithValue("number", BeNumerically("==", 80)))))))
+
+			Expect(serviceEntries["headless.default"]).To(SatisfyAll(
+				Not(HaveKey("addresses")),
+				HaveKeyWithValue("resolution", "DNS"),
+				HaveKeyWithValue("ports", ConsistOf(HaveKeyWithValue("name", "db")))))
+		})
+
+		Context("and a ServiceImport is removed", func() {
+			It("should delete its ServiceEntry", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+				Expect(t.GeneralClient.Delete(ctx, newServiceImport("nginx", "default"))).To(Succeed())
+
+				t.AssertReconcileRequeue(ctx)
+				Expect(t.getServiceEntries(ctx)).To(HaveLen(1))
+			})
+		})
+
+		Context("and is then disabled", func() {
+			It("should delete the ServiceEntries", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+				Expect(t.getServiceEntries(ctx)).To(HaveLen(2))
+
+				serviceDiscovery := &submariner_v1.ServiceDiscovery{}
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(t.serviceDiscovery), serviceDiscovery)).To(Succeed())
+				serviceDiscovery.Spec.IstioServiceEntries = nil
+				Expect(t.ScopedClient.Update(ctx, serviceDiscovery)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+				Expect(t.getServiceEntries(ctx)).To(BeEmpty())
+			})
+		})
+	})
 }
 
 func testCoreDNSCleanup() {
@@ -311,6 +574,15 @@ func testDeploymentUninstall() {
 			t.AssertReconcileSuccess(ctx)
 			t.AssertNoDeployment(ctx, opnames.AppendUninstall(names.ServiceDiscoveryComponent))
 		})
+
+		It("should back off while the uninstall is in progress", func(ctx SpecContext) {
+			first, err := t.DoReconcile(ctx)
+			Expect(err).To(Succeed())
+
+			second, err := t.DoReconcile(ctx)
+			Expect(err).To(Succeed())
+			Expect(second.RequeueAfter).To(BeNumerically(">", first.RequeueAfter))
+		})
 	})
 
 	When("the version of the deleting ServiceDiscovery instance does not support uninstall", func() {
@@ -331,4 +603,24 @@ func testDeploymentUninstall() {
 			t.awaitServiceDiscoveryDeleted()
 		})
 	})
+
+	When("the deleting ServiceDiscovery instance is paused", func() {
+		BeforeEach(func() {
+			t.serviceDiscovery.Annotations = map[string]string{submariner_v1.PausedAnnotation: "true"}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, t.NewDeployment(names.ServiceDiscoveryComponent))
+		})
+
+		It("should still perform uninstall and remove the finalizer", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			t.AssertNoDeployment(ctx, names.ServiceDiscoveryComponent)
+
+			t.UpdateDeploymentToReady(ctx, t.assertUninstallServiceDiscoveryDeployment(ctx))
+
+			t.AssertReconcileSuccess(ctx)
+
+			t.awaitServiceDiscoveryDeleted()
+		})
+	})
 }
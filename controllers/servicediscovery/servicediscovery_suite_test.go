@@ -36,6 +36,8 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -58,9 +60,21 @@ supercluster.local:53 {
 		"\n#lighthouse-end\n"
 )
 
+var (
+	serviceImportGVK = schema.GroupVersionKind{Group: "multicluster.x-k8s.io", Version: "v1alpha1", Kind: "ServiceImport"}
+	dnsEndpointGVK   = schema.GroupVersionKind{Group: "externaldns.k8s.io", Version: "v1alpha1", Kind: "DNSEndpoint"}
+	serviceEntryGVK  = schema.GroupVersionKind{Group: "networking.istio.io", Version: "v1beta1", Kind: "ServiceEntry"}
+)
+
 var _ = BeforeSuite(func() {
 	Expect(v1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(operatorv1.Install(scheme.Scheme)).To(Succeed())
+
+	// Neither the MCS API nor the external-dns and Istio types are dependencies, handle them as unstructured
+	for _, gvk := range []schema.GroupVersionKind{serviceImportGVK, dnsEndpointGVK, serviceEntryGVK} {
+		scheme.Scheme.AddKnownTypeWithName(gvk, &unstructured.Unstructured{})
+		scheme.Scheme.AddKnownTypeWithName(gvk.GroupVersion().WithKind(gvk.Kind+"List"), &unstructured.UnstructuredList{})
+	}
 })
 
 var _ = Describe("", func() {
@@ -108,6 +122,13 @@ func (t *testDriver) awaitFinalizer() {
 	t.AwaitFinalizer(t.serviceDiscovery, opnames.CleanupFinalizer)
 }
 
+func (t *testDriver) getServiceDiscovery(ctx context.Context) *v1alpha1.ServiceDiscovery {
+	serviceDiscovery := &v1alpha1.ServiceDiscovery{}
+	Expect(t.ScopedClient.Get(ctx, controllerClient.ObjectKeyFromObject(t.serviceDiscovery), serviceDiscovery)).To(Succeed())
+
+	return serviceDiscovery
+}
+
 func (t *testDriver) awaitServiceDiscoveryDeleted() {
 	t.AwaitNoResource(t.serviceDiscovery)
 }
@@ -314,3 +335,37 @@ func newCoreDNSConfigMap(corefile string) *corev1.ConfigMap {
 		},
 	}
 }
+
+func newServiceImport(name, namespace string, ips ...string) *unstructured.Unstructured {
+	serviceImport := &unstructured.Unstructured{}
+	serviceImport.SetGroupVersionKind(serviceImportGVK)
+	serviceImport.SetName(name)
+	serviceImport.SetNamespace(namespace)
+
+	if len(ips) > 0 {
+		Expect(unstructured.SetNestedStringSlice(serviceImport.Object, ips, "spec", "ips")).To(Succeed())
+	}
+
+	return serviceImport
+}
+
+func (t *testDriver) getDNSEndpoints(ctx context.Context) []unstructured.Unstructured {
+	dnsEndpoints := &unstructured.UnstructuredList{}
+	dnsEndpoints.SetGroupVersionKind(dnsEndpointGVK.GroupVersion().WithKind(dnsEndpointGVK.Kind + "List"))
+	Expect(t.ScopedClient.List(ctx, dnsEndpoints, controllerClient.InNamespace(submarinerNamespace))).To(Succeed())
+
+	return dnsEndpoints.Items
+}
+
+func (t *testDriver) getServiceEntries(ctx context.Context) []unstructured.Unstructured {
+	serviceEntries := &unstructured.UnstructuredList{}
+	serviceEntries.SetGroupVersionKind(serviceEntryGVK.GroupVersion().WithKind(serviceEntryGVK.Kind + "List"))
+	Expect(t.ScopedClient.List(ctx, serviceEntries, controllerClient.InNamespace(submarinerNamespace))).To(Succeed())
+
+	return serviceEntries.Items
+}
+
+func withServiceImportPorts(serviceImport *unstructured.Unstructured, ports ...interface{}) *unstructured.Unstructured {
+	Expect(unstructured.SetNestedSlice(serviceImport.Object, ports, "spec", "ports")).To(Succeed())
+	return serviceImport
+}
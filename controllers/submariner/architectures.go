@@ -0,0 +1,198 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/controllers/apply"
+	"github.com/submariner-io/submariner-operator/pkg/images"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	architectureLabel = "submariner.io/architecture"
+	// Identifies the DaemonSets deploying a component on the nodes of an architecture with image overrides.
+	architectureVariantOfLabel = "submariner.io/architecture-variant-of"
+)
+
+// applyDaemonSet applies the given component DaemonSet, along with its architecture-specific variants, and removes
+// the variants which are no longer needed. The component's configured update strategy, if any, replaces the default
+// one. The given DaemonSet is returned as applied.
+func (r *Reconciler) applyDaemonSet(ctx context.Context, instance *v1alpha1.Submariner, daemonSet *appsv1.DaemonSet,
+	component string, reqLogger logr.Logger,
+) (*appsv1.DaemonSet, error) {
+	if strategy := instance.Spec.UpdateStrategies[component].DaemonSet; strategy != nil {
+		daemonSet.Spec.UpdateStrategy = *strategy
+	}
+
+	variants := architectureVariants(instance, daemonSet, component)
+	wanted := sets.New[string]()
+
+	var applied *appsv1.DaemonSet
+
+	for i, variant := range variants {
+		result, err := apply.DaemonSet(ctx, instance, variant, reqLogger, r.config.ScopedClient, r.config.Scheme)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // No need to wrap here
+		}
+
+		if i == 0 {
+			applied = result
+		}
+
+		wanted.Insert(variant.Name)
+	}
+
+	existing := &appsv1.DaemonSetList{}
+
+	err := r.config.ScopedClient.List(ctx, existing, client.InNamespace(instance.Namespace),
+		client.MatchingLabels{architectureVariantOfLabel: daemonSet.Name})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing the architecture variants of DaemonSet %q", daemonSet.Name)
+	}
+
+	for i := range existing.Items {
+		if wanted.Has(existing.Items[i].Name) {
+			continue
+		}
+
+		reqLogger.Info("Deleting architecture variant DaemonSet", "name", existing.Items[i].Name)
+
+		if err := r.config.ScopedClient.Delete(ctx, &existing.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "error deleting DaemonSet %q", existing.Items[i].Name)
+		}
+	}
+
+	return applied, nil
+}
+
+// architectureVariants returns the given component DaemonSet, followed by a variant of it for each architecture with
+// image overrides for the component. The variants only run on the nodes of their architecture, using the overridden
+// images, and the original DaemonSet runs on the nodes of the other architectures.
+func architectureVariants(cr *v1alpha1.Submariner, daemonSet *appsv1.DaemonSet, component string) []*appsv1.DaemonSet {
+	architectures := []string{}
+
+	for architecture, overrides := range cr.Spec.ArchitectureImageOverrides {
+		if _, ok := overrides[component]; ok {
+			architectures = append(architectures, architecture)
+		}
+	}
+
+	if len(architectures) == 0 {
+		return []*appsv1.DaemonSet{daemonSet}
+	}
+
+	sort.Strings(architectures)
+
+	defaultImage := getImagePath(cr, componentImages[component], component)
+	variants := []*appsv1.DaemonSet{daemonSet}
+
+	for _, architecture := range architectures {
+		variant := daemonSet.DeepCopy()
+		variant.Name = daemonSet.Name + "-" + architecture
+		variant.Labels[architectureVariantOfLabel] = daemonSet.Name
+		variant.Spec.Selector.MatchLabels[architectureLabel] = architecture
+		variant.Spec.Template.Labels[architectureLabel] = architecture
+		requireNodeArchitectures(&variant.Spec.Template.Spec, corev1.NodeSelectorOpIn, []string{architecture})
+
+		image := cr.Spec.ArchitectureImageOverrides[architecture][component]
+
+		for i := range variant.Spec.Template.Spec.Containers {
+			container := &variant.Spec.Template.Spec.Containers[i]
+			if container.Image == defaultImage {
+				container.Image = image
+				container.ImagePullPolicy = images.GetPullPolicy(cr.Spec.Version, image)
+			}
+		}
+
+		variants = append(variants, variant)
+	}
+
+	requireNodeArchitectures(&daemonSet.Spec.Template.Spec, corev1.NodeSelectorOpNotIn, architectures)
+
+	return variants
+}
+
+// requireNodeArchitectures restricts the pods to the nodes whose architecture matches the given requirement, in addition
+// to any existing node affinity.
+func requireNodeArchitectures(podSpec *corev1.PodSpec, operator corev1.NodeSelectorOperator, architectures []string) {
+	requireNodes(podSpec, corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{Key: corev1.LabelArchStable, Operator: operator, Values: architectures}},
+	})
+}
+
+// requireNodes restricts the pods to the nodes matching the given term, in addition to any existing node affinity.
+func requireNodes(podSpec *corev1.PodSpec, term corev1.NodeSelectorTerm) {
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	required := podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{term},
+		}
+
+		return
+	}
+
+	// The terms are ORed, so the requirements must be added to each of them
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions,
+			term.MatchExpressions...)
+		required.NodeSelectorTerms[i].MatchFields = append(required.NodeSelectorTerms[i].MatchFields, term.MatchFields...)
+	}
+}
+
+// nodeArchitectures returns the architectures of the cluster's nodes.
+func (r *Reconciler) nodeArchitectures(ctx context.Context) ([]string, error) {
+	nodes := &corev1.NodeList{}
+
+	if err := r.config.GeneralClient.List(ctx, nodes); err != nil {
+		return nil, errors.Wrap(err, "error listing the nodes")
+	}
+
+	architectures := sets.New[string]()
+
+	for i := range nodes.Items {
+		if architecture := nodes.Items[i].Labels[corev1.LabelArchStable]; architecture != "" {
+			architectures.Insert(architecture)
+		}
+	}
+
+	var result []string
+	if architectures.Len() > 0 {
+		result = sets.List(architectures)
+	}
+
+	return result, nil
+}
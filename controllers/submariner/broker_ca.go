@@ -0,0 +1,61 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func validateBrokerCABundle(bundle string) error {
+	if bundle != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(bundle)) {
+		return errors.New("the broker CA bundle doesn't contain any PEM certificate")
+	}
+
+	return nil
+}
+
+// brokerCA returns the base64-encoded CA data the components use to connect to the broker, preferring the CA bundle.
+func brokerCA(spec *v1alpha1.SubmarinerSpec) string {
+	if spec.BrokerK8sCABundle != "" {
+		return base64.StdEncoding.EncodeToString([]byte(spec.BrokerK8sCABundle))
+	}
+
+	return spec.BrokerK8sCA
+}
+
+// withBrokerCABundle replaces the CA in the given broker secret data with the CA bundle, if any.
+func withBrokerCABundle(data map[string][]byte, spec *v1alpha1.SubmarinerSpec) map[string][]byte {
+	if spec.BrokerK8sCABundle == "" {
+		return data
+	}
+
+	transformed := make(map[string][]byte, len(data)+1)
+	for k, v := range data {
+		transformed[k] = v
+	}
+
+	transformed[corev1.ServiceAccountRootCAKey] = []byte(spec.BrokerK8sCABundle)
+
+	return transformed
+}
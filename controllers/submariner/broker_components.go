@@ -0,0 +1,114 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"slices"
+
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/crd"
+	"github.com/submariner-io/submariner-operator/pkg/gateway"
+	"github.com/submariner-io/submariner-operator/pkg/lighthouse"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	serviceDiscoveryComponent = "service-discovery"
+	connectivityComponent     = "connectivity"
+)
+
+// BrokerComponent is a component with prerequisites on the broker. The Broker controller accepts the names of the
+// built-in components and of those added in the BrokerReconciler in the Broker components, and ensures the
+// prerequisites of all of them concurrently.
+type BrokerComponent interface {
+	// Name returns the name of the component, as listed in the Broker components.
+	Name() string
+
+	// Ensure deploys the component's prerequisites on the broker, such as its CRDs, roles and configuration.
+	Ensure(ctx context.Context, broker *v1alpha1.Broker, crdUpdater crd.Updater) error
+}
+
+func builtinBrokerComponents() []BrokerComponent {
+	return []BrokerComponent{
+		&connectivityBrokerComponent{},
+		&serviceDiscoveryBrokerComponent{},
+	}
+}
+
+func (r *BrokerReconciler) components() []BrokerComponent {
+	return append(builtinBrokerComponents(), r.Components...)
+}
+
+func (r *BrokerReconciler) componentNames() sets.Set[string] {
+	componentNames := sets.New[string]()
+	for _, component := range r.components() {
+		componentNames.Insert(component.Name())
+	}
+
+	return componentNames
+}
+
+// brokerComponentEnabled determines whether the given component is listed in the Broker components; an empty list means
+// all the components.
+func brokerComponentEnabled(spec *v1alpha1.BrokerSpec, component string) bool {
+	return len(spec.Components) == 0 || slices.Contains(spec.Components, component)
+}
+
+// ensureBrokerComponents ensures the prerequisites of all the broker components.
+func (r *BrokerReconciler) ensureBrokerComponents(ctx context.Context, broker *v1alpha1.Broker) error {
+	crdUpdater := crd.UpdaterFromControllerClient(r.Client, r.CRDOptions...)
+
+	components := r.components()
+	steps := make([]func() error, len(components))
+
+	for i := range components {
+		component := components[i]
+		steps[i] = func() error {
+			return component.Ensure(ctx, broker, crdUpdater)
+		}
+	}
+
+	return runConcurrently(steps...)
+}
+
+// The gateway CRDs: Clusters, Endpoints, Gateways and the Globalnet resources.
+type connectivityBrokerComponent struct{}
+
+func (c *connectivityBrokerComponent) Name() string {
+	return connectivityComponent
+}
+
+//nolint:wrapcheck // Errors are already wrapped
+func (c *connectivityBrokerComponent) Ensure(ctx context.Context, _ *v1alpha1.Broker, crdUpdater crd.Updater) error {
+	return gateway.Ensure(ctx, crdUpdater)
+}
+
+// The Lighthouse CRDs needed on the broker: ServiceImports.
+type serviceDiscoveryBrokerComponent struct{}
+
+func (c *serviceDiscoveryBrokerComponent) Name() string {
+	return serviceDiscoveryComponent
+}
+
+//nolint:wrapcheck // Errors are already wrapped
+func (c *serviceDiscoveryBrokerComponent) Ensure(ctx context.Context, _ *v1alpha1.Broker, crdUpdater crd.Updater) error {
+	_, err := lighthouse.Ensure(ctx, crdUpdater, lighthouse.BrokerCluster)
+	return err
+}
@@ -0,0 +1,88 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	brokerReasonReady             = "Ready"
+	brokerReasonFailed            = "Failed"
+	brokerReasonNamespaceMissing  = "NamespaceMissing"
+	brokerReasonNamespaceDeleting = "NamespaceTerminating"
+	brokerReasonGlobalnetEnabled  = "GlobalnetEnabled"
+	brokerReasonGlobalnetDisabled = "GlobalnetDisabled"
+)
+
+func setBrokerCondition(broker *v1alpha1.Broker, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&broker.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: broker.Generation,
+	})
+}
+
+// brokerStepFailed records the failure of a reconciliation step in the given condition, and returns the error. The
+// status is updated straight away, since the rest of the reconciliation is skipped.
+func (r *BrokerReconciler) brokerStepFailed(ctx context.Context, broker *v1alpha1.Broker, conditionType string, err error) error {
+	setBrokerCondition(broker, conditionType, metav1.ConditionFalse, brokerReasonFailed, err.Error())
+
+	if updateErr := r.Client.Status().Update(ctx, broker); updateErr != nil {
+		log.Error(updateErr, "Error updating the Broker status", "condition", conditionType)
+	}
+
+	return err
+}
+
+// reconcileBrokerNamespace checks that the broker namespace can hold the broker resources, and records it in the
+// NamespaceReady condition.
+func (r *BrokerReconciler) reconcileBrokerNamespace(ctx context.Context, broker *v1alpha1.Broker) (bool, error) {
+	namespace := &corev1.Namespace{}
+
+	err := r.Client.Get(ctx, client.ObjectKey{Name: broker.Namespace}, namespace)
+
+	switch {
+	case apierrors.IsNotFound(err):
+		setBrokerCondition(broker, v1alpha1.BrokerNamespaceReady, metav1.ConditionFalse, brokerReasonNamespaceMissing,
+			"The broker namespace "+broker.Namespace+" doesn't exist")
+		return false, nil
+	case err != nil:
+		return false, r.brokerStepFailed(ctx, broker, v1alpha1.BrokerNamespaceReady,
+			errors.Wrapf(err, "error retrieving the broker namespace %q", broker.Namespace))
+	case namespace.DeletionTimestamp != nil || namespace.Status.Phase == corev1.NamespaceTerminating:
+		setBrokerCondition(broker, v1alpha1.BrokerNamespaceReady, metav1.ConditionFalse, brokerReasonNamespaceDeleting,
+			"The broker namespace "+broker.Namespace+" is being deleted")
+		return false, nil
+	}
+
+	setBrokerCondition(broker, v1alpha1.BrokerNamespaceReady, metav1.ConditionTrue, brokerReasonReady, "")
+
+	return true, nil
+}
@@ -0,0 +1,59 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// The readiness probe's own timeout is a second.
+const brokerConnectionCheckTimeout = time.Second
+
+// BrokerConnectionCheck returns a ready check which fails if a broker secret syncer can't reach its broker, for instance
+// because its token was revoked, so that an operator which can no longer follow the broker's token rotations is
+// reported as not ready. The check retrieves the broker's version, which any authenticated client may do.
+func (r *Reconciler) BrokerConnectionCheck() healthz.Checker {
+	return func(req *http.Request) error {
+		r.syncerMutex.Lock()
+
+		brokerClients := map[secretSyncerKey]rest.Interface{}
+		for key, running := range r.secretSyncers {
+			brokerClients[key] = running.brokerClient
+		}
+
+		r.syncerMutex.Unlock()
+
+		ctx, cancel := context.WithTimeout(req.Context(), brokerConnectionCheckTimeout)
+		defer cancel()
+
+		for key, brokerClient := range brokerClients {
+			if err := brokerClient.Get().AbsPath("/version").Do(ctx).Error(); err != nil {
+				return errors.Wrapf(err, "error connecting to the broker to sync secret %q", key.secret)
+			}
+		}
+
+		return nil
+	}
+}
@@ -20,17 +20,26 @@ package submariner
 
 import (
 	"context"
+	"reflect"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/pkg/crd"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
-	"github.com/submariner-io/submariner-operator/pkg/gateway"
-	"github.com/submariner-io/submariner-operator/pkg/lighthouse"
+	"github.com/submariner-io/submariner-operator/pkg/names"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -38,11 +47,16 @@ import (
 type BrokerReconciler struct {
 	Client client.Client
 	Config *rest.Config
+	// The options used to install the broker CRDs, e.g. to use server-side apply.
+	CRDOptions []crd.Option
+	// Additional components with prerequisites on the broker, besides the built-in ones.
+	Components []BrokerComponent
 }
 
 //+kubebuilder:rbac:groups=submariner.io,resources=brokers,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=submariner.io,resources=brokers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=submariner.io,resources=brokers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=submariner.io,resources=clusters,verbs=get;list;watch
 
 func (r *BrokerReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	_ = context.Background()
@@ -67,38 +81,163 @@ func (r *BrokerReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		return reconcile.Result{}, nil
 	}
 
-	// Broker CRDs
-	crdUpdater := crd.UpdaterFromControllerClient(r.Client)
+	err = validateBrokerSpec(&instance.Spec, r.componentNames())
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "invalid Broker configuration")
+	}
 
-	err = gateway.Ensure(ctx, crdUpdater)
+	status := instance.Status.DeepCopy()
+
+	namespaceReady, err := r.reconcileBrokerNamespace(ctx, instance)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return ctrl.Result{}, err
 	}
 
-	// Lighthouse CRDs
-	_, err = lighthouse.Ensure(ctx, crdUpdater, lighthouse.BrokerCluster)
+	if !namespaceReady {
+		return r.updateBrokerStatus(ctx, instance, status)
+	}
+
+	// Component prerequisites
+	err = r.ensureBrokerComponents(ctx, instance)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return ctrl.Result{}, r.brokerStepFailed(ctx, instance, v1alpha1.BrokerCRDsInstalled, err)
 	}
 
+	setBrokerCondition(instance, v1alpha1.BrokerCRDsInstalled, metav1.ConditionTrue, brokerReasonReady, "")
+
 	// Globalnet
 	err = globalnet.ValidateExistingGlobalNetworks(ctx, r.Client, request.Namespace)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return ctrl.Result{}, r.brokerStepFailed(ctx, instance, v1alpha1.BrokerGlobalnetConfigured, err)
 	}
 
 	err = globalnet.CreateConfigMap(ctx, r.Client, instance.Spec.GlobalnetEnabled, instance.Spec.GlobalnetCIDRRange,
 		instance.Spec.DefaultGlobalnetClusterSize, request.Namespace)
 	if err != nil {
-		return ctrl.Result{}, err //nolint:wrapcheck // Errors are already wrapped
+		return ctrl.Result{}, r.brokerStepFailed(ctx, instance, v1alpha1.BrokerGlobalnetConfigured, err)
+	}
+
+	globalnetReason := brokerReasonGlobalnetEnabled
+	if !instance.Spec.GlobalnetEnabled {
+		globalnetReason = brokerReasonGlobalnetDisabled
+	}
+
+	setBrokerCondition(instance, v1alpha1.BrokerGlobalnetConfigured, metav1.ConditionTrue, globalnetReason, "")
+
+	// Access for the joined clusters and the broker admin
+	err = r.reconcileBrokerRBAC(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, r.brokerStepFailed(ctx, instance, v1alpha1.BrokerRBACReady, err)
+	}
+
+	err = r.reconcileFederatedClusterSubjects(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, r.brokerStepFailed(ctx, instance, v1alpha1.BrokerRBACReady, err)
+	}
+
+	err = r.reconcileClusterEnrollment(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, r.brokerStepFailed(ctx, instance, v1alpha1.BrokerRBACReady, err)
+	}
+
+	err = r.reconcileClusterIsolation(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, r.brokerStepFailed(ctx, instance, v1alpha1.BrokerRBACReady, err)
+	}
+
+	setBrokerCondition(instance, v1alpha1.BrokerRBACReady, metav1.ConditionTrue, brokerReasonReady, "")
+
+	err = r.reconcileGlobalnetAllocations(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Joined cluster tokens
+	err = r.reconcileClusterTokens(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Read-only observers
+	err = r.reconcileBrokerObservers(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Departed clusters
+	err = r.reconcileStaleClusters(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, nil
+	err = r.reconcileStaleEndpointSlices(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Broker namespace growth
+	err = r.reconcileObjectCounts(ctx, instance)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return r.updateBrokerStatus(ctx, instance, status)
+}
+
+// updateBrokerStatus updates the Broker status if it changed, and requeues the Broker to refresh the object counts.
+func (r *BrokerReconciler) updateBrokerStatus(ctx context.Context, instance *v1alpha1.Broker, previous *v1alpha1.BrokerStatus,
+) (ctrl.Result, error) {
+	if !reflect.DeepEqual(previous, &instance.Status) {
+		err := r.Client.Status().Update(ctx, instance)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "error updating the Broker status")
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: objectCountInterval}, nil
 }
 
 //nolint:wrapcheck // No need to wrap here.
 func (r *BrokerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1.Broker{}).
+		// Watch for global CIDR allocations, which are recorded in the globalnet ConfigMap
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.brokersInNamespace),
+			builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+				return object.GetName() == globalnet.GlobalCIDRConfigMapName
+			}))).
+		// Watch for clusters joining or leaving
+		Watches(&submv1.Cluster{}, handler.EnqueueRequestsFromMapFunc(r.brokersInNamespace)).
+		// Watch for endpoints being added or removed, which isolated clusters are restricted to
+		Watches(&submv1.Endpoint{}, handler.EnqueueRequestsFromMapFunc(r.brokersInNamespace),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(_ event.UpdateEvent) bool { return false },
+			})).
+		// Watch for changes to the broker RBAC, to restore it, and for clusters enrolling
+		Watches(&corev1.ServiceAccount{}, handler.EnqueueRequestsFromMapFunc(r.brokersInNamespace), brokerRBACPredicate).
+		Watches(&rbacv1.Role{}, handler.EnqueueRequestsFromMapFunc(r.brokersInNamespace), brokerRBACPredicate).
+		Watches(&rbacv1.RoleBinding{}, handler.EnqueueRequestsFromMapFunc(r.brokersInNamespace), brokerRBACPredicate).
 		Complete(r)
 }
+
+var brokerRBACPredicate = builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+	// The cluster service accounts, roles and role bindings are watched for the cluster enrollment and isolation
+	return brokerRBACNames.Has(object.GetName()) || strings.HasPrefix(object.GetName(), names.ForClusterSA(""))
+}))
+
+func (r *BrokerReconciler) brokersInNamespace(ctx context.Context, object client.Object) []reconcile.Request {
+	brokers := &v1alpha1.BrokerList{}
+
+	err := r.Client.List(ctx, brokers, client.InNamespace(object.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Error listing the Broker resources")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(brokers.Items))
+	for i := range brokers.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&brokers.Items[i])})
+	}
+
+	return requests
+}
@@ -19,18 +19,37 @@ limitations under the License.
 package submariner_test
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	submarinerController "github.com/submariner-io/submariner-operator/controllers/submariner"
 	"github.com/submariner-io/submariner-operator/controllers/test"
+	"github.com/submariner-io/submariner-operator/pkg/crd"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
+	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-const brokerName = "test-broker"
+const (
+	brokerName           = "test-broker"
+	staleSinceAnnotation = "submariner.io/stale-since"
+)
 
 var _ = Describe("Broker controller tests", func() {
 	t := test.Driver{
@@ -38,10 +57,14 @@ var _ = Describe("Broker controller tests", func() {
 		ResourceName: brokerName,
 	}
 
-	var broker *v1alpha1.Broker
+	var (
+		broker     *v1alpha1.Broker
+		components []submarinerController.BrokerComponent
+	)
 
 	BeforeEach(func() {
 		t.BeforeEach()
+		components = nil
 		broker = &v1alpha1.Broker{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      brokerName,
@@ -54,19 +77,20 @@ var _ = Describe("Broker controller tests", func() {
 			},
 		}
 
-		t.InitScopedClientObjs = []client.Object{broker}
+		t.InitScopedClientObjs = []client.Object{broker, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: submarinerNamespace}}}
 	})
 
 	JustBeforeEach(func() {
 		t.JustBeforeEach()
 
 		t.Controller = &submarinerController.BrokerReconciler{
-			Client: t.ScopedClient,
+			Client:     t.ScopedClient,
+			Components: components,
 		}
 	})
 
 	It("should create the globalnet ConfigMap", func(ctx SpecContext) {
-		t.AssertReconcileSuccess(ctx)
+		t.AssertReconcileRequeue(ctx)
 
 		globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, t.ScopedClient, submarinerNamespace)
 		Expect(err).To(Succeed())
@@ -75,7 +99,7 @@ var _ = Describe("Broker controller tests", func() {
 	})
 
 	It("should create the CRDs", func(ctx SpecContext) {
-		t.AssertReconcileSuccess(ctx)
+		t.AssertReconcileRequeue(ctx)
 
 		crd := &apiextensions.CustomResourceDefinition{}
 		Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "clusters.submariner.io"}, crd)).To(Succeed())
@@ -84,6 +108,845 @@ var _ = Describe("Broker controller tests", func() {
 		Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "serviceimports.multicluster.x-k8s.io"}, crd)).To(Succeed())
 	})
 
+	It("should record the object counts in the Broker status", func(ctx SpecContext) {
+		t.AssertReconcileRequeue(ctx)
+
+		status := getBroker(ctx, t.ScopedClient).Status
+		Expect(status.ObjectCounts).To(HaveKeyWithValue("Endpoint", 0))
+		Expect(status.ObjectCounts).To(HaveKeyWithValue("ServiceImport", 0))
+		Expect(status.ObjectCounts).To(HaveKeyWithValue("EndpointSlice", 0))
+		Expect(status.ObjectCountWarnings).To(BeEmpty())
+	})
+
+	When("global CIDRs have been allocated", func() {
+		var clusterInfo string
+
+		BeforeEach(func() {
+			clusterInfo = `[{"cluster_id":"west","global_cidr":["168.254.32.0/19"]},{"cluster_id":"east","global_cidr":["168.254.0.0/19"]}]`
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			configMap, err := globalnet.NewGlobalnetConfigMap(true, broker.Spec.GlobalnetCIDRRange,
+				broker.Spec.DefaultGlobalnetClusterSize, submarinerNamespace)
+			Expect(err).To(Succeed())
+
+			configMap.Data["clusterinfo"] = clusterInfo
+			Expect(t.ScopedClient.Create(ctx, configMap)).To(Succeed())
+
+			t.AssertReconcileRequeue(ctx)
+		})
+
+		It("should record the allocations in the Broker status", func(ctx SpecContext) {
+			status := getBroker(ctx, t.ScopedClient).Status
+			Expect(status.GlobalnetAllocations).To(Equal([]v1alpha1.GlobalnetAllocation{
+				{ClusterID: "east", GlobalCIDRs: []string{"168.254.0.0/19"}},
+				{ClusterID: "west", GlobalCIDRs: []string{"168.254.32.0/19"}},
+			}))
+			Expect(status.GlobalnetConflicts).To(BeEmpty())
+		})
+
+		Context("with overlapping CIDRs", func() {
+			BeforeEach(func() {
+				clusterInfo = `[{"cluster_id":"west","global_cidr":["168.254.16.0/20"]},{"cluster_id":"east","global_cidr":["168.254.0.0/19"]}]`
+			})
+
+			It("should record the conflict in the Broker status", func(ctx SpecContext) {
+				Expect(getBroker(ctx, t.ScopedClient).Status.GlobalnetConflicts).To(HaveLen(1))
+			})
+		})
+	})
+
+	When("the number of objects exceeds the warning threshold", func() {
+		BeforeEach(func() {
+			broker.Spec.ObjectCountWarningThreshold = 1
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newEndpoint("east"), newEndpoint("west"))
+		})
+
+		It("should raise a warning in the Broker status", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			status := getBroker(ctx, t.ScopedClient).Status
+			Expect(status.ObjectCounts).To(HaveKeyWithValue("Endpoint", 2))
+			Expect(status.ObjectCountWarnings).To(HaveLen(1))
+			Expect(status.ObjectCountWarnings[0]).To(ContainSubstring("Endpoint"))
+		})
+	})
+
+	When("cluster token rotation is configured", func() {
+		var oldToken *corev1.Secret
+
+		BeforeEach(func() {
+			oldToken = newClusterToken("east", time.Now().Add(-48*time.Hour))
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newCluster("east"), oldToken,
+				newCluster("west"), newClusterToken("west", time.Now().Add(-time.Hour)))
+		})
+
+		Context("with an interval", func() {
+			BeforeEach(func() {
+				broker.Spec.ClusterTokenRotation = &v1alpha1.ClusterTokenRotation{
+					Interval: &metav1.Duration{Duration: 24 * time.Hour},
+				}
+			})
+
+			It("should replace the expired tokens and record the current tokens in the Broker status", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				tokens := getBroker(ctx, t.ScopedClient).Status.ClusterTokens
+				Expect(tokens).To(HaveLen(2))
+				Expect(tokens[0].ClusterID).To(Equal("east"))
+				Expect(tokens[0].SecretName).ToNot(Equal(oldToken.Name))
+				Expect(tokens[0].IssueTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+				Expect(tokens[1].ClusterID).To(Equal("west"))
+				Expect(tokens[1].SecretName).To(Equal("cluster-west-token"))
+
+				newToken := &corev1.Secret{}
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: tokens[0].SecretName, Namespace: submarinerNamespace},
+					newToken)).To(Succeed())
+				Expect(newToken.Type).To(Equal(corev1.SecretTypeServiceAccountToken))
+				Expect(newToken.Annotations).To(HaveKeyWithValue(corev1.ServiceAccountNameKey, opnames.ForClusterSA("east")))
+
+				t.AssertReconcileRequeue(ctx)
+				Expect(getBroker(ctx, t.ScopedClient).Status.ClusterTokens).To(Equal(tokens))
+			})
+
+			It("should keep the previous tokens until the new ones are populated and the overlap period has elapsed",
+				func(ctx SpecContext) {
+					t.AssertReconcileRequeue(ctx)
+
+					previous := &corev1.Secret{}
+					Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(oldToken), previous)).To(Succeed())
+
+					newTokenName := getBroker(ctx, t.ScopedClient).Status.ClusterTokens[0].SecretName
+					Expect(previous.Annotations).To(HaveKeyWithValue("submariner.io/token-superseded", newTokenName))
+
+					newToken := &corev1.Secret{}
+					Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: newTokenName, Namespace: submarinerNamespace},
+						newToken)).To(Succeed())
+
+					newToken.Data = map[string][]byte{corev1.ServiceAccountTokenKey: []byte("rotated")}
+					Expect(t.ScopedClient.Update(ctx, newToken)).To(Succeed())
+
+					t.AssertReconcileRequeue(ctx)
+					Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(oldToken), &corev1.Secret{})).To(Succeed())
+
+					newToken.Annotations["submariner.io/token-issue-time"] = time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+					Expect(t.ScopedClient.Update(ctx, newToken)).To(Succeed())
+
+					t.AssertReconcileRequeue(ctx)
+
+					err := t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(oldToken), &corev1.Secret{})
+					Expect(apierrors.IsNotFound(err)).To(BeTrue())
+					Expect(getBroker(ctx, t.ScopedClient).Status.ClusterTokens[0].SecretName).To(Equal(newTokenName))
+				})
+		})
+
+		Context("with a cut-off time for specific clusters", func() {
+			BeforeEach(func() {
+				broker.Spec.ClusterTokenRotation = &v1alpha1.ClusterTokenRotation{
+					RotateIssuedBefore: ptr.To(metav1.Now()),
+					ClusterIDs:         []string{"west"},
+				}
+			})
+
+			It("should only replace the tokens of those clusters", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				tokens := getBroker(ctx, t.ScopedClient).Status.ClusterTokens
+				Expect(tokens).To(HaveLen(2))
+				Expect(tokens[0].SecretName).To(Equal(oldToken.Name))
+				Expect(tokens[1].SecretName).ToNot(Equal("cluster-west-token"))
+			})
+
+			It("should delete the previous tokens as soon as the new ones are populated", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				newToken := &corev1.Secret{}
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{
+					Name: getBroker(ctx, t.ScopedClient).Status.ClusterTokens[1].SecretName, Namespace: submarinerNamespace,
+				}, newToken)).To(Succeed())
+
+				t.AssertReconcileRequeue(ctx)
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "cluster-west-token", Namespace: submarinerNamespace},
+					&corev1.Secret{})).To(Succeed())
+
+				newToken.Data = map[string][]byte{corev1.ServiceAccountTokenKey: []byte("rotated")}
+				Expect(t.ScopedClient.Update(ctx, newToken)).To(Succeed())
+
+				t.AssertReconcileRequeue(ctx)
+
+				err := t.ScopedClient.Get(ctx, client.ObjectKey{Name: "cluster-west-token", Namespace: submarinerNamespace},
+					&corev1.Secret{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	It("should create the broker RBAC", func(ctx SpecContext) {
+		t.AssertReconcileRequeue(ctx)
+
+		Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "submariner-k8s-broker-client", Namespace: submarinerNamespace},
+			&corev1.ServiceAccount{})).To(Succeed())
+		Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "submariner-k8s-broker-admin", Namespace: submarinerNamespace},
+			&rbacv1.Role{})).To(Succeed())
+
+		roleBinding := &rbacv1.RoleBinding{}
+		Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "submariner-k8s-broker-client", Namespace: submarinerNamespace},
+			roleBinding)).To(Succeed())
+		Expect(roleBinding.Subjects).To(HaveExactElements(rbacv1.Subject{
+			Kind: rbacv1.ServiceAccountKind, Name: "submariner-k8s-broker-client", Namespace: submarinerNamespace,
+		}))
+	})
+
+	When("the broker RBAC is modified", func() {
+		It("should restore it and record the repair in the Broker status", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			role := &rbacv1.Role{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "submariner-k8s-broker-cluster", Namespace: submarinerNamespace},
+				role)).To(Succeed())
+
+			expectedRules := role.Rules
+			role.Rules = role.Rules[:1]
+			Expect(t.ScopedClient.Update(ctx, role)).To(Succeed())
+
+			Expect(t.ScopedClient.Delete(ctx, &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{
+				Name: "submariner-k8s-broker-client", Namespace: submarinerNamespace,
+			}})).To(Succeed())
+
+			t.AssertReconcileRequeue(ctx)
+
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(role), role)).To(Succeed())
+			Expect(role.Rules).To(Equal(expectedRules))
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "submariner-k8s-broker-client", Namespace: submarinerNamespace},
+				&rbacv1.RoleBinding{})).To(Succeed())
+
+			repair := getBroker(ctx, t.ScopedClient).Status.LastRBACRepair
+			Expect(repair).ToNot(BeNil())
+			Expect(repair.Problems).To(ConsistOf(
+				`the rules of Role "submariner-k8s-broker-cluster" were modified`,
+				`the RoleBinding "submariner-k8s-broker-client" was missing`))
+		})
+	})
+
+	When("the broker role is modified concurrently while being restored", func() {
+		BeforeEach(func() {
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, &rbacv1.Role{
+				ObjectMeta: metav1.ObjectMeta{Name: "submariner-k8s-broker-cluster", Namespace: submarinerNamespace},
+				Rules: []rbacv1.PolicyRule{{
+					Verbs: []string{"get"}, APIGroups: []string{""}, Resources: []string{"configmaps"},
+				}},
+			})
+
+			conflicted := false
+
+			t.InterceptorFuncs.Update = func(ctx context.Context, c client.WithWatch, obj client.Object,
+				opts ...client.UpdateOption,
+			) error {
+				if _, ok := obj.(*rbacv1.Role); ok && !conflicted {
+					conflicted = true
+					return apierrors.NewConflict(rbacv1.Resource("roles"), obj.GetName(), errors.New("fake conflict"))
+				}
+
+				return c.Update(ctx, obj, opts...)
+			}
+		})
+
+		It("should retry", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			role := &rbacv1.Role{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "submariner-k8s-broker-cluster", Namespace: submarinerNamespace},
+				role)).To(Succeed())
+			Expect(role.Rules).ToNot(HaveLen(1))
+		})
+	})
+
+	When("observers are configured", func() {
+		BeforeEach(func() {
+			broker.Spec.Observers = []string{"grafana", "inventory"}
+		})
+
+		It("should give each one a read-only token", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			Expect(getBroker(ctx, t.ScopedClient).Status.ObserverTokens).To(Equal([]v1alpha1.ObserverToken{
+				{Name: "grafana", SecretName: "broker-observer-grafana-token"},
+				{Name: "inventory", SecretName: "broker-observer-inventory-token"},
+			}))
+
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "broker-observer-grafana", Namespace: submarinerNamespace},
+				roleBinding)).To(Succeed())
+			Expect(roleBinding.RoleRef.Name).To(Equal("submariner-k8s-broker-observer"))
+
+			role := &rbacv1.Role{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "submariner-k8s-broker-observer", Namespace: submarinerNamespace},
+				role)).To(Succeed())
+
+			for i := range role.Rules {
+				Expect(role.Rules[i].Verbs).To(ConsistOf("get", "list", "watch"))
+			}
+
+			token := &corev1.Secret{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "broker-observer-grafana-token", Namespace: submarinerNamespace},
+				token)).To(Succeed())
+			Expect(token.Annotations).To(HaveKeyWithValue(corev1.ServiceAccountNameKey, "broker-observer-grafana"))
+		})
+
+		Context("and one is removed", func() {
+			It("should revoke its access", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				broker := getBroker(ctx, t.ScopedClient)
+				broker.Spec.Observers = []string{"inventory"}
+				Expect(t.ScopedClient.Update(ctx, broker)).To(Succeed())
+
+				t.AssertReconcileRequeue(ctx)
+
+				Expect(getBroker(ctx, t.ScopedClient).Status.ObserverTokens).To(HaveExactElements(HaveField("Name", "inventory")))
+
+				for _, obj := range []client.Object{&corev1.ServiceAccount{}, &rbacv1.RoleBinding{}} {
+					err := t.ScopedClient.Get(ctx, client.ObjectKey{Name: "broker-observer-grafana", Namespace: submarinerNamespace}, obj)
+					Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%T still exists", obj)
+				}
+
+				err := t.ScopedClient.Get(ctx, client.ObjectKey{Name: "broker-observer-grafana-token", Namespace: submarinerNamespace},
+					&corev1.Secret{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	When("federated cluster subjects are configured", func() {
+		BeforeEach(func() {
+			broker.Spec.FederatedClusterSubjects = []rbacv1.Subject{
+				{Kind: rbacv1.GroupKind, Name: "oidc:submariner-clusters"},
+				{Kind: rbacv1.UserKind, Name: "oidc:west"},
+			}
+		})
+
+		getRoleBinding := func(ctx context.Context) (*rbacv1.RoleBinding, error) {
+			roleBinding := &rbacv1.RoleBinding{}
+			err := t.ScopedClient.Get(ctx, client.ObjectKey{Name: "submariner-k8s-broker-federated-clusters", Namespace: submarinerNamespace},
+				roleBinding)
+
+			return roleBinding, err
+		}
+
+		It("should give them the joined clusters' access", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			roleBinding, err := getRoleBinding(ctx)
+			Expect(err).To(Succeed())
+			Expect(roleBinding.RoleRef.Name).To(Equal("submariner-k8s-broker-cluster"))
+			Expect(roleBinding.Subjects).To(HaveExactElements(
+				rbacv1.Subject{Kind: rbacv1.GroupKind, APIGroup: rbacv1.GroupName, Name: "oidc:submariner-clusters"},
+				rbacv1.Subject{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "oidc:west"}))
+		})
+
+		Context("and are then removed", func() {
+			It("should revoke their access", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				broker := getBroker(ctx, t.ScopedClient)
+				broker.Spec.FederatedClusterSubjects = nil
+				Expect(t.ScopedClient.Update(ctx, broker)).To(Succeed())
+
+				t.AssertReconcileRequeue(ctx)
+
+				_, err := getRoleBinding(ctx)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	When("a cluster has left the clusterset", func() {
+		BeforeEach(func() {
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newCluster("east"),
+				newClusterSA("east"), newClusterRoleBinding("east"),
+				newClusterSA("west"), newClusterRoleBinding("west"), newClusterToken("west", time.Now()),
+				newExportedEndpointSlice("nginx-default-east", "east"), newExportedEndpointSlice("nginx-default-west", "west"),
+				&rbacv1.RoleBinding{
+					ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: submarinerNamespace},
+					Subjects: []rbacv1.Subject{
+						{Kind: rbacv1.ServiceAccountKind, Name: opnames.ForClusterSA("west")},
+						{Kind: rbacv1.ServiceAccountKind, Name: opnames.ForClusterSA("east")},
+					},
+				})
+		})
+
+		It("should record its service account and EndpointSlices in the Broker status", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			status := getBroker(ctx, t.ScopedClient).Status
+			Expect(status.StaleClusterServiceAccounts).To(Equal([]string{opnames.ForClusterSA("west")}))
+			Expect(status.StaleClusterEndpointSlices).To(Equal([]string{"nginx-default-west"}))
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterSA("west")), &corev1.ServiceAccount{})).To(Succeed())
+		})
+
+		Context("and stale cluster cleanup is enabled", func() {
+			BeforeEach(func() {
+				broker.Spec.StaleClusterCleanup = &v1alpha1.StaleClusterCleanup{}
+			})
+
+			It("should only start the grace period of its resources once they are found stale", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				status := getBroker(ctx, t.ScopedClient).Status
+				Expect(status.StaleClusterServiceAccounts).To(Equal([]string{opnames.ForClusterSA("west")}))
+				Expect(status.StaleClusterEndpointSlices).To(Equal([]string{"nginx-default-west"}))
+
+				sa := &corev1.ServiceAccount{}
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterSA("west")), sa)).To(Succeed())
+				Expect(sa.Annotations).To(HaveKey(staleSinceAnnotation))
+
+				endpointSlice := &discoveryv1.EndpointSlice{}
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newExportedEndpointSlice("nginx-default-west", "west")),
+					endpointSlice)).To(Succeed())
+				Expect(endpointSlice.Annotations).To(HaveKey(staleSinceAnnotation))
+			})
+
+			Context("and the grace period has elapsed", func() {
+				BeforeEach(func() {
+					markStaleSince(t.InitScopedClientObjs, time.Now().Add(-48*time.Hour))
+				})
+
+				It("should delete its service account, role bindings, tokens and EndpointSlices", func(ctx SpecContext) {
+					t.AssertReconcileRequeue(ctx)
+
+					status := getBroker(ctx, t.ScopedClient).Status
+					Expect(status.StaleClusterServiceAccounts).To(BeEmpty())
+					Expect(status.StaleClusterEndpointSlices).To(BeEmpty())
+
+					for _, obj := range []client.Object{
+						newClusterSA("west"), newClusterRoleBinding("west"), newClusterToken("west", time.Now()),
+						newExportedEndpointSlice("nginx-default-west", "west"),
+					} {
+						err := t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+						Expect(apierrors.IsNotFound(err)).To(BeTrue(), "%T %q still exists", obj, obj.GetName())
+					}
+
+					for _, obj := range []client.Object{
+						newClusterSA("east"), newClusterRoleBinding("east"), newExportedEndpointSlice("nginx-default-east", "east"),
+						&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: submarinerNamespace}},
+					} {
+						Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(obj), obj)).To(Succeed())
+					}
+				})
+			})
+
+			Context("and its Cluster resource reappears", func() {
+				It("should no longer consider it stale", func(ctx SpecContext) {
+					t.AssertReconcileRequeue(ctx)
+
+					Expect(t.ScopedClient.Create(ctx, newCluster("west"))).To(Succeed())
+					t.AssertReconcileRequeue(ctx)
+
+					Expect(getBroker(ctx, t.ScopedClient).Status.StaleClusterServiceAccounts).To(BeEmpty())
+
+					sa := &corev1.ServiceAccount{}
+					Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterSA("west")), sa)).To(Succeed())
+					Expect(sa.Annotations).ToNot(HaveKey(staleSinceAnnotation))
+				})
+			})
+
+			Context("and its resources are within the grace period", func() {
+				BeforeEach(func() {
+					broker.Spec.StaleClusterCleanup.GracePeriod = &metav1.Duration{Duration: time.Hour}
+
+					markStaleSince(t.InitScopedClientObjs, time.Now().Add(-time.Minute))
+				})
+
+				It("should not delete them", func(ctx SpecContext) {
+					t.AssertReconcileRequeue(ctx)
+
+					status := getBroker(ctx, t.ScopedClient).Status
+					Expect(status.StaleClusterServiceAccounts).To(Equal([]string{opnames.ForClusterSA("west")}))
+					Expect(status.StaleClusterEndpointSlices).To(Equal([]string{"nginx-default-west"}))
+					Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterSA("west")), &corev1.ServiceAccount{})).To(Succeed())
+				})
+			})
+		})
+	})
+
+	When("the connectivity component isn't deployed", func() {
+		BeforeEach(func() {
+			broker.Spec.Components = []string{"service-discovery"}
+			broker.Spec.GlobalnetEnabled = false
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newClusterSA("west"),
+				newExportedEndpointSlice("nginx-default-west", "west"))
+		})
+
+		It("should not consider the clusters stale", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			status := getBroker(ctx, t.ScopedClient).Status
+			Expect(status.StaleClusterServiceAccounts).To(BeEmpty())
+			Expect(status.StaleClusterEndpointSlices).To(BeEmpty())
+		})
+	})
+
+	When("cluster enrollment approval is required", func() {
+		BeforeEach(func() {
+			broker.Spec.ClusterEnrollment = &v1alpha1.ClusterEnrollment{
+				ApprovedClusters:    []string{"east"},
+				AutoApprovePatterns: []string{"staging-*"},
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newClusterSA("east"),
+				newClusterSA("west"), newClusterRoleBinding("west"), newClusterSA("staging-1"))
+		})
+
+		It("should withhold the access of the clusters which aren't approved", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			Expect(getBroker(ctx, t.ScopedClient).Status.PendingClusters).To(Equal([]string{"west"}))
+
+			err := t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterRoleBinding("west")), &rbacv1.RoleBinding{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			for _, clusterID := range []string{"east", "staging-1"} {
+				roleBinding := &rbacv1.RoleBinding{}
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterRoleBinding(clusterID)), roleBinding)).To(Succeed())
+				Expect(roleBinding.RoleRef).To(Equal(newClusterRoleBinding(clusterID).RoleRef))
+				Expect(roleBinding.Subjects).To(Equal(newClusterRoleBinding(clusterID).Subjects))
+			}
+		})
+
+		Context("and stale cluster cleanup is enabled", func() {
+			BeforeEach(func() {
+				broker.Spec.StaleClusterCleanup = &v1alpha1.StaleClusterCleanup{GracePeriod: &metav1.Duration{}}
+			})
+
+			It("should not consider the pending clusters stale", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterSA("west")), &corev1.ServiceAccount{})).
+					To(Succeed())
+				Expect(getBroker(ctx, t.ScopedClient).Status.StaleClusterServiceAccounts).ToNot(ContainElement(
+					opnames.ForClusterSA("west")))
+			})
+		})
+
+		Context("and a pending cluster is subsequently approved", func() {
+			It("should grant its access", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				broker := getBroker(ctx, t.ScopedClient)
+				broker.Spec.ClusterEnrollment.ApprovedClusters = append(broker.Spec.ClusterEnrollment.ApprovedClusters, "west")
+				Expect(t.ScopedClient.Update(ctx, broker)).To(Succeed())
+
+				t.AssertReconcileRequeue(ctx)
+
+				Expect(getBroker(ctx, t.ScopedClient).Status.PendingClusters).To(BeEmpty())
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterRoleBinding("west")), &rbacv1.RoleBinding{})).
+					To(Succeed())
+			})
+		})
+	})
+
+	When("cluster isolation is enabled", func() {
+		BeforeEach(func() {
+			broker.Spec.ClusterIsolation = true
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newClusterSA("east"), newClusterRoleBinding("east"),
+				newCluster("east"), newEndpoint("east"), newCluster("west"), newEndpoint("west"))
+		})
+
+		It("should bind each cluster to a role restricted to its own resources", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			role := &rbacv1.Role{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: opnames.ForClusterSA("east"), Namespace: submarinerNamespace},
+				role)).To(Succeed())
+			Expect(role.Rules).To(ContainElements(
+				rbacv1.PolicyRule{
+					APIGroups: []string{"submariner.io"},
+					Resources: []string{"clusters", "endpoints"},
+					Verbs:     []string{"create", "get", "list", "watch"},
+				},
+				rbacv1.PolicyRule{
+					APIGroups:     []string{"submariner.io"},
+					Resources:     []string{"clusters"},
+					ResourceNames: []string{"east"},
+					Verbs:         []string{"delete", "patch", "update"},
+				},
+				rbacv1.PolicyRule{
+					APIGroups:     []string{"submariner.io"},
+					Resources:     []string{"endpoints"},
+					ResourceNames: []string{"east"},
+					Verbs:         []string{"delete", "patch", "update"},
+				}))
+			Expect(role.Rules).ToNot(ContainElement(HaveField("ResourceNames", ContainElement("west"))))
+
+			roleBinding := &rbacv1.RoleBinding{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterRoleBinding("east")), roleBinding)).To(Succeed())
+			Expect(roleBinding.RoleRef.Name).To(Equal(role.Name))
+		})
+
+		Context("and subsequently disabled", func() {
+			It("should bind the clusters to the shared role again", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				broker := getBroker(ctx, t.ScopedClient)
+				broker.Spec.ClusterIsolation = false
+				Expect(t.ScopedClient.Update(ctx, broker)).To(Succeed())
+
+				t.AssertReconcileRequeue(ctx)
+
+				roleBinding := &rbacv1.RoleBinding{}
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterRoleBinding("east")), roleBinding)).To(Succeed())
+				Expect(roleBinding.RoleRef).To(Equal(newClusterRoleBinding("east").RoleRef))
+
+				err := t.ScopedClient.Get(ctx, client.ObjectKey{Name: opnames.ForClusterSA("east"), Namespace: submarinerNamespace},
+					&rbacv1.Role{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		Context("and the cluster enrollment isn't approved", func() {
+			BeforeEach(func() {
+				broker.Spec.ClusterEnrollment = &v1alpha1.ClusterEnrollment{}
+			})
+
+			It("should not grant the cluster access", func(ctx SpecContext) {
+				t.AssertReconcileRequeue(ctx)
+
+				err := t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterRoleBinding("east")), &rbacv1.RoleBinding{})
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	It("should report the broker readiness in the status conditions", func(ctx SpecContext) {
+		t.AssertReconcileRequeue(ctx)
+
+		conditions := getBroker(ctx, t.ScopedClient).Status.Conditions
+		for _, conditionType := range []string{
+			v1alpha1.BrokerNamespaceReady, v1alpha1.BrokerCRDsInstalled,
+			v1alpha1.BrokerGlobalnetConfigured, v1alpha1.BrokerRBACReady,
+		} {
+			Expect(meta.IsStatusConditionTrue(conditions, conditionType)).To(BeTrue(), "Condition %s", conditionType)
+		}
+	})
+
+	When("another broker is deployed in a different namespace", func() {
+		const stagingNamespace = "staging-broker"
+
+		BeforeEach(func() {
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs,
+				&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: stagingNamespace}},
+				&v1alpha1.Broker{
+					ObjectMeta: metav1.ObjectMeta{Name: brokerName, Namespace: stagingNamespace},
+					Spec:       v1alpha1.BrokerSpec{GlobalnetCIDRRange: "242.0.0.0/16", GlobalnetEnabled: true},
+				})
+		})
+
+		It("should deploy each broker in its own namespace", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			_, err := t.Controller.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name: brokerName, Namespace: stagingNamespace,
+			}})
+			Expect(err).To(Succeed())
+
+			for namespace, cidrRange := range map[string]string{
+				submarinerNamespace: broker.Spec.GlobalnetCIDRRange,
+				stagingNamespace:    "242.0.0.0/16",
+			} {
+				globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, t.ScopedClient, namespace)
+				Expect(err).To(Succeed())
+				Expect(globalnetInfo.CidrRange).To(Equal(cidrRange))
+
+				roleBinding := &rbacv1.RoleBinding{}
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "submariner-k8s-broker-client", Namespace: namespace},
+					roleBinding)).To(Succeed())
+				Expect(roleBinding.Subjects).To(HaveExactElements(HaveField("Namespace", namespace)))
+			}
+		})
+	})
+
+	When("the broker namespace is being deleted", func() {
+		BeforeEach(func() {
+			t.InitScopedClientObjs = []client.Object{broker, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: submarinerNamespace},
+				Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+			}}
+		})
+
+		It("should report it in the status conditions and not deploy the broker", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			condition := meta.FindStatusCondition(getBroker(ctx, t.ScopedClient).Status.Conditions, v1alpha1.BrokerNamespaceReady)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("NamespaceTerminating"))
+
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "clusters.submariner.io"},
+				&apiextensions.CustomResourceDefinition{})).To(Satisfy(apierrors.IsNotFound))
+		})
+	})
+
+	When("an additional component is registered", func() {
+		var component *fakeBrokerComponent
+
+		BeforeEach(func() {
+			component = &fakeBrokerComponent{}
+			components = []submarinerController.BrokerComponent{component}
+			broker.Spec.Components = []string{"connectivity", "fake"}
+		})
+
+		It("should accept it in the Broker and ensure its prerequisites", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			Expect(component.ensuredBroker).To(Equal(brokerName))
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "clusters.submariner.io"},
+				&apiextensions.CustomResourceDefinition{})).To(Succeed())
+		})
+
+		Context("and its prerequisites can't be ensured", func() {
+			BeforeEach(func() {
+				component.err = errors.New("fake error")
+			})
+
+			It("should report it in the status conditions", func(ctx SpecContext) {
+				t.AssertReconcileError(ctx)
+
+				condition := meta.FindStatusCondition(getBroker(ctx, t.ScopedClient).Status.Conditions, v1alpha1.BrokerCRDsInstalled)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Message).To(ContainSubstring("fake error"))
+			})
+		})
+	})
+
+	When("the Broker configuration is invalid", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			t.AssertReconcileError(ctx)
+		})
+
+		Context("because of an unknown component", func() {
+			BeforeEach(func() {
+				broker.Spec.Components = []string{"connectivity", "bogus"}
+			})
+
+			It("should not create the CRDs", func(ctx SpecContext) {
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "clusters.submariner.io"},
+					&apiextensions.CustomResourceDefinition{})).To(Satisfy(apierrors.IsNotFound))
+			})
+		})
+
+		Context("because of an invalid observer name", func() {
+			BeforeEach(func() {
+				broker.Spec.Observers = []string{"Grafana.Dashboards"}
+			})
+
+			It("should not create the CRDs", func(ctx SpecContext) {
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "clusters.submariner.io"},
+					&apiextensions.CustomResourceDefinition{})).To(Satisfy(apierrors.IsNotFound))
+			})
+		})
+
+		Context("because of an unsupported federated cluster subject", func() {
+			BeforeEach(func() {
+				broker.Spec.FederatedClusterSubjects = []rbacv1.Subject{
+					{Kind: rbacv1.ServiceAccountKind, Name: "west", Namespace: "submariner-operator"},
+				}
+			})
+
+			It("should not create the CRDs", func(ctx SpecContext) {
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "clusters.submariner.io"},
+					&apiextensions.CustomResourceDefinition{})).To(Satisfy(apierrors.IsNotFound))
+			})
+		})
+
+		Context("because federated cluster subjects are used with cluster isolation", func() {
+			BeforeEach(func() {
+				broker.Spec.ClusterIsolation = true
+				broker.Spec.FederatedClusterSubjects = []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "oidc:clusters"}}
+			})
+
+			It("should not create the CRDs", func(ctx SpecContext) {
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "clusters.submariner.io"},
+					&apiextensions.CustomResourceDefinition{})).To(Satisfy(apierrors.IsNotFound))
+			})
+		})
+
+		Context("because federated cluster subjects are used with cluster enrollment", func() {
+			BeforeEach(func() {
+				broker.Spec.ClusterEnrollment = &v1alpha1.ClusterEnrollment{}
+				broker.Spec.FederatedClusterSubjects = []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "oidc:clusters"}}
+			})
+
+			It("should not create the CRDs", func(ctx SpecContext) {
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "clusters.submariner.io"},
+					&apiextensions.CustomResourceDefinition{})).To(Satisfy(apierrors.IsNotFound))
+			})
+		})
+
+		Context("because of an invalid cluster auto-approval pattern", func() {
+			BeforeEach(func() {
+				broker.Spec.ClusterEnrollment = &v1alpha1.ClusterEnrollment{AutoApprovePatterns: []string{"staging-["}}
+			})
+
+			It("should not create the CRDs", func(ctx SpecContext) {
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKey{Name: "clusters.submariner.io"},
+					&apiextensions.CustomResourceDefinition{})).To(Satisfy(apierrors.IsNotFound))
+			})
+		})
+
+		Context("because stale cluster cleanup is enabled without connectivity", func() {
+			BeforeEach(func() {
+				broker.Spec.Components = []string{"service-discovery"}
+				broker.Spec.GlobalnetEnabled = false
+				broker.Spec.StaleClusterCleanup = &v1alpha1.StaleClusterCleanup{}
+				t.InitScopedClientObjs = append(t.InitScopedClientObjs, newClusterSA("west"))
+			})
+
+			It("should not delete the cluster service accounts", func(ctx SpecContext) {
+				Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(newClusterSA("west")), &corev1.ServiceAccount{})).To(Succeed())
+			})
+		})
+
+		Context("because Globalnet is enabled without connectivity", func() {
+			BeforeEach(func() {
+				broker.Spec.Components = []string{"service-discovery"}
+			})
+
+			It("should not create the globalnet ConfigMap", func(ctx SpecContext) {
+				_, _, err := globalnet.GetGlobalNetworks(ctx, t.ScopedClient, submarinerNamespace)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+
+		Context("because the Globalnet cluster size doesn't fit the CIDR range", func() {
+			BeforeEach(func() {
+				broker.Spec.GlobalnetCIDRRange = "168.254.0.0/24"
+			})
+
+			It("should not create the globalnet ConfigMap", func(ctx SpecContext) {
+				_, _, err := globalnet.GetGlobalNetworks(ctx, t.ScopedClient, submarinerNamespace)
+				Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+
+	When("Globalnet is enabled without a CIDR range", func() {
+		BeforeEach(func() {
+			broker.Spec.GlobalnetCIDRRange = ""
+			broker.Spec.DefaultGlobalnetClusterSize = 0
+		})
+
+		It("should create the globalnet ConfigMap with the defaults", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, t.ScopedClient, submarinerNamespace)
+			Expect(err).To(Succeed())
+			Expect(globalnetInfo.Enabled).To(BeTrue())
+		})
+	})
+
 	When("the Broker resource doesn't exist", func() {
 		BeforeEach(func() {
 			t.InitScopedClientObjs = nil
@@ -94,3 +957,110 @@ var _ = Describe("Broker controller tests", func() {
 		})
 	})
 })
+
+func newCluster(clusterID string) *submarinerv1.Cluster {
+	return &submarinerv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterID,
+			Namespace: submarinerNamespace,
+		},
+		Spec: submarinerv1.ClusterSpec{
+			ClusterID: clusterID,
+		},
+	}
+}
+
+func newClusterToken(clusterID string, issued time.Time) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opnames.ForClusterSA(clusterID) + "-token",
+			Namespace: submarinerNamespace,
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey:     opnames.ForClusterSA(clusterID),
+				"submariner.io/token-issue-time": issued.UTC().Format(time.RFC3339),
+			},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+}
+
+func newExportedEndpointSlice(name, clusterID string) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: submarinerNamespace,
+			Labels:    map[string]string{"multicluster.kubernetes.io/source-cluster": clusterID},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+}
+
+// markStaleSince records the given time as when the service accounts and EndpointSlices of the west cluster were found
+// stale.
+func markStaleSince(objs []client.Object, since time.Time) {
+	for _, obj := range objs {
+		switch obj.(type) {
+		case *corev1.ServiceAccount, *discoveryv1.EndpointSlice:
+			if strings.HasSuffix(obj.GetName(), "west") {
+				obj.SetAnnotations(map[string]string{staleSinceAnnotation: since.UTC().Format(time.RFC3339)})
+			}
+		}
+	}
+}
+
+func newClusterSA(clusterID string) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opnames.ForClusterSA(clusterID),
+			Namespace: submarinerNamespace,
+		},
+	}
+}
+
+func newClusterRoleBinding(clusterID string) *rbacv1.RoleBinding {
+	return &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opnames.ForClusterSA(clusterID),
+			Namespace: submarinerNamespace,
+		},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      opnames.ForClusterSA(clusterID),
+			Namespace: submarinerNamespace,
+		}},
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "submariner-k8s-broker-cluster"},
+	}
+}
+
+func newEndpoint(clusterID string) *submarinerv1.Endpoint {
+	return &submarinerv1.Endpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterID,
+			Namespace: submarinerNamespace,
+		},
+		Spec: submarinerv1.EndpointSpec{
+			ClusterID: clusterID,
+		},
+	}
+}
+
+func getBroker(ctx context.Context, c client.Client) *v1alpha1.Broker {
+	broker := &v1alpha1.Broker{}
+	Expect(c.Get(ctx, client.ObjectKey{Name: brokerName, Namespace: submarinerNamespace}, broker)).To(Succeed())
+
+	return broker
+}
+
+type fakeBrokerComponent struct {
+	err           error
+	ensuredBroker string
+}
+
+func (c *fakeBrokerComponent) Name() string {
+	return "fake"
+}
+
+func (c *fakeBrokerComponent) Ensure(_ context.Context, broker *v1alpha1.Broker, _ crd.Updater) error {
+	c.ensuredBroker = broker.Name
+	return c.err
+}
@@ -0,0 +1,144 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// reconcileClusterEnrollment gates the access of the cluster service accounts (see names.ForClusterSA) when the Broker
+// requires cluster enrollments to be approved. The role bindings of the clusters which aren't approved are removed,
+// and the clusters are recorded as pending in the Broker status; approved clusters are bound to the broker cluster
+// role, or to their own role if the clusters are isolated.
+func (r *BrokerReconciler) reconcileClusterEnrollment(ctx context.Context, broker *v1alpha1.Broker) error {
+	broker.Status.PendingClusters = nil
+
+	enrollment := broker.Spec.ClusterEnrollment
+	if enrollment == nil {
+		return nil
+	}
+
+	serviceAccounts := &corev1.ServiceAccountList{}
+
+	err := r.Client.List(ctx, serviceAccounts, client.InNamespace(broker.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the ServiceAccounts")
+	}
+
+	clusterSAPrefix := names.ForClusterSA("")
+	pending := sets.New[string]()
+
+	for i := range serviceAccounts.Items {
+		sa := &serviceAccounts.Items[i]
+		if !strings.HasPrefix(sa.Name, clusterSAPrefix) || sa.DeletionTimestamp != nil {
+			continue
+		}
+
+		clusterID := strings.TrimPrefix(sa.Name, clusterSAPrefix)
+
+		if clusterApproved(enrollment, clusterID) {
+			err = r.grantClusterAccess(ctx, sa, clusterAccessRole(broker, sa))
+		} else {
+			pending.Insert(clusterID)
+			err = r.withholdClusterAccess(ctx, sa)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if pending.Len() > 0 {
+		broker.Status.PendingClusters = sets.List(pending)
+	}
+
+	return nil
+}
+
+// clusterApproved determines whether the given cluster was approved, explicitly or by an auto-approval pattern.
+func clusterApproved(enrollment *v1alpha1.ClusterEnrollment, clusterID string) bool {
+	if slices.Contains(enrollment.ApprovedClusters, clusterID) {
+		return true
+	}
+
+	for _, pattern := range enrollment.AutoApprovePatterns {
+		// The patterns are validated with the Broker
+		if matched, _ := path.Match(pattern, clusterID); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// grantClusterAccess binds the given cluster service account to the given role.
+func (r *BrokerReconciler) grantClusterAccess(ctx context.Context, sa *corev1.ServiceAccount, role string) error {
+	change, err := r.ensureRoleBinding(ctx, &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: sa.Name, Namespace: sa.Namespace},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     role,
+		},
+		Subjects: []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: sa.Name, Namespace: sa.Namespace}},
+	})
+	if change != "" {
+		log.Info("Granted the access of an approved cluster", "name", sa.Name, "change", change)
+	}
+
+	return err
+}
+
+// withholdClusterAccess deletes the role bindings which only apply to the given cluster service account.
+func (r *BrokerReconciler) withholdClusterAccess(ctx context.Context, sa *corev1.ServiceAccount) error {
+	roleBindings := &rbacv1.RoleBindingList{}
+
+	err := r.Client.List(ctx, roleBindings, client.InNamespace(sa.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the RoleBindings")
+	}
+
+	for i := range roleBindings.Items {
+		if !boundOnlyTo(&roleBindings.Items[i], sa) {
+			continue
+		}
+
+		err = r.Client.Delete(ctx, &roleBindings.Items[i])
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the RoleBinding %q", roleBindings.Items[i].Name)
+		}
+
+		log.Info("Withheld the access of a cluster pending approval", "name", sa.Name, "roleBinding", roleBindings.Items[i].Name)
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/embeddedyamls"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Binds the federated cluster subjects to the role used by the joined clusters.
+const federatedClusterRoleBinding = "submariner-k8s-broker-federated-clusters"
+
+// The role bound to the joined clusters' service account, see config/broker/broker-client.
+var brokerClusterRole = func() string {
+	roleBinding := &rbacv1.RoleBinding{}
+	if err := embeddedyamls.GetObject(embeddedyamls.Config_broker_broker_client_role_binding_yaml, roleBinding); err != nil {
+		panic(err)
+	}
+
+	return roleBinding.RoleRef.Name
+}()
+
+// reconcileFederatedClusterSubjects grants the Broker's federated cluster subjects the same access as the joined
+// clusters' service accounts, through a dedicated role binding which is removed when there are no such subjects.
+func (r *BrokerReconciler) reconcileFederatedClusterSubjects(ctx context.Context, broker *v1alpha1.Broker) error {
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: federatedClusterRoleBinding, Namespace: broker.Namespace},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     brokerClusterRole,
+		},
+		Subjects: make([]rbacv1.Subject, len(broker.Spec.FederatedClusterSubjects)),
+	}
+
+	if len(roleBinding.Subjects) == 0 {
+		err := r.Client.Delete(ctx, roleBinding)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the RoleBinding %q", roleBinding.Name)
+		}
+
+		return nil
+	}
+
+	for i := range broker.Spec.FederatedClusterSubjects {
+		roleBinding.Subjects[i] = rbacv1.Subject{
+			Kind:     broker.Spec.FederatedClusterSubjects[i].Kind,
+			APIGroup: rbacv1.GroupName,
+			Name:     broker.Spec.FederatedClusterSubjects[i].Name,
+		}
+	}
+
+	change, err := r.ensureRoleBinding(ctx, roleBinding)
+	if change != "" {
+		log.Info("Updated the access of the federated cluster subjects", "namespace", broker.Namespace, "change", change)
+	}
+
+	return err
+}
@@ -0,0 +1,64 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// reconcileGlobalnetAllocations records the global CIDRs allocated to each cluster in the Broker status, along with any
+// conflicts between them. The globalnet ConfigMap remains the source of truth, since that's what joining clusters use
+// to allocate their global CIDRs. The status is updated by the caller.
+func (r *BrokerReconciler) reconcileGlobalnetAllocations(ctx context.Context, broker *v1alpha1.Broker) error {
+	broker.Status.GlobalnetAllocations = nil
+	broker.Status.GlobalnetConflicts = nil
+
+	globalnetInfo, _, err := globalnet.GetGlobalNetworks(ctx, r.Client, broker.Namespace)
+	if apierrors.IsNotFound(errors.Cause(err)) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "error retrieving the global CIDR allocations")
+	}
+
+	for clusterID, globalNetwork := range globalnetInfo.CidrInfo {
+		broker.Status.GlobalnetAllocations = append(broker.Status.GlobalnetAllocations, v1alpha1.GlobalnetAllocation{
+			ClusterID:   clusterID,
+			GlobalCIDRs: globalNetwork.GlobalCIDRs,
+		})
+	}
+
+	sort.Slice(broker.Status.GlobalnetAllocations, func(i, j int) bool {
+		return broker.Status.GlobalnetAllocations[i].ClusterID < broker.Status.GlobalnetAllocations[j].ClusterID
+	})
+
+	broker.Status.GlobalnetConflicts = globalnet.FindAllocationConflicts(globalnetInfo)
+	for _, conflict := range broker.Status.GlobalnetConflicts {
+		log.Info("Conflicting global CIDR allocation: "+conflict, "namespace", broker.Namespace)
+	}
+
+	return nil
+}
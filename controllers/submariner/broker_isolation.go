@@ -0,0 +1,290 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/embeddedyamls"
+	"github.com/submariner-io/submariner-operator/pkg/names"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Labels the roles of the isolated clusters with their cluster ID.
+const isolatedClusterLabel = "submariner.io/isolated-cluster"
+
+var (
+	// The rules of the role shared by the joined clusters, see config/broker/broker-client.
+	brokerClusterRules = func() []rbacv1.PolicyRule {
+		role := &rbacv1.Role{}
+		if err := embeddedyamls.GetObject(embeddedyamls.Config_broker_broker_client_role_yaml, role); err != nil {
+			panic(err)
+		}
+
+		return role.Rules
+	}()
+
+	// The resources isolated clusters can only modify or delete for themselves, and the verbs this applies to.
+	isolatedResources = sets.New("clusters", "endpoints")
+	isolatedVerbs     = sets.New("patch", "update", "delete")
+)
+
+// reconcileClusterIsolation binds, when the Broker isolates the joined clusters, each cluster service account (see
+// names.ForClusterSA) to a role named after it, which only allows modifying or deleting the Cluster and Endpoint
+// resources of that cluster; the role bindings granting it the shared cluster role are removed. When isolation is
+// disabled, the clusters are bound to the shared role again and their roles are deleted. With cluster enrollment, only
+// approved clusters are bound, see reconcileClusterEnrollment.
+func (r *BrokerReconciler) reconcileClusterIsolation(ctx context.Context, broker *v1alpha1.Broker) error {
+	serviceAccounts := &corev1.ServiceAccountList{}
+
+	err := r.Client.List(ctx, serviceAccounts, client.InNamespace(broker.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the ServiceAccounts")
+	}
+
+	var owned map[string]map[string]sets.Set[string]
+
+	if broker.Spec.ClusterIsolation {
+		owned, err = r.clusterOwnedResources(ctx, broker.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	clusterSAPrefix := names.ForClusterSA("")
+
+	for i := range serviceAccounts.Items {
+		sa := &serviceAccounts.Items[i]
+		if !strings.HasPrefix(sa.Name, clusterSAPrefix) || sa.DeletionTimestamp != nil {
+			continue
+		}
+
+		clusterID := strings.TrimPrefix(sa.Name, clusterSAPrefix)
+
+		if broker.Spec.ClusterIsolation {
+			err = r.isolateCluster(ctx, broker, sa, clusterID, owned[clusterID])
+		} else {
+			err = r.removeClusterIsolation(ctx, broker, sa)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// clusterAccessRole returns the role the given cluster service account is bound to.
+func clusterAccessRole(broker *v1alpha1.Broker, sa *corev1.ServiceAccount) string {
+	if broker.Spec.ClusterIsolation {
+		return sa.Name
+	}
+
+	return brokerClusterRole
+}
+
+// clusterOwnedResources returns the names of the Cluster and Endpoint resources of each cluster, by cluster ID and
+// resource.
+func (r *BrokerReconciler) clusterOwnedResources(ctx context.Context, namespace string) (map[string]map[string]sets.Set[string],
+	error,
+) {
+	owned := map[string]map[string]sets.Set[string]{}
+
+	add := func(clusterID, resource, name string) {
+		if owned[clusterID] == nil {
+			owned[clusterID] = map[string]sets.Set[string]{}
+		}
+
+		if owned[clusterID][resource] == nil {
+			owned[clusterID][resource] = sets.New[string]()
+		}
+
+		owned[clusterID][resource].Insert(name)
+	}
+
+	clusters := &submv1.ClusterList{}
+
+	err := r.Client.List(ctx, clusters, client.InNamespace(namespace))
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing the Cluster resources")
+	}
+
+	for i := range clusters.Items {
+		add(clusters.Items[i].Spec.ClusterID, "clusters", clusters.Items[i].Name)
+	}
+
+	endpoints := &submv1.EndpointList{}
+
+	err = r.Client.List(ctx, endpoints, client.InNamespace(namespace))
+	if err != nil {
+		return nil, errors.Wrap(err, "error listing the Endpoint resources")
+	}
+
+	for i := range endpoints.Items {
+		add(endpoints.Items[i].Spec.ClusterID, "endpoints", endpoints.Items[i].Name)
+	}
+
+	return owned, nil
+}
+
+func (r *BrokerReconciler) isolateCluster(ctx context.Context, broker *v1alpha1.Broker, sa *corev1.ServiceAccount, clusterID string,
+	owned map[string]sets.Set[string],
+) error {
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: sa.Name, Namespace: sa.Namespace}}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+		metav1.SetMetaDataLabel(&role.ObjectMeta, isolatedClusterLabel, clusterID)
+		role.Rules = isolatedClusterRules(owned)
+
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "error reconciling the Role %q", role.Name)
+	}
+
+	if result != controllerutil.OperationResultNone {
+		log.Info("Updated the role of an isolated cluster", "name", role.Name, "result", result)
+	}
+
+	// Access is granted by the cluster enrollment if it's enabled
+	if broker.Spec.ClusterEnrollment == nil {
+		if err := r.grantClusterAccess(ctx, sa, role.Name); err != nil {
+			return err
+		}
+	}
+
+	roleBindings := &rbacv1.RoleBindingList{}
+
+	err = r.Client.List(ctx, roleBindings, client.InNamespace(sa.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the RoleBindings")
+	}
+
+	for i := range roleBindings.Items {
+		roleBinding := &roleBindings.Items[i]
+		if roleBinding.RoleRef.Kind != "Role" || roleBinding.RoleRef.Name != brokerClusterRole || !boundOnlyTo(roleBinding, sa) {
+			continue
+		}
+
+		err = r.Client.Delete(ctx, roleBinding)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the RoleBinding %q", roleBinding.Name)
+		}
+
+		log.Info("Removed the shared access of an isolated cluster", "name", sa.Name, "roleBinding", roleBinding.Name)
+	}
+
+	return nil
+}
+
+// isolatedClusterRules returns the rules of the shared cluster role, restricted to modifying or deleting the given
+// resources, by resource.
+func isolatedClusterRules(owned map[string]sets.Set[string]) []rbacv1.PolicyRule {
+	rules := make([]rbacv1.PolicyRule, 0, len(brokerClusterRules))
+
+	for i := range brokerClusterRules {
+		rule := brokerClusterRules[i].DeepCopy()
+
+		if !slices.Equal(rule.APIGroups, []string{submv1.SchemeGroupVersion.Group}) || !isolatedResources.HasAll(rule.Resources...) {
+			rules = append(rules, *rule)
+			continue
+		}
+
+		restrictedVerbs := sets.List(isolatedVerbs.Intersection(sets.New(rule.Verbs...)))
+		rule.Verbs = slices.DeleteFunc(rule.Verbs, isolatedVerbs.Has)
+		rules = append(rules, *rule)
+
+		// An empty list of resource names would grant the verbs on all the resources
+		for _, resource := range rule.Resources {
+			if owned[resource].Len() > 0 && len(restrictedVerbs) > 0 {
+				rules = append(rules, rbacv1.PolicyRule{
+					APIGroups:     rule.APIGroups,
+					Resources:     []string{resource},
+					ResourceNames: sets.List(owned[resource]),
+					Verbs:         restrictedVerbs,
+				})
+			}
+		}
+	}
+
+	return rules
+}
+
+func (r *BrokerReconciler) removeClusterIsolation(ctx context.Context, broker *v1alpha1.Broker, sa *corev1.ServiceAccount) error {
+	role := &rbacv1.Role{}
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(sa), role)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving the Role %q", sa.Name)
+	}
+
+	if _, ok := role.Labels[isolatedClusterLabel]; !ok {
+		return nil
+	}
+
+	// Access is granted by the cluster enrollment if it's enabled
+	if broker.Spec.ClusterEnrollment == nil {
+		if err := r.grantClusterAccess(ctx, sa, brokerClusterRole); err != nil {
+			return err
+		}
+	}
+
+	return r.deleteIsolatedClusterRole(ctx, sa)
+}
+
+// deleteIsolatedClusterRole deletes the role of the given cluster service account, if it was isolated.
+func (r *BrokerReconciler) deleteIsolatedClusterRole(ctx context.Context, sa *corev1.ServiceAccount) error {
+	roles := &rbacv1.RoleList{}
+
+	err := r.Client.List(ctx, roles, client.InNamespace(sa.Namespace), client.HasLabels{isolatedClusterLabel})
+	if err != nil {
+		return errors.Wrap(err, "error listing the Roles")
+	}
+
+	for i := range roles.Items {
+		if roles.Items[i].Name != sa.Name {
+			continue
+		}
+
+		err = r.Client.Delete(ctx, &roles.Items[i])
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the Role %q", sa.Name)
+		}
+
+		log.Info("Deleted the role of an isolated cluster", "name", sa.Name)
+	}
+
+	return nil
+}
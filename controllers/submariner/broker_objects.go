@@ -0,0 +1,89 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultObjectCountWarningThreshold = 5000
+	// The monitored objects aren't watched, their counts are refreshed periodically instead.
+	objectCountInterval = 5 * time.Minute
+)
+
+// The kinds of objects which accumulate in the broker namespace as the clusterset grows.
+var brokerObjectKinds = []schema.GroupVersionKind{
+	{Group: "submariner.io", Version: "v1", Kind: "Endpoint"},
+	{Group: "multicluster.x-k8s.io", Version: "v1alpha1", Kind: "ServiceImport"},
+	{Group: "discovery.k8s.io", Version: "v1", Kind: "EndpointSlice"},
+}
+
+// reconcileObjectCounts counts the objects of each monitored kind in the broker namespace, records the counts in the
+// metrics and in the Broker status, and raises warnings for the kinds exceeding the threshold. The status is updated by
+// the caller.
+func (r *BrokerReconciler) reconcileObjectCounts(ctx context.Context, broker *v1alpha1.Broker) error {
+	threshold := broker.Spec.ObjectCountWarningThreshold
+	if threshold == 0 {
+		threshold = defaultObjectCountWarningThreshold
+	}
+
+	counts := map[string]int{}
+
+	var warnings []string
+
+	for _, gvk := range brokerObjectKinds {
+		objects := &metav1.PartialObjectMetadataList{}
+		objects.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		err := r.Client.List(ctx, objects, client.InNamespace(broker.Namespace))
+		if meta.IsNoMatchError(err) {
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "error listing the %s resources", gvk.Kind)
+		}
+
+		count := len(objects.Items)
+		counts[gvk.Kind] = count
+		recordBrokerObjects(broker.Namespace, gvk.Kind, count)
+
+		if count > threshold {
+			warning := fmt.Sprintf("There are %d %s resources in the broker namespace, above the warning threshold of %d",
+				count, gvk.Kind, threshold)
+			log.Info(warning, "namespace", broker.Namespace)
+			warnings = append(warnings, warning)
+		}
+	}
+
+	broker.Status.ObjectCounts = counts
+	broker.Status.ObjectCountWarnings = warnings
+
+	return nil
+}
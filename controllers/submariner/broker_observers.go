@@ -0,0 +1,131 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/embeddedyamls"
+	"github.com/submariner-io/submariner-operator/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Identifies the service accounts of broker observers, with the observer name as value.
+const brokerObserverLabel = "submariner.io/broker-observer"
+
+// The read-only role bound to the observers, see config/broker/broker-observer.
+var brokerObserverRole = func() string {
+	name, err := embeddedyamls.GetObjectName(embeddedyamls.Config_broker_broker_observer_role_yaml)
+	if err != nil {
+		panic(err)
+	}
+
+	return name
+}()
+
+// reconcileBrokerObservers gives each observer listed in the Broker a service account, bound to the read-only observer
+// role, and a token, which is recorded in the Broker status. The access of observers which are no longer listed is
+// revoked.
+func (r *BrokerReconciler) reconcileBrokerObservers(ctx context.Context, broker *v1alpha1.Broker) error {
+	observers := sets.New(broker.Spec.Observers...)
+
+	serviceAccounts := &corev1.ServiceAccountList{}
+
+	err := r.Client.List(ctx, serviceAccounts, client.InNamespace(broker.Namespace), client.HasLabels{brokerObserverLabel})
+	if err != nil {
+		return errors.Wrap(err, "error listing the observer ServiceAccounts")
+	}
+
+	for i := range serviceAccounts.Items {
+		sa := &serviceAccounts.Items[i]
+		if observers.Has(sa.Labels[brokerObserverLabel]) {
+			continue
+		}
+
+		if err := r.deleteServiceAccountAccess(ctx, sa); err != nil {
+			return err
+		}
+
+		log.Info("Revoked the access of a broker observer", "observer", sa.Labels[brokerObserverLabel])
+	}
+
+	var tokens []v1alpha1.ObserverToken
+
+	for _, observer := range sets.List(observers) {
+		secretName, err := r.ensureBrokerObserver(ctx, broker.Namespace, observer)
+		if err != nil {
+			return err
+		}
+
+		tokens = append(tokens, v1alpha1.ObserverToken{Name: observer, SecretName: secretName})
+	}
+
+	broker.Status.ObserverTokens = tokens
+
+	return nil
+}
+
+// ensureBrokerObserver creates the service account, role binding and token of the given observer if they're missing,
+// and returns the name of the token Secret.
+func (r *BrokerReconciler) ensureBrokerObserver(ctx context.Context, namespace, observer string) (string, error) {
+	saName := names.ForBrokerObserverSA(observer)
+
+	objects := []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+			Name:      saName,
+			Namespace: namespace,
+			Labels:    map[string]string{brokerObserverLabel: observer},
+		}},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: saName, Namespace: namespace},
+			RoleRef: rbacv1.RoleRef{
+				APIGroup: rbacv1.GroupName,
+				Kind:     "Role",
+				Name:     brokerObserverRole,
+			},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: saName, Namespace: namespace}},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        saName + "-token",
+				Namespace:   namespace,
+				Annotations: map[string]string{corev1.ServiceAccountNameKey: saName},
+			},
+			Type: corev1.SecretTypeServiceAccountToken,
+		},
+	}
+
+	for _, obj := range objects {
+		err := r.Client.Create(ctx, obj)
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return "", errors.Wrapf(err, "error creating the %s %q for broker observer %q", reflect.TypeOf(obj).Elem().Name(),
+				obj.GetName(), observer)
+		}
+	}
+
+	return saName + "-token", nil
+}
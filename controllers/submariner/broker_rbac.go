@@ -0,0 +1,297 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/embeddedyamls"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The manifests of the service accounts, roles and role bindings deployed with the broker, see config/broker.
+var (
+	brokerServiceAccountYamls = []string{
+		embeddedyamls.Config_broker_broker_admin_service_account_yaml,
+		embeddedyamls.Config_broker_broker_client_service_account_yaml,
+	}
+	brokerRoleYamls = []string{
+		embeddedyamls.Config_broker_broker_admin_role_yaml,
+		embeddedyamls.Config_broker_broker_client_role_yaml,
+		embeddedyamls.Config_broker_broker_observer_role_yaml,
+	}
+	brokerRoleBindingYamls = []string{
+		embeddedyamls.Config_broker_broker_admin_role_binding_yaml,
+		embeddedyamls.Config_broker_broker_client_role_binding_yaml,
+	}
+)
+
+// brokerRBACNames are the names of the broker service accounts, roles and role bindings.
+var brokerRBACNames = func() sets.Set[string] {
+	rbacNames := sets.New[string]()
+
+	for _, yamls := range [][]string{brokerServiceAccountYamls, brokerRoleYamls, brokerRoleBindingYamls} {
+		for _, yaml := range yamls {
+			name, err := embeddedyamls.GetObjectName(yaml)
+			if err != nil {
+				panic(err)
+			}
+
+			rbacNames.Insert(name)
+		}
+	}
+
+	return rbacNames
+}()
+
+// reconcileBrokerRBAC restores the broker service accounts, roles and role bindings if they've been deleted or modified;
+// without them, clusters can't join the broker. Repairs are recorded in the Broker status.
+func (r *BrokerReconciler) reconcileBrokerRBAC(ctx context.Context, broker *v1alpha1.Broker) error {
+	var (
+		steps        []func() error
+		stepProblems []string
+	)
+
+	for _, kind := range []struct {
+		ensure func(context.Context, string, string) (string, error)
+		yamls  []string
+	}{
+		{r.ensureBrokerServiceAccount, brokerServiceAccountYamls},
+		{r.ensureBrokerRole, brokerRoleYamls},
+		{r.ensureBrokerRoleBinding, brokerRoleBindingYamls},
+	} {
+		for _, yaml := range kind.yamls {
+			i, ensure, yaml := len(stepProblems), kind.ensure, yaml
+
+			stepProblems = append(stepProblems, "")
+			steps = append(steps, func() error {
+				var err error
+
+				stepProblems[i], err = ensure(ctx, broker.Namespace, yaml)
+
+				return err
+			})
+		}
+	}
+
+	// Role bindings don't need their role or subjects to exist, so all the objects are ensured concurrently
+	if err := runConcurrently(steps...); err != nil {
+		return err
+	}
+
+	var problems []string
+
+	for _, problem := range stepProblems {
+		problems = appendIfSet(problems, problem)
+	}
+
+	if len(problems) > 0 {
+		log.Info("Repaired the broker RBAC", "namespace", broker.Namespace, "problems", problems)
+
+		broker.Status.LastRBACRepair = &v1alpha1.BrokerRBACRepair{
+			Time:     metav1.Now(),
+			Problems: problems,
+		}
+	}
+
+	return nil
+}
+
+func (r *BrokerReconciler) ensureBrokerServiceAccount(ctx context.Context, namespace, yaml string) (string, error) {
+	desired := &corev1.ServiceAccount{}
+	if err := embeddedyamls.GetObject(yaml, desired); err != nil {
+		return "", err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	desired.Namespace = namespace
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), &corev1.ServiceAccount{})
+	if !apierrors.IsNotFound(err) {
+		return "", errors.Wrapf(err, "error retrieving the ServiceAccount %q", desired.Name)
+	}
+
+	return r.createBrokerRBAC(ctx, "ServiceAccount", desired)
+}
+
+func (r *BrokerReconciler) ensureBrokerRole(ctx context.Context, namespace, yaml string) (string, error) {
+	desired := &rbacv1.Role{}
+	if err := embeddedyamls.GetObject(yaml, desired); err != nil {
+		return "", err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	desired.Namespace = namespace
+
+	var problem string
+
+	// The role may be modified concurrently, e.g. by clusters joining the broker
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+
+		problem, err = r.tryEnsureBrokerRole(ctx, desired)
+
+		return err
+	})
+
+	return problem, err //nolint:wrapcheck // Errors are already wrapped
+}
+
+func (r *BrokerReconciler) tryEnsureBrokerRole(ctx context.Context, desired *rbacv1.Role) (string, error) {
+	existing := &rbacv1.Role{}
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierrors.IsNotFound(err) {
+		return r.createBrokerRBAC(ctx, "Role", desired)
+	}
+
+	if err != nil {
+		return "", errors.Wrapf(err, "error retrieving the Role %q", desired.Name)
+	}
+
+	if equality.Semantic.DeepEqual(existing.Rules, desired.Rules) {
+		return "", nil
+	}
+
+	existing.Rules = desired.Rules
+
+	if err := r.Client.Update(ctx, existing); err != nil {
+		return "", errors.Wrapf(err, "error restoring the rules of Role %q", desired.Name)
+	}
+
+	return fmt.Sprintf("the rules of Role %q were modified", desired.Name), nil
+}
+
+func (r *BrokerReconciler) ensureBrokerRoleBinding(ctx context.Context, namespace, yaml string) (string, error) {
+	desired := &rbacv1.RoleBinding{}
+	if err := embeddedyamls.GetObject(yaml, desired); err != nil {
+		return "", err //nolint:wrapcheck // Errors are already wrapped
+	}
+
+	desired.Namespace = namespace
+	for i := range desired.Subjects {
+		desired.Subjects[i].Namespace = namespace
+	}
+
+	return r.ensureRoleBinding(ctx, desired)
+}
+
+// ensureRoleBinding creates the given role binding, or restores its role and subjects; the problem found, if any, is
+// returned.
+func (r *BrokerReconciler) ensureRoleBinding(ctx context.Context, desired *rbacv1.RoleBinding) (string, error) {
+	var problem string
+
+	// The role binding may be modified concurrently, e.g. by clusters joining the broker
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+
+		problem, err = r.tryEnsureRoleBinding(ctx, desired)
+
+		return err
+	})
+
+	return problem, err //nolint:wrapcheck // Errors are already wrapped
+}
+
+func (r *BrokerReconciler) tryEnsureRoleBinding(ctx context.Context, desired *rbacv1.RoleBinding) (string, error) {
+	existing := &rbacv1.RoleBinding{}
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(desired), existing)
+	if apierrors.IsNotFound(err) {
+		return r.createBrokerRBAC(ctx, "RoleBinding", desired)
+	}
+
+	if err != nil {
+		return "", errors.Wrapf(err, "error retrieving the RoleBinding %q", desired.Name)
+	}
+
+	if existing.RoleRef != desired.RoleRef {
+		// The role reference can't be changed, the binding must be recreated
+		err = r.Client.Delete(ctx, existing)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return "", errors.Wrapf(err, "error deleting the RoleBinding %q", desired.Name)
+		}
+
+		if _, err := r.createBrokerRBAC(ctx, "RoleBinding", desired); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("the role of RoleBinding %q was modified", desired.Name), nil
+	}
+
+	if equality.Semantic.DeepEqual(existing.Subjects, desired.Subjects) {
+		return "", nil
+	}
+
+	existing.Subjects = desired.Subjects
+
+	if err := r.Client.Update(ctx, existing); err != nil {
+		return "", errors.Wrapf(err, "error restoring the subjects of RoleBinding %q", desired.Name)
+	}
+
+	return fmt.Sprintf("the subjects of RoleBinding %q were modified", desired.Name), nil
+}
+
+func (r *BrokerReconciler) createBrokerRBAC(ctx context.Context, kind string, obj client.Object) (string, error) {
+	err := r.Client.Create(ctx, obj)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return "", errors.Wrapf(err, "error creating the %s %q", kind, obj.GetName())
+	}
+
+	return fmt.Sprintf("the %s %q was missing", kind, obj.GetName()), nil
+}
+
+func appendIfSet(problems []string, problem string) []string {
+	if problem == "" {
+		return problems
+	}
+
+	return append(problems, problem)
+}
+
+// runConcurrently runs the given functions concurrently, and aggregates the errors they return.
+func runConcurrently(funcs ...func() error) error {
+	errs := make([]error, len(funcs))
+
+	var wg sync.WaitGroup
+
+	for i := range funcs {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			errs[i] = funcs[i]()
+		}(i)
+	}
+
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
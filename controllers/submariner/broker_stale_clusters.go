@@ -0,0 +1,246 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/names"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultStaleClusterGracePeriod = 24 * time.Hour
+
+	// Records when a broker resource was first found without the Cluster resource of its cluster.
+	staleSinceAnnotation = "submariner.io/stale-since"
+)
+
+// reconcileStaleClusters records, in the Broker status, the cluster service accounts (see names.ForClusterSA) which no
+// longer have a Cluster resource, and deletes them along with their role bindings and tokens if requested, once
+// they've been without a Cluster resource for the grace period. Clusters pending enrollment approval can't register
+// their Cluster resource, so they aren't considered stale.
+func (r *BrokerReconciler) reconcileStaleClusters(ctx context.Context, broker *v1alpha1.Broker) error {
+	broker.Status.StaleClusterServiceAccounts = nil
+
+	// The Cluster resources are registered by the connectivity component, other clusters would all appear stale
+	if !brokerComponentEnabled(&broker.Spec, connectivityComponent) {
+		return nil
+	}
+
+	clusters := &submv1.ClusterList{}
+
+	err := r.Client.List(ctx, clusters, client.InNamespace(broker.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the Cluster resources")
+	}
+
+	current := sets.New[string]()
+	for i := range clusters.Items {
+		current.Insert(names.ForClusterSA(clusters.Items[i].Spec.ClusterID))
+	}
+
+	// The pending clusters are determined by reconcileClusterEnrollment
+	for _, clusterID := range broker.Status.PendingClusters {
+		current.Insert(names.ForClusterSA(clusterID))
+	}
+
+	serviceAccounts := &corev1.ServiceAccountList{}
+
+	err = r.Client.List(ctx, serviceAccounts, client.InNamespace(broker.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the ServiceAccounts")
+	}
+
+	clusterSAPrefix := names.ForClusterSA("")
+	stale := sets.New[string]()
+
+	for i := range serviceAccounts.Items {
+		sa := &serviceAccounts.Items[i]
+		if !strings.HasPrefix(sa.Name, clusterSAPrefix) {
+			continue
+		}
+
+		if current.Has(sa.Name) {
+			if err := r.clearStaleSince(ctx, sa); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		expired, err := r.staleResourceExpired(ctx, broker.Spec.StaleClusterCleanup, sa)
+		if err != nil {
+			return err
+		}
+
+		if !expired {
+			stale.Insert(sa.Name)
+			continue
+		}
+
+		if err := r.deleteStaleCluster(ctx, sa); err != nil {
+			return err
+		}
+	}
+
+	if stale.Len() > 0 {
+		broker.Status.StaleClusterServiceAccounts = sets.List(stale)
+	}
+
+	return nil
+}
+
+// staleResourceExpired determines whether the given resource, left by a departed cluster, is past the cleanup grace
+// period. The grace period starts when the resource is first found stale, which is recorded on the resource, rather
+// than when it was created: long-joined clusters whose Cluster resource is briefly missing, e.g. while their gateways
+// are redeployed, keep their resources.
+func (r *BrokerReconciler) staleResourceExpired(ctx context.Context, cleanup *v1alpha1.StaleClusterCleanup, obj client.Object,
+) (bool, error) {
+	if cleanup == nil {
+		return false, nil
+	}
+
+	gracePeriod := defaultStaleClusterGracePeriod
+	if cleanup.GracePeriod != nil {
+		gracePeriod = cleanup.GracePeriod.Duration
+	}
+
+	staleSince, err := time.Parse(time.RFC3339, obj.GetAnnotations()[staleSinceAnnotation])
+	if err == nil {
+		return time.Since(staleSince) >= gracePeriod, nil
+	}
+
+	original := obj.DeepCopyObject().(client.Object)
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[staleSinceAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	obj.SetAnnotations(annotations)
+
+	if err := r.Client.Patch(ctx, obj, client.MergeFrom(original)); err != nil {
+		return false, errors.Wrapf(err, "error recording when %q became stale", obj.GetName())
+	}
+
+	return gracePeriod <= 0, nil
+}
+
+// clearStaleSince removes the record of when the given resource was found stale, if any.
+func (r *BrokerReconciler) clearStaleSince(ctx context.Context, obj client.Object) error {
+	if _, ok := obj.GetAnnotations()[staleSinceAnnotation]; !ok {
+		return nil
+	}
+
+	original := obj.DeepCopyObject().(client.Object)
+
+	annotations := obj.GetAnnotations()
+	delete(annotations, staleSinceAnnotation)
+	obj.SetAnnotations(annotations)
+
+	return errors.Wrapf(r.Client.Patch(ctx, obj, client.MergeFrom(original)), "error clearing the stale record of %q", obj.GetName())
+}
+
+// deleteStaleCluster deletes the given cluster service account, the role bindings granting it access, and its tokens.
+func (r *BrokerReconciler) deleteStaleCluster(ctx context.Context, sa *corev1.ServiceAccount) error {
+	if err := r.deleteServiceAccountAccess(ctx, sa); err != nil {
+		return err
+	}
+
+	log.Info("Deleted the service account of a cluster which left the clusterset", "name", sa.Name)
+
+	return nil
+}
+
+// deleteServiceAccountAccess deletes the given service account, the role bindings which only apply to it, its isolated
+// cluster role if any, and its tokens.
+func (r *BrokerReconciler) deleteServiceAccountAccess(ctx context.Context, sa *corev1.ServiceAccount) error {
+	roleBindings := &rbacv1.RoleBindingList{}
+
+	err := r.Client.List(ctx, roleBindings, client.InNamespace(sa.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the RoleBindings")
+	}
+
+	for i := range roleBindings.Items {
+		if !boundOnlyTo(&roleBindings.Items[i], sa) {
+			continue
+		}
+
+		err = r.Client.Delete(ctx, &roleBindings.Items[i])
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the RoleBinding %q", roleBindings.Items[i].Name)
+		}
+	}
+
+	if err := r.deleteIsolatedClusterRole(ctx, sa); err != nil {
+		return err
+	}
+
+	secrets := &corev1.SecretList{}
+
+	err = r.Client.List(ctx, secrets, client.InNamespace(sa.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the Secrets")
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if secret.Type != corev1.SecretTypeServiceAccountToken || secret.Annotations[corev1.ServiceAccountNameKey] != sa.Name {
+			continue
+		}
+
+		err = r.Client.Delete(ctx, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the token %q", secret.Name)
+		}
+	}
+
+	err = r.Client.Delete(ctx, sa)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "error deleting the ServiceAccount %q", sa.Name)
+	}
+
+	return nil
+}
+
+// boundOnlyTo determines whether the given role binding only applies to the given service account, so that deleting
+// it doesn't affect anyone else.
+func boundOnlyTo(roleBinding *rbacv1.RoleBinding, sa *corev1.ServiceAccount) bool {
+	for i := range roleBinding.Subjects {
+		subject := &roleBinding.Subjects[i]
+		if subject.Kind != rbacv1.ServiceAccountKind || subject.Name != sa.Name ||
+			(subject.Namespace != "" && subject.Namespace != sa.Namespace) {
+			return false
+		}
+	}
+
+	return len(roleBinding.Subjects) > 0
+}
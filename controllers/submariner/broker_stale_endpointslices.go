@@ -0,0 +1,108 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The label set by Lighthouse on the EndpointSlices it exports to the broker, identifying the exporting cluster.
+const sourceClusterLabel = "multicluster.kubernetes.io/source-cluster"
+
+// reconcileStaleEndpointSlices records, in the Broker status, the EndpointSlices exported to the broker by clusters
+// which no longer have a Cluster resource, typically after the cluster was removed without unexporting its services.
+// They're deleted if requested, once they've been stale for the grace period (see staleResourceExpired), so that the
+// services are no longer imported.
+func (r *BrokerReconciler) reconcileStaleEndpointSlices(ctx context.Context, broker *v1alpha1.Broker) error {
+	broker.Status.StaleClusterEndpointSlices = nil
+
+	// The Cluster resources are registered by the connectivity component, other clusters would all appear stale
+	if !brokerComponentEnabled(&broker.Spec, connectivityComponent) {
+		return nil
+	}
+
+	clusters := &submv1.ClusterList{}
+
+	err := r.Client.List(ctx, clusters, client.InNamespace(broker.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the Cluster resources")
+	}
+
+	current := sets.New[string]()
+	for i := range clusters.Items {
+		current.Insert(clusters.Items[i].Spec.ClusterID)
+	}
+
+	endpointSlices := &discoveryv1.EndpointSliceList{}
+
+	err = r.Client.List(ctx, endpointSlices, client.InNamespace(broker.Namespace), client.HasLabels{sourceClusterLabel})
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "error listing the EndpointSlices")
+	}
+
+	stale := sets.New[string]()
+
+	for i := range endpointSlices.Items {
+		endpointSlice := &endpointSlices.Items[i]
+		if current.Has(endpointSlice.Labels[sourceClusterLabel]) {
+			if err := r.clearStaleSince(ctx, endpointSlice); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		expired, err := r.staleResourceExpired(ctx, broker.Spec.StaleClusterCleanup, endpointSlice)
+		if err != nil {
+			return err
+		}
+
+		if !expired {
+			stale.Insert(endpointSlice.Name)
+			continue
+		}
+
+		err = r.Client.Delete(ctx, endpointSlice)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the EndpointSlice %q", endpointSlice.Name)
+		}
+
+		log.Info("Deleted an EndpointSlice exported by a cluster which left the clusterset", "name", endpointSlice.Name,
+			"cluster", endpointSlice.Labels[sourceClusterLabel])
+	}
+
+	if stale.Len() > 0 {
+		broker.Status.StaleClusterEndpointSlices = sets.List(stale)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/names"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Records when a cluster token was issued by a rotation, since the creation timestamp can't be set explicitly.
+	tokenIssueTimeAnnotation = "submariner.io/token-issue-time"
+
+	// Marks the tokens replaced by a rotation; joined clusters don't sync them, and they're deleted after the overlap period.
+	tokenSupersededAnnotation = "submariner.io/token-superseded"
+
+	defaultTokenOverlapPeriod = time.Hour
+)
+
+// reconcileClusterTokens records the current token of each joined cluster in the Broker status, rotating the tokens
+// which are due according to the Broker's token rotation settings. A new token Secret is created for the cluster's
+// service account and the previous ones are marked as superseded; joined clusters pick up the new token through their
+// broker secret syncer, which authenticates with the previous token until then. The superseded tokens are deleted once
+// the new token has been populated by the token controller and the overlap period has elapsed, or as soon as the new
+// token has been populated if they were issued before the RotateIssuedBefore cut-off, since they may have leaked.
+func (r *BrokerReconciler) reconcileClusterTokens(ctx context.Context, broker *v1alpha1.Broker) error {
+	clusters := &submv1.ClusterList{}
+
+	err := r.Client.List(ctx, clusters, client.InNamespace(broker.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the Cluster resources")
+	}
+
+	secrets := &corev1.SecretList{}
+
+	err = r.Client.List(ctx, secrets, client.InNamespace(broker.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the Secrets")
+	}
+
+	tokensBySA := map[string][]*corev1.Secret{}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if secret.Type == corev1.SecretTypeServiceAccountToken && secret.DeletionTimestamp == nil {
+			saName := secret.Annotations[corev1.ServiceAccountNameKey]
+			tokensBySA[saName] = append(tokensBySA[saName], secret)
+		}
+	}
+
+	sort.Slice(clusters.Items, func(i, j int) bool {
+		return clusters.Items[i].Spec.ClusterID < clusters.Items[j].Spec.ClusterID
+	})
+
+	var clusterTokens []v1alpha1.ClusterToken
+
+	for i := range clusters.Items {
+		clusterID := clusters.Items[i].Spec.ClusterID
+
+		tokens := tokensBySA[names.ForClusterSA(clusterID)]
+		if len(tokens) == 0 {
+			continue
+		}
+
+		sort.Slice(tokens, func(i, j int) bool {
+			return tokenIssueTime(tokens[i]).After(tokenIssueTime(tokens[j]))
+		})
+
+		current := tokens[0]
+
+		if tokenRotationDue(broker.Spec.ClusterTokenRotation, clusterID, tokenIssueTime(current)) {
+			current, err = r.rotateClusterToken(ctx, broker.Namespace, clusterID, tokens)
+			if err != nil {
+				return err
+			}
+		} else {
+			err = r.deleteSupersededTokens(ctx, broker.Spec.ClusterTokenRotation, clusterID, current, tokens[1:])
+			if err != nil {
+				return err
+			}
+		}
+
+		clusterTokens = append(clusterTokens, v1alpha1.ClusterToken{
+			ClusterID:  clusterID,
+			SecretName: current.Name,
+			IssueTime:  metav1.NewTime(tokenIssueTime(current)),
+		})
+	}
+
+	broker.Status.ClusterTokens = clusterTokens
+
+	return nil
+}
+
+func (r *BrokerReconciler) rotateClusterToken(ctx context.Context, namespace, clusterID string, previous []*corev1.Secret,
+) (*corev1.Secret, error) {
+	saName := names.ForClusterSA(clusterID)
+
+	token := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: saName + "-token-",
+			Namespace:    namespace,
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey: saName,
+				tokenIssueTimeAnnotation:     time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+
+	err := r.Client.Create(ctx, token)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error creating a new token for cluster %q", clusterID)
+	}
+
+	log.Info("Rotated the broker token", "cluster", clusterID, "secret", token.Name)
+
+	for _, secret := range previous {
+		if _, ok := secret.Annotations[tokenSupersededAnnotation]; ok {
+			continue
+		}
+
+		patch := client.MergeFrom(secret.DeepCopy())
+		metav1.SetMetaDataAnnotation(&secret.ObjectMeta, tokenSupersededAnnotation, token.Name)
+
+		err = r.Client.Patch(ctx, secret, patch)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, errors.Wrapf(err, "error marking the previous token %q for cluster %q as superseded", secret.Name, clusterID)
+		}
+	}
+
+	return token, nil
+}
+
+// supersededTokenExpired determines whether a token superseded by the given current token can be deleted: the current
+// token must have been populated by the token controller, and the overlap period must have elapsed unless the superseded
+// token was issued before the RotateIssuedBefore cut-off.
+func supersededTokenExpired(rotation *v1alpha1.ClusterTokenRotation, current, superseded *corev1.Secret) bool {
+	if len(current.Data[corev1.ServiceAccountTokenKey]) == 0 {
+		return false
+	}
+
+	if rotation != nil && rotation.RotateIssuedBefore != nil && tokenIssueTime(superseded).Before(rotation.RotateIssuedBefore.Time) {
+		return true
+	}
+
+	overlapPeriod := defaultTokenOverlapPeriod
+	if rotation != nil && rotation.OverlapPeriod != nil {
+		overlapPeriod = rotation.OverlapPeriod.Duration
+	}
+
+	return time.Since(tokenIssueTime(current)) >= overlapPeriod
+}
+
+func (r *BrokerReconciler) deleteSupersededTokens(ctx context.Context, rotation *v1alpha1.ClusterTokenRotation, clusterID string,
+	current *corev1.Secret, tokens []*corev1.Secret,
+) error {
+	for _, secret := range tokens {
+		if _, ok := secret.Annotations[tokenSupersededAnnotation]; !ok || !supersededTokenExpired(rotation, current, secret) {
+			continue
+		}
+
+		err := r.Client.Delete(ctx, secret)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the superseded token %q for cluster %q", secret.Name, clusterID)
+		}
+
+		log.Info("Deleted a superseded broker token", "cluster", clusterID, "secret", secret.Name)
+	}
+
+	return nil
+}
+
+func tokenIssueTime(token *corev1.Secret) time.Time {
+	if issued, err := time.Parse(time.RFC3339, token.Annotations[tokenIssueTimeAnnotation]); err == nil {
+		return issued
+	}
+
+	return token.CreationTimestamp.Time
+}
+
+func tokenRotationDue(rotation *v1alpha1.ClusterTokenRotation, clusterID string, issued time.Time) bool {
+	if rotation == nil || (len(rotation.ClusterIDs) > 0 && !slices.Contains(rotation.ClusterIDs, clusterID)) {
+		return false
+	}
+
+	if rotation.RotateIssuedBefore != nil && issued.Before(rotation.RotateIssuedBefore.Time) {
+		return true
+	}
+
+	return rotation.Interval != nil && rotation.Interval.Duration > 0 && time.Since(issued) >= rotation.Interval.Duration
+}
@@ -0,0 +1,111 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/discovery/globalnet"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// validateBrokerSpec rejects Broker configurations which can't work, so that they are reported when the broker is
+// deployed rather than when clusters later try to join it. The components must be among the given supported ones.
+func validateBrokerSpec(spec *v1alpha1.BrokerSpec, brokerComponents sets.Set[string]) error {
+	var unknown []string
+
+	for _, component := range spec.Components {
+		if !brokerComponents.Has(component) {
+			unknown = append(unknown, component)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown component(s) %s, the supported components are %s", strings.Join(unknown, ", "),
+			strings.Join(sets.List(brokerComponents), ", "))
+	}
+
+	for i := range spec.FederatedClusterSubjects {
+		subject := &spec.FederatedClusterSubjects[i]
+		if (subject.Kind != rbacv1.UserKind && subject.Kind != rbacv1.GroupKind) || subject.Name == "" {
+			return fmt.Errorf("invalid federated cluster subject %s %q, only named User and Group subjects are supported",
+				subject.Kind, subject.Name)
+		}
+	}
+
+	// The federated subjects can't be tied to a cluster, so they can't be bound to the role of a single cluster
+	if spec.ClusterIsolation && len(spec.FederatedClusterSubjects) > 0 {
+		return errors.New("federated cluster subjects can't be used with cluster isolation")
+	}
+
+	if spec.ClusterEnrollment != nil {
+		// The federated subjects don't belong to a cluster which could be approved, so they would bypass the enrollment
+		if len(spec.FederatedClusterSubjects) > 0 {
+			return errors.New("federated cluster subjects can't be used with cluster enrollment")
+		}
+
+		for _, pattern := range spec.ClusterEnrollment.AutoApprovePatterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("invalid cluster auto-approval pattern %q", pattern)
+			}
+		}
+	}
+
+	for _, observer := range spec.Observers {
+		if problems := validation.IsDNS1123Label(observer); len(problems) > 0 {
+			return fmt.Errorf("invalid observer name %q: %s", observer, strings.Join(problems, ", "))
+		}
+	}
+
+	// Departed clusters are determined from the Cluster resources, which are registered by the connectivity component
+	if spec.StaleClusterCleanup != nil && !brokerComponentEnabled(spec, connectivityComponent) {
+		return fmt.Errorf("the %q component is required by stale cluster cleanup", connectivityComponent)
+	}
+
+	if !spec.GlobalnetEnabled {
+		return nil
+	}
+
+	if !brokerComponentEnabled(spec, connectivityComponent) {
+		return fmt.Errorf("the %q component is required by Globalnet", connectivityComponent)
+	}
+
+	// An empty range means the default range
+	if spec.GlobalnetCIDRRange == "" {
+		return nil
+	}
+
+	if err := globalnet.IsValidCIDR(spec.GlobalnetCIDRRange); err != nil {
+		return errors.Wrap(err, "invalid Globalnet CIDR range")
+	}
+
+	if spec.DefaultGlobalnetClusterSize != 0 {
+		if _, err := globalnet.GetValidClusterSize(spec.GlobalnetCIDRRange, spec.DefaultGlobalnetClusterSize); err != nil {
+			return errors.Wrap(err, "invalid default Globalnet cluster size")
+		}
+	}
+
+	return nil
+}
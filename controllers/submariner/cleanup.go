@@ -20,7 +20,6 @@ package submariner
 
 import (
 	"context"
-	"time"
 
 	"github.com/submariner-io/admiral/pkg/finalizer"
 	"github.com/submariner-io/admiral/pkg/names"
@@ -33,6 +32,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -91,7 +91,11 @@ func (r *Reconciler) runComponentCleanup(ctx context.Context, instance *operator
 	}
 
 	if requeue {
-		return reconcile.Result{RequeueAfter: time.Millisecond * 500}, nil
+		return reconcile.Result{RequeueAfter: r.backoff.After(client.ObjectKeyFromObject(instance))}, nil
+	}
+
+	if err := r.removeGatewayDrainingLabels(ctx); err != nil {
+		return reconcile.Result{}, err
 	}
 
 	return reconcile.Result{}, r.removeFinalizer(ctx, instance)
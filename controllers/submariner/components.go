@@ -0,0 +1,304 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Component is a part of the Submariner deployment. The Submariner controller validates all the components, then
+// reconciles the enabled ones and cleans up the others, and finally records their status; the built-in components
+// come first, followed by those added in the controller Config, in order.
+type Component interface {
+	// Name returns the name of the component, as used for image overrides.
+	Name() string
+
+	// Enabled determines whether the component is deployed for the given Submariner.
+	Enabled(submariner *v1alpha1.Submariner) bool
+
+	// Validate checks the component's configuration in the given Submariner.
+	Validate(submariner *v1alpha1.Submariner) error
+
+	// Render returns the resources deployed for the component, without contacting a cluster.
+	Render(submariner *v1alpha1.Submariner) []client.Object
+
+	// Reconcile deploys the component.
+	Reconcile(ctx context.Context, submariner *v1alpha1.Submariner, reqLogger logr.Logger) error
+
+	// Cleanup removes the component's resources when it isn't enabled.
+	Cleanup(ctx context.Context, submariner *v1alpha1.Submariner, reqLogger logr.Logger) error
+
+	// Status records the status of the component in the given Submariner's status.
+	Status(ctx context.Context, submariner *v1alpha1.Submariner) error
+}
+
+func builtinComponents(r *Reconciler) []Component {
+	return []Component{
+		&gatewayDeployment{r: r},
+		&routeAgentDeployment{r: r},
+		&globalnetDeployment{r: r},
+		&metricsProxyDeployment{r: r},
+		&serviceDiscoveryDeployment{r: r},
+	}
+}
+
+func (r *Reconciler) components() []Component {
+	return append(builtinComponents(r), r.config.Components...)
+}
+
+// The gateway engine, along with its load balancer if requested.
+type gatewayDeployment struct {
+	r *Reconciler
+}
+
+func (c *gatewayDeployment) Name() string {
+	return names.GatewayComponent
+}
+
+func (c *gatewayDeployment) Enabled(_ *v1alpha1.Submariner) bool {
+	return true
+}
+
+func (c *gatewayDeployment) Validate(_ *v1alpha1.Submariner) error {
+	return nil
+}
+
+func (c *gatewayDeployment) Render(submariner *v1alpha1.Submariner) []client.Object {
+	objs := appendDaemonSets(nil, submariner, newGatewayDaemonSet(submariner, names.GatewayComponent), names.GatewayComponent)
+
+	if submariner.Spec.LoadBalancerEnabled {
+		objs = append(objs, newLoadBalancerService(submariner, ""))
+	}
+
+	return objs
+}
+
+func (c *gatewayDeployment) Reconcile(ctx context.Context, submariner *v1alpha1.Submariner, reqLogger logr.Logger) error {
+	if _, err := c.r.reconcileGatewayDaemonSet(ctx, submariner, reqLogger); err != nil {
+		return err
+	}
+
+	if submariner.Spec.LoadBalancerEnabled {
+		if _, err := c.r.reconcileLoadBalancer(ctx, submariner, reqLogger); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *gatewayDeployment) Cleanup(_ context.Context, _ *v1alpha1.Submariner, _ logr.Logger) error {
+	return nil
+}
+
+func (c *gatewayDeployment) Status(ctx context.Context, submariner *v1alpha1.Submariner) error {
+	err := c.r.updateComponentDaemonSetStatus(ctx, submariner, names.GatewayComponent, &submariner.Status.GatewayDaemonSetStatus)
+	if err != nil {
+		return err
+	}
+
+	submariner.Status.LoadBalancerStatus.Status = nil
+
+	if !submariner.Spec.LoadBalancerEnabled {
+		return nil
+	}
+
+	loadBalancer := newLoadBalancerService(submariner, "")
+
+	err = c.r.config.ScopedClient.Get(ctx, client.ObjectKeyFromObject(loadBalancer), loadBalancer)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "error retrieving the load balancer Service")
+	}
+
+	submariner.Status.LoadBalancerStatus.Status = &loadBalancer.Status.LoadBalancer
+
+	return nil
+}
+
+type routeAgentDeployment struct {
+	r *Reconciler
+}
+
+func (c *routeAgentDeployment) Name() string {
+	return names.RouteAgentComponent
+}
+
+func (c *routeAgentDeployment) Enabled(_ *v1alpha1.Submariner) bool {
+	return true
+}
+
+func (c *routeAgentDeployment) Validate(_ *v1alpha1.Submariner) error {
+	return nil
+}
+
+func (c *routeAgentDeployment) Render(submariner *v1alpha1.Submariner) []client.Object {
+	return appendDaemonSets(nil, submariner, newRouteAgentDaemonSet(submariner, names.RouteAgentComponent), names.RouteAgentComponent)
+}
+
+func (c *routeAgentDeployment) Reconcile(ctx context.Context, submariner *v1alpha1.Submariner, reqLogger logr.Logger) error {
+	_, err := c.r.reconcileRouteagentDaemonSet(ctx, submariner, reqLogger)
+	return err
+}
+
+func (c *routeAgentDeployment) Cleanup(_ context.Context, _ *v1alpha1.Submariner, _ logr.Logger) error {
+	return nil
+}
+
+func (c *routeAgentDeployment) Status(ctx context.Context, submariner *v1alpha1.Submariner) error {
+	return c.r.updateComponentDaemonSetStatus(ctx, submariner, names.RouteAgentComponent, &submariner.Status.RouteAgentDaemonSetStatus)
+}
+
+type globalnetDeployment struct {
+	r *Reconciler
+}
+
+func (c *globalnetDeployment) Name() string {
+	return names.GlobalnetComponent
+}
+
+func (c *globalnetDeployment) Enabled(submariner *v1alpha1.Submariner) bool {
+	return submariner.Spec.GlobalCIDR != ""
+}
+
+func (c *globalnetDeployment) Validate(_ *v1alpha1.Submariner) error {
+	return nil
+}
+
+func (c *globalnetDeployment) Render(submariner *v1alpha1.Submariner) []client.Object {
+	return appendDaemonSets(nil, submariner, newGlobalnetDaemonSet(submariner, names.GlobalnetComponent), names.GlobalnetComponent)
+}
+
+func (c *globalnetDeployment) Reconcile(ctx context.Context, submariner *v1alpha1.Submariner, reqLogger logr.Logger) error {
+	_, err := c.r.reconcileGlobalnetDaemonSet(ctx, submariner, reqLogger)
+	return err
+}
+
+// Cleanup leaves Globalnet in place: the cluster's global IPs remain allocated until Submariner is uninstalled.
+func (c *globalnetDeployment) Cleanup(_ context.Context, _ *v1alpha1.Submariner, _ logr.Logger) error {
+	return nil
+}
+
+func (c *globalnetDeployment) Status(ctx context.Context, submariner *v1alpha1.Submariner) error {
+	if !c.Enabled(submariner) {
+		return nil
+	}
+
+	return c.r.updateComponentDaemonSetStatus(ctx, submariner, names.GlobalnetComponent, &submariner.Status.GlobalnetDaemonSetStatus)
+}
+
+type metricsProxyDeployment struct {
+	r *Reconciler
+}
+
+func (c *metricsProxyDeployment) Name() string {
+	return names.MetricsProxyComponent
+}
+
+func (c *metricsProxyDeployment) Enabled(_ *v1alpha1.Submariner) bool {
+	return true
+}
+
+func (c *metricsProxyDeployment) Validate(_ *v1alpha1.Submariner) error {
+	return nil
+}
+
+func (c *metricsProxyDeployment) Render(submariner *v1alpha1.Submariner) []client.Object {
+	return appendDaemonSets(nil, submariner, newMetricsProxyDaemonSet(submariner), names.MetricsProxyComponent)
+}
+
+func (c *metricsProxyDeployment) Reconcile(ctx context.Context, submariner *v1alpha1.Submariner, reqLogger logr.Logger) error {
+	_, err := c.r.reconcileMetricsProxyDaemonSet(ctx, submariner, reqLogger)
+	return err
+}
+
+func (c *metricsProxyDeployment) Cleanup(_ context.Context, _ *v1alpha1.Submariner, _ logr.Logger) error {
+	return nil
+}
+
+// TODO: vthapar Add metrics-proxy status to Submariner CR so we can update it with daemonset status
+func (c *metricsProxyDeployment) Status(_ context.Context, _ *v1alpha1.Submariner) error {
+	return nil
+}
+
+// The Lighthouse agent and CoreDNS, which are deployed by the ServiceDiscovery controller.
+type serviceDiscoveryDeployment struct {
+	r *Reconciler
+}
+
+func (c *serviceDiscoveryDeployment) Name() string {
+	return serviceDiscoveryComponent
+}
+
+func (c *serviceDiscoveryDeployment) Enabled(submariner *v1alpha1.Submariner) bool {
+	return submariner.Spec.ServiceDiscoveryEnabled
+}
+
+func (c *serviceDiscoveryDeployment) Validate(_ *v1alpha1.Submariner) error {
+	return nil
+}
+
+func (c *serviceDiscoveryDeployment) Render(submariner *v1alpha1.Submariner) []client.Object {
+	sd := newServiceDiscoveryCR(submariner.Namespace)
+	sd.Spec = newServiceDiscoverySpec(submariner)
+
+	return []client.Object{sd}
+}
+
+func (c *serviceDiscoveryDeployment) Reconcile(ctx context.Context, submariner *v1alpha1.Submariner, reqLogger logr.Logger) error {
+	return c.r.serviceDiscoveryReconciler(ctx, submariner, reqLogger, true)
+}
+
+func (c *serviceDiscoveryDeployment) Cleanup(ctx context.Context, submariner *v1alpha1.Submariner, reqLogger logr.Logger) error {
+	return c.r.serviceDiscoveryReconciler(ctx, submariner, reqLogger, false)
+}
+
+// The ServiceDiscovery resource has its own status.
+func (c *serviceDiscoveryDeployment) Status(_ context.Context, _ *v1alpha1.Submariner) error {
+	return nil
+}
+
+// updateComponentDaemonSetStatus records the status of the given component's DaemonSet, if it exists.
+func (r *Reconciler) updateComponentDaemonSetStatus(ctx context.Context, submariner *v1alpha1.Submariner, component string,
+	status *v1alpha1.DaemonSetStatusWrapper,
+) error {
+	daemonSet := &appsv1.DaemonSet{}
+
+	err := r.config.ScopedClient.Get(ctx, client.ObjectKey{Namespace: submariner.Namespace, Name: component}, daemonSet)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving the %s DaemonSet", component)
+	}
+
+	return updateDaemonSetStatus(ctx, r.config.ScopedClient, daemonSet, status, submariner.Namespace)
+}
@@ -0,0 +1,246 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const defaultConnectivitySampleInterval = 30 * time.Second
+
+var defaultConnectivityWindows = []time.Duration{time.Hour, 24 * time.Hour}
+
+type connectivitySample struct {
+	time      time.Time
+	available bool
+	latency   time.Duration
+}
+
+// connectivityRecorder holds the connectivity samples of a Submariner resource, by remote cluster ID. Samples older than
+// the longest window are dropped.
+type connectivityRecorder struct {
+	interval   time.Duration
+	lastSample time.Time
+	samples    map[string][]connectivitySample
+}
+
+// recordConnectivity samples the connections of the active gateway, at most once per sample interval, and returns their
+// availability over the windows of the connectivity SLO. The connections of the remote clusters are sampled as
+// unavailable while there is no active gateway.
+func (r *Reconciler) recordConnectivity(key types.NamespacedName, slo *v1alpha1.ConnectivitySLO, gateways []submv1.Gateway,
+	now time.Time,
+) []v1alpha1.ConnectionAvailability {
+	if slo == nil {
+		r.removeConnectivityRecorder(key)
+
+		return nil
+	}
+
+	interval, windows := connectivitySLOSettings(slo)
+
+	r.connectivityMutex.Lock()
+	defer r.connectivityMutex.Unlock()
+
+	recorder, found := r.connectivityRecorders[key]
+	if !found {
+		recorder = &connectivityRecorder{samples: map[string][]connectivitySample{}}
+		r.connectivityRecorders[key] = recorder
+	}
+
+	recorder.interval = interval
+
+	if now.Sub(recorder.lastSample) >= interval {
+		recorder.sample(gateways, now)
+		recorder.prune(now.Add(-windows[len(windows)-1]))
+	}
+
+	availability := recorder.availability(now, windows)
+	recordConnectionAvailability(availability)
+
+	return availability
+}
+
+func (r *Reconciler) removeConnectivityRecorder(key types.NamespacedName) {
+	r.connectivityMutex.Lock()
+	defer r.connectivityMutex.Unlock()
+
+	if _, found := r.connectivityRecorders[key]; found {
+		delete(r.connectivityRecorders, key)
+		recordNoConnectionAvailability()
+	}
+}
+
+// nextConnectivitySample returns the delay until the next connectivity sample of the given Submariner resource, or zero if
+// its connectivity isn't recorded.
+func (r *Reconciler) nextConnectivitySample(key types.NamespacedName) time.Duration {
+	r.connectivityMutex.Lock()
+	defer r.connectivityMutex.Unlock()
+
+	recorder, found := r.connectivityRecorders[key]
+	if !found {
+		return 0
+	}
+
+	return max(time.Until(recorder.lastSample.Add(recorder.interval)), time.Second)
+}
+
+// connectivitySLOSettings returns the sample interval and the windows, sorted by duration, of the given connectivity SLO.
+func connectivitySLOSettings(slo *v1alpha1.ConnectivitySLO) (time.Duration, []time.Duration) {
+	interval := defaultConnectivitySampleInterval
+	if slo.SampleInterval != nil && slo.SampleInterval.Duration > 0 {
+		interval = slo.SampleInterval.Duration
+	}
+
+	windows := []time.Duration{}
+
+	for i := range slo.Windows {
+		if slo.Windows[i].Duration > 0 {
+			windows = append(windows, slo.Windows[i].Duration)
+		}
+	}
+
+	if len(windows) == 0 {
+		windows = defaultConnectivityWindows
+	}
+
+	sort.Slice(windows, func(i, j int) bool {
+		return windows[i] < windows[j]
+	})
+
+	return interval, windows
+}
+
+func (c *connectivityRecorder) sample(gateways []submv1.Gateway, now time.Time) {
+	c.lastSample = now
+
+	var active *submv1.Gateway
+
+	for i := range gateways {
+		if gateways[i].Status.HAStatus == submv1.HAStatusActive {
+			active = &gateways[i]
+			break
+		}
+	}
+
+	if active == nil {
+		for clusterID := range c.samples {
+			c.samples[clusterID] = append(c.samples[clusterID], connectivitySample{time: now})
+		}
+
+		return
+	}
+
+	for i := range active.Status.Connections {
+		connection := &active.Status.Connections[i]
+		sample := connectivitySample{
+			time:      now,
+			available: connection.Status == submv1.Connected,
+		}
+
+		if sample.available && connection.LatencyRTT != nil {
+			// The latency is optional, an unparseable one is ignored
+			sample.latency, _ = time.ParseDuration(connection.LatencyRTT.Last)
+		}
+
+		clusterID := connection.Endpoint.ClusterID
+		c.samples[clusterID] = append(c.samples[clusterID], sample)
+	}
+}
+
+func (c *connectivityRecorder) prune(oldest time.Time) {
+	for clusterID, samples := range c.samples {
+		i := sort.Search(len(samples), func(i int) bool {
+			return !samples[i].time.Before(oldest)
+		})
+
+		if i == len(samples) {
+			delete(c.samples, clusterID)
+		} else {
+			c.samples[clusterID] = samples[i:]
+		}
+	}
+}
+
+func (c *connectivityRecorder) availability(now time.Time, windows []time.Duration) []v1alpha1.ConnectionAvailability {
+	clusterIDs := make([]string, 0, len(c.samples))
+	for clusterID := range c.samples {
+		clusterIDs = append(clusterIDs, clusterID)
+	}
+
+	sort.Strings(clusterIDs)
+
+	var availability []v1alpha1.ConnectionAvailability
+
+	for _, clusterID := range clusterIDs {
+		samples := c.samples[clusterID]
+
+		for _, window := range windows {
+			oldest := now.Add(-window)
+			start := sort.Search(len(samples), func(i int) bool {
+				return !samples[i].time.Before(oldest)
+			})
+
+			if start == len(samples) {
+				continue
+			}
+
+			availability = append(availability, windowAvailability(clusterID, window, samples[start:]))
+		}
+	}
+
+	return availability
+}
+
+func windowAvailability(clusterID string, window time.Duration, samples []connectivitySample) v1alpha1.ConnectionAvailability {
+	available := 0
+	measured := 0
+
+	var latency time.Duration
+
+	for i := range samples {
+		if samples[i].available {
+			available++
+		}
+
+		if samples[i].latency > 0 {
+			measured++
+			latency += samples[i].latency
+		}
+	}
+
+	result := v1alpha1.ConnectionAvailability{
+		ClusterID:    clusterID,
+		Window:       metav1.Duration{Duration: window},
+		Availability: fmt.Sprintf("%.2f", float64(available)*100/float64(len(samples))),
+		Samples:      int32(len(samples)),
+	}
+
+	if measured > 0 {
+		result.AverageLatency = (latency / time.Duration(measured)).Round(time.Microsecond).String()
+	}
+
+	return result
+}
@@ -0,0 +1,129 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// The cluster autoscaler doesn't scale down nodes running pods annotated as not safe to evict.
+const safeToEvictAnnotation = "cluster-autoscaler.kubernetes.io/safe-to-evict"
+
+// The DaemonSets whose pods can be protected against eviction.
+var evictionProtectedComponents = []string{names.GatewayComponent, names.RouteAgentComponent}
+
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;create;update;delete
+
+// reconcilePodDisruptionBudgets creates or removes the PodDisruptionBudgets protecting the gateways and the route
+// agents, as configured by the eviction protection.
+func (r *Reconciler) reconcilePodDisruptionBudgets(ctx context.Context, instance *v1alpha1.Submariner,
+	reqLogger logr.Logger,
+) error {
+	budgets := newPodDisruptionBudgets(instance)
+
+	for _, name := range evictionProtectedComponents {
+		budget, protected := budgets[name]
+		if !protected {
+			if err := r.deletePodDisruptionBudget(ctx, instance.Namespace, name); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		pdb := &policyv1.PodDisruptionBudget{ObjectMeta: budget.ObjectMeta}
+
+		result, err := controllerutil.CreateOrUpdate(ctx, r.config.ScopedClient, pdb, func() error {
+			pdb.Spec = budget.Spec
+
+			return controllerutil.SetControllerReference(instance, pdb, r.config.Scheme)
+		})
+		if err != nil {
+			return errors.Wrapf(err, "error reconciling the PodDisruptionBudget %q", name)
+		}
+
+		if result != controllerutil.OperationResultNone {
+			reqLogger.Info("Reconciled PodDisruptionBudget", "name", name, "result", result)
+		}
+	}
+
+	return nil
+}
+
+// newPodDisruptionBudgets returns the PodDisruptionBudgets required by the eviction protection, keyed by the name of
+// the DaemonSet whose pods they cover.
+func newPodDisruptionBudgets(cr *v1alpha1.Submariner) map[string]*policyv1.PodDisruptionBudget {
+	budgets := map[string]*policyv1.PodDisruptionBudget{}
+
+	if cr.Spec.EvictionProtection == nil {
+		return budgets
+	}
+
+	// The last available gateway can't be evicted
+	budgets[names.GatewayComponent] = newPodDisruptionBudget(cr, names.GatewayComponent,
+		policyv1.PodDisruptionBudgetSpec{MinAvailable: ptr.To(intstr.FromInt32(1))})
+
+	if cr.Spec.EvictionProtection.RouteAgents {
+		budgets[names.RouteAgentComponent] = newPodDisruptionBudget(cr, names.RouteAgentComponent,
+			policyv1.PodDisruptionBudgetSpec{MaxUnavailable: ptr.To(intstr.FromInt32(1))})
+	}
+
+	return budgets
+}
+
+func newPodDisruptionBudget(cr *v1alpha1.Submariner, name string, spec policyv1.PodDisruptionBudgetSpec,
+) *policyv1.PodDisruptionBudget {
+	spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{appLabel: name}}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: cr.Namespace, Name: name},
+		Spec:       spec,
+	}
+}
+
+func (r *Reconciler) deletePodDisruptionBudget(ctx context.Context, namespace, name string) error {
+	err := r.config.ScopedClient.Delete(ctx, &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "error deleting the PodDisruptionBudget %q", name)
+	}
+
+	return nil
+}
+
+// evictionAnnotations returns the pod annotations preventing the cluster autoscaler from evicting protected pods.
+func evictionAnnotations(protected bool) map[string]string {
+	if !protected {
+		return nil
+	}
+
+	return map[string]string{safeToEvictAnnotation: "false"}
+}
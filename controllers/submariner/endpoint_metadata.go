@@ -0,0 +1,176 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// These record the custom metadata keys applied to an Endpoint, so that they can be removed once they're no longer
+	// requested.
+	managedEndpointLabelsAnnotation      = "submariner.io/managed-labels"
+	managedEndpointAnnotationsAnnotation = "submariner.io/managed-annotations"
+
+	// These carry the cluster metadata to the other clusters.
+	clusterDisplayNameAnnotation = "submariner.io/cluster-display-name"
+	clusterRegionAnnotation      = "submariner.io/cluster-region"
+	clusterEnvironmentAnnotation = "submariner.io/cluster-environment"
+)
+
+// reconcileEndpointMetadata applies the custom labels and annotations, the cluster metadata and the candidate gateway
+// addresses to the local Endpoints.
+// The gateway recreates its Endpoint without them, so they are reapplied whenever the Endpoints change.
+func (r *Reconciler) reconcileEndpointMetadata(ctx context.Context, instance *v1alpha1.Submariner) error {
+	endpoints := &submv1.EndpointList{}
+
+	err := r.config.ScopedClient.List(ctx, endpoints, client.InNamespace(instance.Namespace))
+	if err != nil {
+		return errors.Wrap(err, "error listing the Endpoint resources")
+	}
+
+	labels, annotations := map[string]string{}, map[string]string{}
+	if instance.Spec.EndpointMetadata != nil {
+		labels = customEndpointMetadata(instance.Spec.EndpointMetadata.Labels)
+		annotations = customEndpointMetadata(instance.Spec.EndpointMetadata.Annotations)
+	}
+
+	addClusterMetadata(annotations, instance.Spec.ClusterMetadata)
+	addGatewayAddresses(annotations, instance.Spec.GatewayAddresses)
+
+	for i := range endpoints.Items {
+		endpoint := &endpoints.Items[i]
+		if endpoint.Spec.ClusterID != instance.Spec.ClusterID {
+			continue
+		}
+
+		updated := endpoint.DeepCopy()
+		updateEndpointMetadata(updated, labels, annotations)
+
+		if reflect.DeepEqual(updated.ObjectMeta, endpoint.ObjectMeta) {
+			continue
+		}
+
+		if err := r.config.ScopedClient.Update(ctx, updated); err != nil {
+			return errors.Wrapf(err, "error updating the metadata of Endpoint %q", endpoint.Name)
+		}
+
+		log.Info("Updated the custom metadata of the local Endpoint", "name", endpoint.Name)
+	}
+
+	return nil
+}
+
+func updateEndpointMetadata(endpoint *submv1.Endpoint, labels, annotations map[string]string) {
+	previousLabels := managedKeys(endpoint.Annotations[managedEndpointLabelsAnnotation])
+	previousAnnotations := managedKeys(endpoint.Annotations[managedEndpointAnnotationsAnnotation])
+
+	endpoint.Labels = replaceManaged(endpoint.Labels, previousLabels, labels)
+	endpoint.Annotations = replaceManaged(endpoint.Annotations, previousAnnotations, annotations)
+	endpoint.Annotations = setManagedKeys(endpoint.Annotations, managedEndpointLabelsAnnotation, labels)
+	endpoint.Annotations = setManagedKeys(endpoint.Annotations, managedEndpointAnnotationsAnnotation, annotations)
+}
+
+// replaceManaged removes the previously managed keys from the given metadata, and adds the desired entries.
+func replaceManaged(metadata map[string]string, previous []string, desired map[string]string) map[string]string {
+	for _, key := range previous {
+		delete(metadata, key)
+	}
+
+	if metadata == nil && len(desired) > 0 {
+		metadata = map[string]string{}
+	}
+
+	for k, v := range desired {
+		metadata[k] = v
+	}
+
+	return metadata
+}
+
+func setManagedKeys(metadata map[string]string, annotation string, desired map[string]string) map[string]string {
+	if len(desired) == 0 {
+		delete(metadata, annotation)
+		return metadata
+	}
+
+	keys := make([]string, 0, len(desired))
+	for k := range desired {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+
+	metadata[annotation] = strings.Join(keys, ",")
+
+	return metadata
+}
+
+func managedKeys(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
+
+// addClusterMetadata adds the set cluster metadata fields to the given annotations.
+func addClusterMetadata(annotations map[string]string, metadata *v1alpha1.ClusterMetadata) {
+	if metadata == nil {
+		return
+	}
+
+	for key, value := range map[string]string{
+		clusterDisplayNameAnnotation: metadata.DisplayName,
+		clusterRegionAnnotation:      metadata.Region,
+		clusterEnvironmentAnnotation: metadata.Environment,
+	} {
+		if value != "" {
+			annotations[key] = value
+		}
+	}
+}
+
+// customEndpointMetadata returns the given metadata without the keys reserved by Submariner.
+func customEndpointMetadata(metadata map[string]string) map[string]string {
+	custom := map[string]string{}
+
+	for k, v := range metadata {
+		if strings.HasPrefix(k, "submariner.io/") || strings.HasPrefix(k, "submariner-io/") {
+			log.Info("Ignoring the reserved Endpoint metadata key", "key", k)
+			continue
+		}
+
+		custom[k] = v
+	}
+
+	return custom
+}
@@ -0,0 +1,73 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+)
+
+// gatewayAddressesAnnotation advertises the candidate gateway addresses to the other clusters, as a comma-separated list
+// of <type>=<address> entries, in the order they should be tried.
+const gatewayAddressesAnnotation = "submariner.io/gateway-addresses"
+
+func validateGatewayAddresses(addresses []v1alpha1.GatewayAddress) error {
+	seen := map[string]bool{}
+
+	for i := range addresses {
+		ip := net.ParseIP(addresses[i].Address)
+		if ip == nil {
+			return errors.Errorf("the gateway address %q isn't a valid IP address", addresses[i].Address)
+		}
+
+		if seen[ip.String()] {
+			return errors.Errorf("the gateway address %q is listed more than once", addresses[i].Address)
+		}
+
+		seen[ip.String()] = true
+	}
+
+	return nil
+}
+
+// addGatewayAddresses adds the candidate gateway addresses, sorted by priority, to the given annotations.
+func addGatewayAddresses(annotations map[string]string, addresses []v1alpha1.GatewayAddress) {
+	if len(addresses) == 0 {
+		return
+	}
+
+	sorted := make([]v1alpha1.GatewayAddress, len(addresses))
+	copy(sorted, addresses)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Priority < sorted[j].Priority
+	})
+
+	entries := make([]string, len(sorted))
+	for i := range sorted {
+		entries[i] = fmt.Sprintf("%s=%s", sorted[i].Type, sorted[i].Address)
+	}
+
+	annotations[gatewayAddressesAnnotation] = strings.Join(entries, ",")
+}
@@ -0,0 +1,236 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultGatewayDrainTimeout = 2 * time.Minute
+
+	// Labels the node whose gateway is drained. The gateway pods avoid labeled nodes, which keeps their template
+	// unchanged during drains: changing it would restart all the gateways, including the standby gateway taking over.
+	gatewayDrainingLabel = "submariner.io/gateway-draining"
+)
+
+// updateGatewayDrain advances the drain of the active gateway, see v1alpha1.GatewayDrain, and returns the delay after
+// which the drain times out if it's in progress.
+func (r *Reconciler) updateGatewayDrain(ctx context.Context, instance *v1alpha1.Submariner) (time.Duration, error) {
+	nodes := &corev1.NodeList{}
+
+	if err := r.config.GeneralClient.List(ctx, nodes); err != nil {
+		return 0, errors.Wrap(err, "error listing the nodes")
+	}
+
+	if instance.Spec.GatewayDrain == nil {
+		instance.Status.GatewayDrain = nil
+		return 0, r.labelDrainingNode(ctx, nodes.Items, "")
+	}
+
+	gateways, err := r.retrieveGateways(ctx, instance, instance.Namespace)
+	if err != nil {
+		return 0, err
+	}
+
+	cordoned := sets.New[string]()
+
+	for i := range nodes.Items {
+		if nodes.Items[i].Spec.Unschedulable {
+			cordoned.Insert(nodes.Items[i].Name)
+		}
+	}
+
+	timeout := defaultGatewayDrainTimeout
+	if instance.Spec.GatewayDrain.Timeout != nil && instance.Spec.GatewayDrain.Timeout.Duration > 0 {
+		timeout = instance.Spec.GatewayDrain.Timeout.Duration
+	}
+
+	now := time.Now()
+	drain := nextGatewayDrain(instance.Status.GatewayDrain, timeout, gateways, newGatewayHosts(nodes.Items), cordoned, now)
+
+	if drain != nil && (instance.Status.GatewayDrain == nil || drain.Phase != instance.Status.GatewayDrain.Phase) {
+		log.Info("Gateway drain", "node", drain.Node, "phase", drain.Phase)
+	}
+
+	instance.Status.GatewayDrain = drain
+
+	drainingNode := ""
+	if drain != nil && drain.Phase != v1alpha1.GatewayDrainTimedOut {
+		drainingNode = drain.Node
+	}
+
+	if err := r.labelDrainingNode(ctx, nodes.Items, drainingNode); err != nil {
+		return 0, err
+	}
+
+	if drain == nil || drain.Phase != v1alpha1.GatewayDraining {
+		return 0, nil
+	}
+
+	return max(drain.StartTime.Add(timeout).Sub(now), time.Second), nil
+}
+
+// labelDrainingNode labels the given node, if any, as draining its gateway, and unlabels the other nodes.
+func (r *Reconciler) labelDrainingNode(ctx context.Context, nodes []corev1.Node, drainingNode string) error {
+	for i := range nodes {
+		node := &nodes[i]
+
+		labeled := node.Labels[gatewayDrainingLabel] == "true"
+		if labeled == (node.Name == drainingNode) {
+			continue
+		}
+
+		original := node.DeepCopy()
+
+		if labeled {
+			delete(node.Labels, gatewayDrainingLabel)
+		} else {
+			metav1.SetMetaDataLabel(&node.ObjectMeta, gatewayDrainingLabel, "true")
+		}
+
+		if err := r.config.GeneralClient.Patch(ctx, node, client.MergeFrom(original)); err != nil {
+			return errors.Wrapf(err, "error updating the gateway drain label of node %q", node.Name)
+		}
+	}
+
+	return nil
+}
+
+// removeGatewayDrainingLabels unlabels the nodes labeled as draining their gateway, so that they don't repel the gateways
+// of later deployments.
+func (r *Reconciler) removeGatewayDrainingLabels(ctx context.Context) error {
+	nodes := &corev1.NodeList{}
+
+	if err := r.config.GeneralClient.List(ctx, nodes, client.HasLabels{gatewayDrainingLabel}); err != nil {
+		return errors.Wrap(err, "error listing the nodes")
+	}
+
+	return r.labelDrainingNode(ctx, nodes.Items, "")
+}
+
+// nextGatewayDrain returns the drain following the current one, given the gateways and the cordoned nodes. A drain
+// starts when the node of the active gateway is cordoned and a healthy passive gateway runs on another node; it ends,
+// whatever its phase, when its node is uncordoned. Gateways whose node can't be determined aren't drained.
+func nextGatewayDrain(current *v1alpha1.GatewayDrainStatus, timeout time.Duration, gateways []submv1.Gateway,
+	hosts *gatewayHosts, cordoned sets.Set[string], now time.Time,
+) *v1alpha1.GatewayDrainStatus {
+	var active *submv1.Gateway
+
+	activeNode := ""
+	standbyAvailable := false
+
+	for i := range gateways {
+		node := hosts.nodeOf(&gateways[i].Status.LocalEndpoint)
+
+		switch {
+		case gateways[i].Status.HAStatus == submv1.HAStatusActive:
+			active = &gateways[i]
+			activeNode = node
+		case gateways[i].Status.StatusFailure == "" && node != "" && !cordoned.Has(node):
+			standbyAvailable = true
+		}
+	}
+
+	if current != nil {
+		if !cordoned.Has(current.Node) {
+			return nil
+		}
+
+		if current.Phase != v1alpha1.GatewayDraining {
+			return current
+		}
+
+		next := current.DeepCopy()
+
+		if active != nil && activeNode != current.Node && allConnected(active) {
+			next.Phase = v1alpha1.GatewayDrained
+		} else if !now.Before(current.StartTime.Add(timeout)) {
+			next.Phase = v1alpha1.GatewayDrainTimedOut
+		}
+
+		return next
+	}
+
+	if active == nil || !cordoned.Has(activeNode) || !standbyAvailable {
+		return nil
+	}
+
+	return &v1alpha1.GatewayDrainStatus{
+		Node:      activeNode,
+		Phase:     v1alpha1.GatewayDraining,
+		StartTime: metav1.NewTime(now),
+	}
+}
+
+func allConnected(gateway *submv1.Gateway) bool {
+	for i := range gateway.Status.Connections {
+		if gateway.Status.Connections[i].Status != submv1.Connected {
+			return false
+		}
+	}
+
+	return true
+}
+
+// avoidDrainingNodes keeps the gateway off the nodes being drained, see labelDrainingNode.
+func avoidDrainingNodes(daemonSet *appsv1.DaemonSet, instance *v1alpha1.Submariner) {
+	if instance.Spec.GatewayDrain == nil {
+		return
+	}
+
+	requireNodes(&daemonSet.Spec.Template.Spec, corev1.NodeSelectorTerm{
+		MatchExpressions: []corev1.NodeSelectorRequirement{{
+			Key:      gatewayDrainingLabel,
+			Operator: corev1.NodeSelectorOpNotIn,
+			Values:   []string{"true"},
+		}},
+	})
+}
+
+func (r *Reconciler) submarinersForCordonedNode(ctx context.Context, _ client.Object) []reconcile.Request {
+	submariners := &v1alpha1.SubmarinerList{}
+
+	err := r.config.ScopedClient.List(ctx, submariners)
+	if err != nil {
+		log.Error(err, "Error listing Submariner resources")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for i := range submariners.Items {
+		if submariners.Items[i].Spec.GatewayDrain != nil {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&submariners.Items[i])})
+		}
+	}
+
+	return requests
+}
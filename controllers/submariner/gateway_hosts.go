@@ -0,0 +1,70 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// gatewayHosts resolves the nodes of the gateways from their endpoints. The gateways report the host name of their
+// node, which is only its node name if the kubelet doesn't override it (--hostname-override) and the node isn't named
+// by a cloud provider (e.g. with a fully-qualified domain name). The nodes are therefore matched by name, then by their
+// host name address, then by their internal IP address, which is the gateway's private IP on host-network pods.
+type gatewayHosts struct {
+	names      sets.Set[string]
+	byHostname map[string]string
+	byIP       map[string]string
+}
+
+func newGatewayHosts(nodes []corev1.Node) *gatewayHosts {
+	hosts := &gatewayHosts{
+		names:      sets.New[string](),
+		byHostname: map[string]string{},
+		byIP:       map[string]string{},
+	}
+
+	for i := range nodes {
+		hosts.names.Insert(nodes[i].Name)
+
+		for _, address := range nodes[i].Status.Addresses {
+			if address.Type == corev1.NodeHostName {
+				hosts.byHostname[address.Address] = nodes[i].Name
+			} else if address.Type == corev1.NodeInternalIP {
+				hosts.byIP[address.Address] = nodes[i].Name
+			}
+		}
+	}
+
+	return hosts
+}
+
+// nodeOf returns the name of the node of the given gateway endpoint, or an empty string if it can't be determined.
+func (h *gatewayHosts) nodeOf(endpoint *submv1.EndpointSpec) string {
+	if h.names.Has(endpoint.Hostname) {
+		return endpoint.Hostname
+	}
+
+	if node, ok := h.byHostname[endpoint.Hostname]; ok {
+		return node
+	}
+
+	return h.byIP[endpoint.PrivateIP]
+}
@@ -0,0 +1,169 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const gatewayNodeLabel = "submariner.io/gateway"
+
+// reconcileGatewayNodes reports the nodes labeled or unlabeled as gateways since the previous reconciliation, and
+// withdraws the local Endpoints and Gateways of the nodes which are no longer labeled: the gateway pods removed from
+// these nodes don't always clean them up, which leaves stale Endpoints on the broker. The nodes of the Endpoints are
+// resolved from their host name and private IP, see gatewayHosts; Endpoints whose node can't be determined are left
+// alone.
+func (r *Reconciler) reconcileGatewayNodes(ctx context.Context, instance *v1alpha1.Submariner) error {
+	nodes := &corev1.NodeList{}
+
+	if err := r.config.GeneralClient.List(ctx, nodes); err != nil {
+		return errors.Wrap(err, "error listing the nodes")
+	}
+
+	gatewayNodes, otherNodes := sets.New[string](), sets.New[string]()
+
+	for i := range nodes.Items {
+		if isGatewayNode(&nodes.Items[i]) {
+			gatewayNodes.Insert(nodes.Items[i].Name)
+		} else {
+			otherNodes.Insert(nodes.Items[i].Name)
+		}
+	}
+
+	r.reportGatewayNodeChanges(instance, gatewayNodes)
+
+	hosts := newGatewayHosts(nodes.Items)
+
+	endpoints := &submv1.EndpointList{}
+
+	if err := r.config.ScopedClient.List(ctx, endpoints, client.InNamespace(instance.Namespace)); err != nil {
+		return errors.Wrap(err, "error listing the Endpoint resources")
+	}
+
+	for i := range endpoints.Items {
+		endpoint := &endpoints.Items[i]
+		node := hosts.nodeOf(&endpoint.Spec)
+		if endpoint.Spec.ClusterID != instance.Spec.ClusterID || !otherNodes.Has(node) {
+			continue
+		}
+
+		if err := r.config.ScopedClient.Delete(ctx, endpoint); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the Endpoint %q", endpoint.Name)
+		}
+
+		log.Info("Withdrew the Endpoint of a node which is no longer a gateway", "name", endpoint.Name, "node", node)
+		r.config.EventRecorder.Eventf(instance, corev1.EventTypeNormal, "GatewayEndpointWithdrawn",
+			"Withdrew the Endpoint %q of node %q, which is no longer labeled as a gateway", endpoint.Name, node)
+	}
+
+	gateways := &submv1.GatewayList{}
+
+	if err := r.config.ScopedClient.List(ctx, gateways, client.InNamespace(instance.Namespace)); err != nil {
+		return errors.Wrap(err, "error listing the Gateway resources")
+	}
+
+	for i := range gateways.Items {
+		gateway := &gateways.Items[i]
+		if !otherNodes.Has(hosts.nodeOf(&gateway.Status.LocalEndpoint)) {
+			continue
+		}
+
+		if err := r.config.ScopedClient.Delete(ctx, gateway); err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting the Gateway %q", gateway.Name)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reportGatewayNodeChanges(instance *v1alpha1.Submariner, current sets.Set[string]) {
+	r.gatewayNodesMutex.Lock()
+	defer r.gatewayNodesMutex.Unlock()
+
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	previous, known := r.gatewayNodes[key]
+	r.gatewayNodes[key] = current
+
+	// There's nothing to compare with after a restart
+	if !known {
+		return
+	}
+
+	for _, node := range sets.List(current.Difference(previous)) {
+		log.Info("Node labeled as a gateway", "node", node)
+		r.config.EventRecorder.Eventf(instance, corev1.EventTypeNormal, "GatewayNodeAdded",
+			"Node %q is labeled as a gateway", node)
+	}
+
+	for _, node := range sets.List(previous.Difference(current)) {
+		log.Info("Node no longer labeled as a gateway", "node", node)
+		r.config.EventRecorder.Eventf(instance, corev1.EventTypeNormal, "GatewayNodeRemoved",
+			"Node %q is no longer labeled as a gateway", node)
+	}
+}
+
+func (r *Reconciler) forgetGatewayNodes(key types.NamespacedName) {
+	r.gatewayNodesMutex.Lock()
+	defer r.gatewayNodesMutex.Unlock()
+
+	delete(r.gatewayNodes, key)
+}
+
+func isGatewayNode(node *corev1.Node) bool {
+	return node.Labels[gatewayNodeLabel] == "true"
+}
+
+// gatewayLabelChanged filters the node events which change whether the node is a gateway.
+var gatewayLabelChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return isGatewayNode(e.ObjectOld.(*corev1.Node)) != isGatewayNode(e.ObjectNew.(*corev1.Node))
+	},
+	CreateFunc:  func(e event.CreateEvent) bool { return isGatewayNode(e.Object.(*corev1.Node)) },
+	DeleteFunc:  func(e event.DeleteEvent) bool { return isGatewayNode(e.Object.(*corev1.Node)) },
+	GenericFunc: func(_ event.GenericEvent) bool { return false },
+}
+
+func (r *Reconciler) submarinersForGatewayNode(ctx context.Context, _ client.Object) []reconcile.Request {
+	submariners := &v1alpha1.SubmarinerList{}
+
+	err := r.config.ScopedClient.List(ctx, submariners)
+	if err != nil {
+		log.Error(err, "Error listing Submariner resources")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(submariners.Items))
+	for i := range submariners.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&submariners.Items[i])})
+	}
+
+	return requests
+}
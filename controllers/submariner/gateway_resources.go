@@ -28,7 +28,6 @@ import (
 	"github.com/submariner-io/admiral/pkg/names"
 	"github.com/submariner-io/admiral/pkg/syncer/broker"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
-	"github.com/submariner-io/submariner-operator/controllers/apply"
 	"github.com/submariner-io/submariner-operator/controllers/metrics"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
@@ -45,7 +44,8 @@ import (
 )
 
 const (
-	appLabel = "app"
+	appLabel              = "app"
+	secretsStoreCSIDriver = "secrets-store.csi.k8s.io"
 )
 
 func newGatewayDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.DaemonSet {
@@ -114,7 +114,7 @@ func newGatewayPodTemplate(cr *v1alpha1.Submariner, name string, podSelectorLabe
 
 		volumes = append(volumes, corev1.Volume{
 			Name:         "brokersecret",
-			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: cr.Spec.BrokerK8sSecret}},
+			VolumeSource: brokerSecretVolumeSource(cr.Spec.BrokerK8sSecret, cr.Spec.BrokerK8sSecretProviderClass),
 		})
 	}
 
@@ -134,7 +134,8 @@ func newGatewayPodTemplate(cr *v1alpha1.Submariner, name string, podSelectorLabe
 
 	podTemplate := corev1.PodTemplateSpec{
 		ObjectMeta: metav1.ObjectMeta{
-			Labels: podSelectorLabels,
+			Labels:      podSelectorLabels,
+			Annotations: evictionAnnotations(cr.Spec.EvictionProtection != nil),
 		},
 		Spec: corev1.PodSpec{
 			Affinity: &corev1.Affinity{
@@ -195,7 +196,7 @@ func newGatewayPodTemplate(cr *v1alpha1.Submariner, name string, podSelectorLabe
 						{Name: broker.EnvironmentVariable("ApiServer"), Value: cr.Spec.BrokerK8sApiServer},
 						{Name: broker.EnvironmentVariable("ApiServerToken"), Value: cr.Spec.BrokerK8sApiServerToken},
 						{Name: broker.EnvironmentVariable("RemoteNamespace"), Value: cr.Spec.BrokerK8sRemoteNamespace},
-						{Name: broker.EnvironmentVariable("CA"), Value: cr.Spec.BrokerK8sCA},
+						{Name: broker.EnvironmentVariable("CA"), Value: brokerCA(&cr.Spec)},
 						{Name: broker.EnvironmentVariable("Insecure"), Value: strconv.FormatBool(cr.Spec.BrokerK8sInsecure)},
 						{Name: broker.EnvironmentVariable("Secret"), Value: cr.Spec.BrokerK8sSecret},
 						{Name: "CE_IPSEC_PSK", Value: cr.Spec.CeIPSecPSK},
@@ -253,8 +254,11 @@ func newGatewayPodTemplate(cr *v1alpha1.Submariner, name string, podSelectorLabe
 func (r *Reconciler) reconcileGatewayDaemonSet(
 	ctx context.Context, instance *v1alpha1.Submariner, reqLogger logr.Logger,
 ) (*appsv1.DaemonSet, error) {
-	daemonSet, err := apply.DaemonSet(ctx, instance, newGatewayDaemonSet(instance, names.GatewayComponent),
-		reqLogger, r.config.ScopedClient, r.config.Scheme)
+	desired := newGatewayDaemonSet(instance, names.GatewayComponent)
+	applyNodePlacement(instance.Spec.NodePlacement, &desired.Spec.Template.Spec)
+	avoidDrainingNodes(desired, instance)
+
+	daemonSet, err := r.applyDaemonSet(ctx, instance, desired, names.GatewayComponent, reqLogger)
 	if err != nil {
 		return nil, err
 	}
@@ -285,6 +289,7 @@ func buildGatewayStatusAndUpdateMetrics(gateways []submarinerv1.Gateway) []subma
 					&gateway.Status.Connections[j].Endpoint,
 					string(gateway.Status.Connections[j].Status),
 				)
+				recordConnectionAddress(&gateway.Status.LocalEndpoint, &gateway.Status.Connections[j])
 			}
 		}
 	} else {
@@ -295,6 +300,58 @@ func buildGatewayStatusAndUpdateMetrics(gateways []submarinerv1.Gateway) []subma
 	return gatewayStatuses
 }
 
+// updateGatewayFailover tracks the changes of the active gateway. If there is no active gateway, for example in the middle
+// of a failover, the current status is kept.
+func updateGatewayFailover(current *v1alpha1.GatewayFailoverStatus, gateways []submarinerv1.Gateway,
+) *v1alpha1.GatewayFailoverStatus {
+	var active *submarinerv1.Gateway
+
+	byHostname := map[string]*submarinerv1.Gateway{}
+
+	for i := range gateways {
+		byHostname[gateways[i].Status.LocalEndpoint.Hostname] = &gateways[i]
+
+		if gateways[i].Status.HAStatus == submarinerv1.HAStatusActive {
+			active = &gateways[i]
+		}
+	}
+
+	if active == nil {
+		return current
+	}
+
+	hostname := active.Status.LocalEndpoint.Hostname
+
+	if current == nil {
+		return &v1alpha1.GatewayFailoverStatus{ActiveGateway: hostname}
+	}
+
+	if current.ActiveGateway == hostname {
+		return current
+	}
+
+	updated := current.DeepCopy()
+	updated.ActiveGateway = hostname
+
+	if current.ActiveGateway == "" {
+		return updated
+	}
+
+	updated.FailoverCount++
+	updated.LastFailoverTime = ptr.To(metav1.Now())
+
+	if gateway, found := byHostname[current.ActiveGateway]; !found {
+		updated.LastFailoverReason = fmt.Sprintf("Gateway %q was removed", current.ActiveGateway)
+	} else if gateway.Status.StatusFailure != "" {
+		updated.LastFailoverReason = fmt.Sprintf("Gateway %q became %s: %s", current.ActiveGateway, gateway.Status.HAStatus,
+			gateway.Status.StatusFailure)
+	} else {
+		updated.LastFailoverReason = fmt.Sprintf("Gateway %q became %s", current.ActiveGateway, gateway.Status.HAStatus)
+	}
+
+	return updated
+}
+
 func (r *Reconciler) retrieveGateways(ctx context.Context, owner metav1.Object,
 	namespace string,
 ) ([]submarinerv1.Gateway, error) {
@@ -318,3 +375,17 @@ func (r *Reconciler) retrieveGateways(ctx context.Context, owner metav1.Object,
 
 	return foundGateways.Items, nil
 }
+
+// brokerSecretVolumeSource returns the source of the broker credentials: the named Secret, or the Secrets Store CSI driver
+// if a SecretProviderClass is specified.
+func brokerSecretVolumeSource(secretName, secretProviderClass string) corev1.VolumeSource {
+	if secretProviderClass != "" {
+		return corev1.VolumeSource{CSI: &corev1.CSIVolumeSource{
+			Driver:           secretsStoreCSIDriver,
+			ReadOnly:         ptr.To(true),
+			VolumeAttributes: map[string]string{"secretProviderClass": secretProviderClass},
+		}}
+	}
+
+	return corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: secretName}}
+}
@@ -24,7 +24,6 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/submariner-io/admiral/pkg/names"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
-	"github.com/submariner-io/submariner-operator/controllers/apply"
 	"github.com/submariner-io/submariner-operator/controllers/metrics"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
@@ -37,8 +36,10 @@ import (
 //nolint:wrapcheck // No need to wrap errors here.
 func (r *Reconciler) reconcileGlobalnetDaemonSet(ctx context.Context, instance *v1alpha1.Submariner, reqLogger logr.Logger,
 ) (*appsv1.DaemonSet, error) {
-	daemonSet, err := apply.DaemonSet(ctx, instance, newGlobalnetDaemonSet(instance, names.GlobalnetComponent), reqLogger,
-		r.config.ScopedClient, r.config.Scheme)
+	desired := newGlobalnetDaemonSet(instance, names.GlobalnetComponent)
+	applyNodePlacement(instance.Spec.NodePlacement, &desired.Spec.Template.Spec)
+
+	daemonSet, err := r.applyDaemonSet(ctx, instance, desired, names.GlobalnetComponent, reqLogger)
 	if err != nil {
 		return nil, err
 	}
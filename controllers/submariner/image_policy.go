@@ -0,0 +1,105 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/names"
+	submopv1a1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/images"
+	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// The images of the components which can be pinned by the image policy.
+var componentImages = map[string]string{
+	names.GatewayComponent:           opnames.GatewayImage,
+	names.RouteAgentComponent:        opnames.RouteAgentImage,
+	names.GlobalnetComponent:         opnames.GlobalnetImage,
+	names.MetricsProxyComponent:      opnames.MetricsProxyImage,
+	names.ServiceDiscoveryComponent:  opnames.ServiceDiscoveryImage,
+	names.LighthouseCoreDNSComponent: opnames.LighthouseCoreDNSImage,
+}
+
+// applyImagePolicy pins the component images as configured by the image policy. The pinned images are handled as image
+// overrides; explicit image overrides take precedence.
+func (r *Reconciler) applyImagePolicy(ctx context.Context, instance *submopv1a1.Submariner) error {
+	policy := instance.Spec.ImagePolicy
+	if policy == nil || policy.Mode != submopv1a1.ImagePolicyDigest {
+		return nil
+	}
+
+	if policy.DigestConfigMap == "" {
+		return errors.New("the Digest image policy requires a digest ConfigMap")
+	}
+
+	digests := &corev1.ConfigMap{}
+
+	err := r.config.ScopedClient.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: policy.DigestConfigMap}, digests)
+	if err != nil {
+		return errors.Wrapf(err, "error retrieving the image digest ConfigMap %q", policy.DigestConfigMap)
+	}
+
+	for component, digest := range digests.Data {
+		imageName, ok := componentImages[component]
+		if !ok {
+			log.Info("Ignoring image digest for unknown component", "component", component)
+			continue
+		}
+
+		if _, overridden := instance.Spec.ImageOverrides[component]; overridden {
+			continue
+		}
+
+		if instance.Spec.ImageOverrides == nil {
+			instance.Spec.ImageOverrides = map[string]string{}
+		}
+
+		instance.Spec.ImageOverrides[component] = images.PinToDigest(getImagePath(instance, imageName, component), digest)
+	}
+
+	return nil
+}
+
+// submarinersForDigestConfigMap maps a digest ConfigMap to the Submariner resources whose image policy references it.
+func (r *Reconciler) submarinersForDigestConfigMap(ctx context.Context, object client.Object) []reconcile.Request {
+	submariners := &submopv1a1.SubmarinerList{}
+
+	err := r.config.ScopedClient.List(ctx, submariners, client.InNamespace(object.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Error listing Submariner resources")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for i := range submariners.Items {
+		policy := submariners.Items[i].Spec.ImagePolicy
+		if policy != nil && policy.DigestConfigMap == object.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&submariners.Items[i])})
+		}
+	}
+
+	return requests
+}
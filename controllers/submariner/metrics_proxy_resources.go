@@ -23,9 +23,9 @@ import (
 	"fmt"
 
 	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/names"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
-	"github.com/submariner-io/submariner-operator/controllers/apply"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
 	appsv1 "k8s.io/api/apps/v1"
@@ -33,11 +33,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-//nolint:wrapcheck // No need to wrap errors here.
+const (
+	// The image override key for the proxy securing the metrics.
+	kubeRBACProxyComponent = "kube-rbac-proxy"
+	metricsProxyTLSPath    = "/etc/metrics-proxy/tls/"
+)
+
+func validateMetricsProxyAuth(auth *v1alpha1.MetricsProxyAuth) error {
+	if auth != nil && auth.ClientCertificates && auth.TLSSecret == "" {
+		return errors.New("the metrics proxy client certificates require a TLS secret holding the client CA")
+	}
+
+	return nil
+}
+
 func (r *Reconciler) reconcileMetricsProxyDaemonSet(ctx context.Context, instance *v1alpha1.Submariner, reqLogger logr.Logger,
 ) (*appsv1.DaemonSet, error) {
-	return apply.DaemonSet(ctx, instance, newMetricsProxyDaemonSet(instance), reqLogger,
-		r.config.ScopedClient, r.config.Scheme)
+	desired := newMetricsProxyDaemonSet(instance)
+	applyNodePlacement(instance.Spec.NodePlacement, &desired.Spec.Template.Spec)
+
+	return r.applyDaemonSet(ctx, instance, desired, names.MetricsProxyComponent, reqLogger)
 }
 
 func newMetricsProxyDaemonSet(cr *v1alpha1.Submariner) *appsv1.DaemonSet {
@@ -77,9 +92,65 @@ func newMetricsProxyDaemonSet(cr *v1alpha1.Submariner) *appsv1.DaemonSet {
 			*metricProxyContainer(cr, "globalnet-metrics-proxy", fmt.Sprint(globalnetMetricsServicePort), globalnetMetricsServerPort))
 	}
 
+	if cr.Spec.MetricsProxyAuth != nil {
+		secureMetricsProxy(cr, &daemonSet.Spec.Template.Spec)
+	}
+
 	return daemonSet
 }
 
+// secureMetricsProxy replaces the plain metrics proxies with kube-rbac-proxy instances, serving the metrics over HTTPS
+// to clients authenticated and authorized by the API server.
+func secureMetricsProxy(cr *v1alpha1.Submariner, podSpec *corev1.PodSpec) {
+	auth := cr.Spec.MetricsProxyAuth
+
+	// Only the secured proxies need the service account, which isn't deployed by older installers
+	podSpec.ServiceAccountName = names.MetricsProxyComponent
+
+	if auth.TLSSecret != "" {
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+			Name:         "metrics-proxy-tls",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: auth.TLSSecret}},
+		})
+	}
+
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		listenPort, upstreamPort := container.Args[0], container.Args[2]
+
+		container.Image = kubeRBACProxyImage(cr)
+		container.ImagePullPolicy = images.GetPullPolicy(cr.Spec.Version, container.Image)
+		container.Command = nil
+		container.Args = []string{
+			"--secure-listen-address=0.0.0.0:" + listenPort,
+			"--upstream=http://$(NODE_IP):" + upstreamPort + "/",
+		}
+
+		if auth.TLSSecret == "" {
+			continue
+		}
+
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name: "metrics-proxy-tls", MountPath: metricsProxyTLSPath, ReadOnly: true,
+		})
+		container.Args = append(container.Args,
+			"--tls-cert-file="+metricsProxyTLSPath+corev1.TLSCertKey,
+			"--tls-private-key-file="+metricsProxyTLSPath+corev1.TLSPrivateKeyKey)
+
+		if auth.ClientCertificates {
+			container.Args = append(container.Args, "--client-ca-file="+metricsProxyTLSPath+"ca.crt")
+		}
+	}
+}
+
+func kubeRBACProxyImage(cr *v1alpha1.Submariner) string {
+	if override, ok := cr.Spec.ImageOverrides[kubeRBACProxyComponent]; ok {
+		return override
+	}
+
+	return opnames.KubeRBACProxyImage
+}
+
 func metricProxyContainer(cr *v1alpha1.Submariner, name, hostPort, podPort string) *corev1.Container {
 	return &corev1.Container{
 		Name:            name,
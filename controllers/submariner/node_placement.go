@@ -0,0 +1,64 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// applyNodePlacement constrains the pods to the nodes selected by the node placement defaults. The component's own node
+// selector entries take precedence.
+func applyNodePlacement(placement *v1alpha1.NodePlacement, podSpec *corev1.PodSpec) {
+	if placement == nil {
+		return
+	}
+
+	podSpec.NodeSelector = mergeNodeSelectors(placement.NodeSelector, podSpec.NodeSelector)
+	podSpec.Tolerations = mergeTolerations(placement.Tolerations, podSpec.Tolerations)
+}
+
+// mergeNodeSelectors returns the union of the default and specific node selectors, or nil if both are empty; the specific
+// entries take precedence.
+func mergeNodeSelectors(defaults, specific map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return specific
+	}
+
+	merged := make(map[string]string, len(defaults)+len(specific))
+
+	for k, v := range defaults {
+		merged[k] = v
+	}
+
+	for k, v := range specific {
+		merged[k] = v
+	}
+
+	return merged
+}
+
+// mergeTolerations returns the default tolerations followed by the specific ones, or nil if both are empty.
+func mergeTolerations(defaults, specific []corev1.Toleration) []corev1.Toleration {
+	if len(defaults) == 0 {
+		return specific
+	}
+
+	return append(append([]corev1.Toleration{}, defaults...), specific...)
+}
@@ -0,0 +1,67 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner
+
+import (
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Render returns the resources the operator would deploy for the given Submariner, without contacting a cluster.
+// Values which are normally discovered from the cluster are taken from the Submariner's status if they are set
+// there, and the cluster and service CIDRs fall back to those in the spec. Image digest policies aren't applied,
+// and owner references aren't set since the Submariner doesn't exist yet.
+func Render(submariner *v1alpha1.Submariner) []client.Object {
+	instance := submariner.DeepCopy()
+
+	if instance.Status.ClusterCIDR == "" {
+		instance.Status.ClusterCIDR = instance.Spec.ClusterCIDR
+	}
+
+	if instance.Status.ServiceCIDR == "" {
+		instance.Status.ServiceCIDR = instance.Spec.ServiceCIDR
+	}
+
+	var objs []client.Object
+
+	for _, component := range builtinComponents(nil) {
+		if component.Enabled(instance) {
+			objs = append(objs, component.Render(instance)...)
+		}
+	}
+
+	budgets := newPodDisruptionBudgets(instance)
+	for _, name := range evictionProtectedComponents {
+		if budget, ok := budgets[name]; ok {
+			objs = append(objs, budget)
+		}
+	}
+
+	return objs
+}
+
+func appendDaemonSets(objs []client.Object, instance *v1alpha1.Submariner, daemonSet *appsv1.DaemonSet, component string,
+) []client.Object {
+	for _, variant := range architectureVariants(instance, daemonSet, component) {
+		objs = append(objs, variant)
+	}
+
+	return objs
+}
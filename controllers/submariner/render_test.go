@@ -0,0 +1,108 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package submariner_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/controllers/submariner"
+	"github.com/submariner-io/submariner-operator/controllers/test"
+	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Render", func() {
+	var (
+		instance *v1alpha1.Submariner
+		objs     []client.Object
+	)
+
+	BeforeEach(func() {
+		instance = newSubmariner()
+		instance.Spec.ClusterCIDR = "10.0.0.0/16"
+		instance.Spec.ServiceCIDR = "10.1.0.0/16"
+	})
+
+	JustBeforeEach(func() {
+		objs = submariner.Render(instance)
+	})
+
+	find := func(name string) client.Object {
+		for _, obj := range objs {
+			if obj.GetName() == name {
+				return obj
+			}
+		}
+
+		return nil
+	}
+
+	It("should render the component DaemonSets", func() {
+		for _, name := range []string{
+			names.GatewayComponent, names.RouteAgentComponent, names.GlobalnetComponent,
+			names.MetricsProxyComponent,
+		} {
+			Expect(find(name)).To(BeAssignableToTypeOf(&appsv1.DaemonSet{}), "missing %s", name)
+			Expect(find(name).GetNamespace()).To(Equal(submarinerNamespace))
+		}
+	})
+
+	It("should use the CIDRs from the spec", func() {
+		envMap := test.EnvMapFrom(find(names.RouteAgentComponent).(*appsv1.DaemonSet))
+		Expect(envMap).To(HaveKeyWithValue("SUBMARINER_CLUSTERCIDR", "10.0.0.0/16"))
+		Expect(envMap).To(HaveKeyWithValue("SUBMARINER_SERVICECIDR", "10.1.0.0/16"))
+		Expect(instance.Status.ClusterCIDR).To(BeEmpty())
+	})
+
+	It("should not render optional resources that aren't enabled", func() {
+		Expect(objs).To(HaveLen(4))
+		Expect(find(opnames.ServiceDiscoveryCrName)).To(BeNil())
+	})
+
+	When("Globalnet is disabled", func() {
+		BeforeEach(func() {
+			instance.Spec.GlobalCIDR = ""
+		})
+
+		It("should not render the Globalnet DaemonSet", func() {
+			Expect(find(names.GlobalnetComponent)).To(BeNil())
+		})
+	})
+
+	When("the load balancer and service discovery are enabled", func() {
+		BeforeEach(func() {
+			instance.Spec.LoadBalancerEnabled = true
+			instance.Spec.ServiceDiscoveryEnabled = true
+		})
+
+		It("should render the load balancer Service and the ServiceDiscovery", func() {
+			Expect(objs).To(HaveLen(6))
+			Expect(objs).To(ContainElement(BeAssignableToTypeOf(&corev1.Service{})))
+
+			sd, ok := find(opnames.ServiceDiscoveryCrName).(*v1alpha1.ServiceDiscovery)
+			Expect(ok).To(BeTrue())
+			Expect(sd.Spec.ClusterID).To(Equal(instance.Spec.ClusterID))
+			Expect(sd.Spec.GlobalnetEnabled).To(BeTrue())
+		})
+	})
+})
@@ -25,7 +25,6 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/submariner-io/admiral/pkg/names"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
-	"github.com/submariner-io/submariner-operator/controllers/apply"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
 	appsv1 "k8s.io/api/apps/v1"
@@ -35,12 +34,12 @@ import (
 	"k8s.io/utils/ptr"
 )
 
-//nolint:wrapcheck // No need to wrap errors here.
+// The route agent must run on every node, so the node placement defaults don't apply to it.
 func (r *Reconciler) reconcileRouteagentDaemonSet(ctx context.Context, instance *v1alpha1.Submariner,
 	reqLogger logr.Logger,
 ) (*appsv1.DaemonSet, error) {
-	return apply.DaemonSet(ctx, instance, newRouteAgentDaemonSet(instance, names.RouteAgentComponent),
-		reqLogger, r.config.ScopedClient, r.config.Scheme)
+	return r.applyDaemonSet(ctx, instance, newRouteAgentDaemonSet(instance, names.RouteAgentComponent),
+		names.RouteAgentComponent, reqLogger)
 }
 
 func newRouteAgentDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.DaemonSet {
@@ -68,7 +67,8 @@ func newRouteAgentDaemonSet(cr *v1alpha1.Submariner, name string) *appsv1.Daemon
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
+					Labels:      labels,
+					Annotations: evictionAnnotations(cr.Spec.EvictionProtection != nil && cr.Spec.EvictionProtection.RouteAgents),
 				},
 				Spec: corev1.PodSpec{
 					TerminationGracePeriodSeconds: ptr.To(int64(1)),
@@ -40,28 +40,7 @@ func (r *Reconciler) serviceDiscoveryReconciler(ctx context.Context, submariner
 			sd := newServiceDiscoveryCR(submariner.Namespace)
 
 			result, err := controllerutil.CreateOrUpdate(ctx, r.config.ScopedClient, sd, func() error {
-				sd.Spec = v1alpha1.ServiceDiscoverySpec{
-					Version:                  submariner.Spec.Version,
-					Repository:               submariner.Spec.Repository,
-					BrokerK8sCA:              submariner.Spec.BrokerK8sCA,
-					BrokerK8sRemoteNamespace: submariner.Spec.BrokerK8sRemoteNamespace,
-					BrokerK8sApiServerToken:  submariner.Spec.BrokerK8sApiServerToken,
-					BrokerK8sApiServer:       submariner.Spec.BrokerK8sApiServer,
-					BrokerK8sInsecure:        submariner.Spec.BrokerK8sInsecure,
-					HaltOnCertificateError:   submariner.Spec.HaltOnCertificateError,
-					Debug:                    submariner.Spec.Debug,
-					ClusterID:                submariner.Spec.ClusterID,
-					Namespace:                submariner.Spec.Namespace,
-					GlobalnetEnabled:         submariner.Spec.GlobalCIDR != "",
-					ImageOverrides:           submariner.Spec.ImageOverrides,
-					CoreDNSCustomConfig:      submariner.Spec.CoreDNSCustomConfig,
-					NodeSelector:             submariner.Spec.NodeSelector,
-					Tolerations:              submariner.Spec.Tolerations,
-				}
-
-				if len(submariner.Spec.CustomDomains) > 0 {
-					sd.Spec.CustomDomains = submariner.Spec.CustomDomains
-				}
+				sd.Spec = newServiceDiscoverySpec(submariner)
 				// Set the owner and controller
 				return controllerutil.SetControllerReference(submariner, sd, r.config.Scheme)
 			})
@@ -101,3 +80,44 @@ func newServiceDiscoveryCR(namespace string) *v1alpha1.ServiceDiscovery {
 		},
 	}
 }
+
+func newServiceDiscoverySpec(submariner *v1alpha1.Submariner) v1alpha1.ServiceDiscoverySpec {
+	spec := v1alpha1.ServiceDiscoverySpec{
+		Version:                  submariner.Spec.Version,
+		Repository:               submariner.Spec.Repository,
+		BrokerK8sCA:              brokerCA(&submariner.Spec),
+		BrokerK8sRemoteNamespace: submariner.Spec.BrokerK8sRemoteNamespace,
+		BrokerK8sApiServerToken:  submariner.Spec.BrokerK8sApiServerToken,
+		BrokerK8sApiServer:       submariner.Spec.BrokerK8sApiServer,
+		BrokerK8sInsecure:        submariner.Spec.BrokerK8sInsecure,
+		HaltOnCertificateError:   submariner.Spec.HaltOnCertificateError,
+		Debug:                    submariner.Spec.Debug,
+		ClusterID:                submariner.Spec.ClusterID,
+		Namespace:                submariner.Spec.Namespace,
+		GlobalnetEnabled:         submariner.Spec.GlobalCIDR != "",
+		ImageOverrides:           submariner.Spec.ImageOverrides,
+		UpdateStrategies:         submariner.Spec.UpdateStrategies,
+		CoreDNSCustomConfig:      submariner.Spec.CoreDNSCustomConfig,
+		NodeSelector:             submariner.Spec.NodeSelector,
+		Tolerations:              submariner.Spec.Tolerations,
+		ExternalDNS:              submariner.Spec.ExternalDNS,
+		IstioServiceEntries:      submariner.Spec.IstioServiceEntries,
+	}
+
+	// Credentials supplied by an external secret manager are only available through the mounted Secret
+	if submariner.Spec.BrokerK8sSecretProviderClass != "" {
+		spec.BrokerK8sSecret = submariner.Spec.BrokerK8sSecret
+		spec.BrokerK8sSecretProviderClass = submariner.Spec.BrokerK8sSecretProviderClass
+	}
+
+	if len(submariner.Spec.CustomDomains) > 0 {
+		spec.CustomDomains = submariner.Spec.CustomDomains
+	}
+
+	if placement := submariner.Spec.NodePlacement; placement != nil {
+		spec.NodeSelector = mergeNodeSelectors(placement.NodeSelector, spec.NodeSelector)
+		spec.Tolerations = mergeTolerations(placement.Tolerations, spec.Tolerations)
+	}
+
+	return spec
+}
@@ -20,6 +20,8 @@ package submariner
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"reflect"
 	"sync"
 	"time"
@@ -33,6 +35,7 @@ import (
 	"github.com/submariner-io/admiral/pkg/syncer"
 	"github.com/submariner-io/admiral/pkg/util"
 	submopv1a1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/controllers/requeue"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
 	"github.com/submariner-io/submariner-operator/pkg/images"
 	"github.com/submariner-io/submariner-operator/pkg/names"
@@ -43,12 +46,19 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -71,6 +81,9 @@ type Config struct {
 	Scheme         *runtime.Scheme
 	DynClient      dynamic.Interface
 	ClusterNetwork *network.ClusterNetwork
+	EventRecorder  record.EventRecorder
+	// Additional components, reconciled after the built-in ones.
+	Components []Component
 }
 
 // Reconciler reconciles a Submariner object.
@@ -90,12 +103,40 @@ type Reconciler struct {
 	//   using the information from the new secret;
 	// - watch for changes to the secret, and if it changes, update the target secret.
 	// Tokens map back to their SA, so we can do both the above by watching tokens only.
-	// Since the synchronisation ends up being specific to a Submariner CR secret, we track one syncer per Submariner CR secret name.
-	// We don't keep track of the secret syncers themselves, just their cancel functions.
-	secretSyncCancelFuncs map[string]context.CancelFunc
-	syncerMutex           sync.Mutex
+	// Since the synchronisation ends up being specific to a Submariner CR secret, we track one syncer per Submariner CR secret name
+	// and namespace, and broker namespace.
+	// We don't keep track of the secret syncers themselves, just their cancel functions and a client to check their
+	// connection to the broker (see BrokerConnectionCheck).
+	secretSyncers map[secretSyncerKey]*brokerSecretSyncer
+	syncerMutex   sync.Mutex
 
 	networkPluginSyncerRemoved bool
+
+	backoff *requeue.Backoff
+
+	// The connectivity samples of the Submariner resources with a connectivity SLO, see recordConnectivity.
+	connectivityRecorders map[types.NamespacedName]*connectivityRecorder
+	connectivityMutex     sync.Mutex
+
+	// The gateway nodes seen by the previous reconciliation of each Submariner resource, see reconcileGatewayNodes.
+	gatewayNodes      map[types.NamespacedName]sets.Set[string]
+	gatewayNodesMutex sync.Mutex
+}
+
+// secretSyncerKey identifies a broker secret syncer by the secret it maintains, the broker namespace it syncs from, the
+// CA bundle it injects and the token it authenticates with. Only a hash of the token is kept, so that it doesn't end up
+// in logs or dumps of the key.
+type secretSyncerKey struct {
+	secret          types.NamespacedName
+	brokerNamespace string
+	caBundle        string
+	tokenHash       string
+}
+
+// brokerSecretSyncer tracks a running broker secret syncer.
+type brokerSecretSyncer struct {
+	cancel       context.CancelFunc
+	brokerClient rest.Interface
 }
 
 // blank assignment to verify that Reconciler implements reconcile.Reconciler.
@@ -106,7 +147,10 @@ func NewReconciler(config *Config) *Reconciler {
 	return &Reconciler{
 		config:                *config,
 		log:                   ctrl.Log.WithName("controllers").WithName("Submariner"),
-		secretSyncCancelFuncs: make(map[string]context.CancelFunc),
+		secretSyncers:         make(map[secretSyncerKey]*brokerSecretSyncer),
+		backoff:               requeue.NewBackoff("submariner-controller", requeue.DefaultBaseDelay, requeue.DefaultMaxDelay),
+		connectivityRecorders: map[types.NamespacedName]*connectivityRecorder{},
+		gatewayNodes:          map[types.NamespacedName]sets.Set[string]{},
 	}
 }
 
@@ -118,9 +162,36 @@ func NewReconciler(config *Config) *Reconciler {
 
 // +kubebuilder:rbac:groups=submariner.io,resources=submariners,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=submariner.io,resources=submariners/status,verbs=get;update;patch
-//
-//nolint:gocyclo // Refactoring would yield functions with a lot of params which isn't ideal either.
+// +kubebuilder:rbac:groups=submariner.io,resources=clusternetworks,verbs=get;list;watch;create;update;patch;delete
 func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	result, err := r.doReconcile(ctx, request)
+	if err != nil {
+		r.recordFailure(ctx, request.NamespacedName)
+
+		return result, err
+	}
+
+	if result.IsZero() {
+		r.backoff.Reset(request.NamespacedName)
+	}
+
+	// Come back for the next connectivity sample, if any
+	result.RequeueAfter = earliest(result.RequeueAfter, r.nextConnectivitySample(request.NamespacedName))
+
+	return result, nil
+}
+
+// earliest returns the earliest of the given delays, ignoring zero delays.
+func earliest(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
+	}
+
+	return a
+}
+
+//nolint:gocyclo // Refactoring would yield functions with a lot of params which isn't ideal either.
+func (r *Reconciler) doReconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	reqLogger := log.V(2).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 
 	// Fetch the Submariner instance
@@ -145,50 +216,71 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if !instance.GetDeletionTimestamp().IsZero() {
 		log.Info("Submariner is being deleted")
 		r.cancelSecretSyncer(instance)
+		r.removeConnectivityRecorder(request.NamespacedName)
+		r.forgetGatewayNodes(request.NamespacedName)
 
 		return r.runComponentCleanup(ctx, instance)
 	}
 
+	if submopv1a1.IsPaused(instance) {
+		return reconcile.Result{}, r.updatePausedStatus(ctx, instance)
+	}
+
+	if err := validateBrokerCABundle(instance.Spec.BrokerK8sCABundle); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := validateGatewayAddresses(instance.Spec.GatewayAddresses); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := validateMetricsProxyAuth(instance.Spec.MetricsProxyAuth); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// Ensure we have a secret syncer
-	if err := r.setupSecretSyncer(instance, reqLogger, request.Namespace); err != nil {
+	if err := r.setupSecretSyncer(ctx, instance, reqLogger, request.Namespace); err != nil {
 		return reconcile.Result{}, err
 	}
 
 	initialStatus := instance.Status.DeepCopy()
 
+	if err := r.applyImagePolicy(ctx, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	// This has the side effect of setting the CIDRs in the Submariner instance.
 	_, err = r.discoverNetwork(ctx, instance, reqLogger)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	gatewayDaemonSet, err := r.reconcileGatewayDaemonSet(ctx, instance, reqLogger)
+	drainTimeout, err := r.updateGatewayDrain(ctx, instance)
 	if err != nil {
 		return reconcile.Result{}, err
 	}
 
-	var loadBalancer *corev1.Service
-	if instance.Spec.LoadBalancerEnabled {
-		loadBalancer, err = r.reconcileLoadBalancer(ctx, instance, reqLogger)
-		if err != nil {
-			return reconcile.Result{}, err
-		}
-	}
+	components := r.components()
 
-	routeagentDaemonSet, err := r.reconcileRouteagentDaemonSet(ctx, instance, reqLogger)
-	if err != nil {
-		return reconcile.Result{}, err
+	for _, component := range components {
+		if err := component.Validate(instance); err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "invalid configuration for component %q", component.Name())
+		}
 	}
 
-	var globalnetDaemonSet *appsv1.DaemonSet
+	for _, component := range components {
+		if component.Enabled(instance) {
+			err = component.Reconcile(ctx, instance, reqLogger)
+		} else {
+			err = component.Cleanup(ctx, instance, reqLogger)
+		}
 
-	if instance.Spec.GlobalCIDR != "" {
-		if globalnetDaemonSet, err = r.reconcileGlobalnetDaemonSet(ctx, instance, reqLogger); err != nil {
+		if err != nil {
 			return reconcile.Result{}, err
 		}
 	}
 
-	if _, err = r.reconcileMetricsProxyDaemonSet(ctx, instance, reqLogger); err != nil {
+	if err := r.reconcilePodDisruptionBudgets(ctx, instance, reqLogger); err != nil {
 		return reconcile.Result{}, err
 	}
 
@@ -196,7 +288,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, err
 	}
 
-	if err := r.serviceDiscoveryReconciler(ctx, instance, reqLogger, instance.Spec.ServiceDiscoveryEnabled); err != nil {
+	if err := r.reconcileGatewayNodes(ctx, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err := r.reconcileEndpointMetadata(ctx, instance); err != nil {
 		return reconcile.Result{}, err
 	}
 
@@ -205,6 +301,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err != nil {
 		// Not fatal
 		log.Error(err, "error retrieving gateways")
+	} else {
+		instance.Status.ConnectionAvailability = r.recordConnectivity(request.NamespacedName, instance.Spec.ConnectivitySLO,
+			gateways, time.Now())
 	}
 
 	gatewayStatuses := buildGatewayStatusAndUpdateMetrics(gateways)
@@ -213,37 +312,26 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	instance.Status.NatEnabled = instance.Spec.NatEnabled
 	instance.Status.AirGappedDeployment = instance.Spec.AirGappedDeployment
 	instance.Status.ColorCodes = instance.Spec.ColorCodes
+	instance.Status.ClusterMetadata = instance.Spec.ClusterMetadata
 	instance.Status.ClusterID = instance.Spec.ClusterID
 	instance.Status.GlobalCIDR = instance.Spec.GlobalCIDR
 	instance.Status.Gateways = &gatewayStatuses
+	instance.Status.GatewayFailover = updateGatewayFailover(instance.Status.GatewayFailover, gateways)
+	recordGatewayFailover(instance.Status.GatewayFailover)
+	instance.Status.ReconcileFailures = 0
+	instance.Status.Paused = false
 
-	err = updateDaemonSetStatus(ctx, r.config.ScopedClient, gatewayDaemonSet, &instance.Status.GatewayDaemonSetStatus, request.Namespace)
+	instance.Status.NodeArchitectures, err = r.nodeArchitectures(ctx)
 	if err != nil {
-		reqLogger.Error(err, "failed to check gateway daemonset containers")
-
-		return reconcile.Result{}, err
-	}
-
-	err = updateDaemonSetStatus(ctx, r.config.ScopedClient, routeagentDaemonSet, &instance.Status.RouteAgentDaemonSetStatus, request.Namespace)
-	if err != nil {
-		reqLogger.Error(err, "failed to check route agent daemonset containers")
-
-		return reconcile.Result{}, err
-	}
-
-	err = updateDaemonSetStatus(ctx, r.config.ScopedClient, globalnetDaemonSet, &instance.Status.GlobalnetDaemonSetStatus, request.Namespace)
-	if err != nil {
-		reqLogger.Error(err, "failed to check gateway daemonset containers")
-
 		return reconcile.Result{}, err
 	}
 
-	// TODO: vthapar Add metrics-proxy status to Submariner CR so we can update it with daemonset status
+	for _, component := range components {
+		if err := component.Status(ctx, instance); err != nil {
+			reqLogger.Error(err, "failed to update the component status", "component", component.Name())
 
-	if loadBalancer != nil {
-		instance.Status.LoadBalancerStatus.Status = &loadBalancer.Status.LoadBalancer
-	} else {
-		instance.Status.LoadBalancerStatus.Status = nil
+			return reconcile.Result{}, err
+		}
 	}
 
 	if !reflect.DeepEqual(instance.Status, initialStatus) {
@@ -251,7 +339,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		if apierrors.IsConflict(err) {
 			reqLogger.Info("conflict occurred on status update - requeuing")
 
-			return reconcile.Result{RequeueAfter: time.Millisecond * 100}, nil
+			return reconcile.Result{RequeueAfter: r.backoff.After(request.NamespacedName)}, nil
 		}
 
 		if err != nil {
@@ -259,7 +347,42 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		}
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: drainTimeout}, nil
+}
+
+func (r *Reconciler) updatePausedStatus(ctx context.Context, instance *submopv1a1.Submariner) error {
+	if instance.Status.Paused {
+		return nil
+	}
+
+	log.Info("Reconciliation of the Submariner resource is paused", "Namespace", instance.Namespace, "Name", instance.Name)
+
+	instance.Status.Paused = true
+
+	return errors.Wrap(r.config.ScopedClient.Status().Update(ctx, instance), "failed to update the Submariner status")
+}
+
+// recordFailure records a failed reconcile in the Submariner status so persistent failures are visible.
+func (r *Reconciler) recordFailure(ctx context.Context, key types.NamespacedName) {
+	failures := r.backoff.Failed(key)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		instance, err := r.getSubmariner(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if instance.Status.ReconcileFailures == failures {
+			return nil
+		}
+
+		instance.Status.ReconcileFailures = failures
+
+		return r.config.ScopedClient.Status().Update(ctx, instance)
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "Error recording the reconcile failure count", "failures", failures)
+	}
 }
 
 func getImagePath(submariner *submopv1a1.Submariner, imageName, componentName string) string {
@@ -315,15 +438,74 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
 		// Watch for changes to secondary resource DaemonSets and requeue the owner Submariner
 		Owns(&appsv1.DaemonSet{}).
 		Watches(&submv1.Gateway{}, handler.EnqueueRequestsFromMapFunc(mapFn)).
+		// Watch for local Endpoints recreated by the gateway without their custom metadata
+		Watches(&submv1.Endpoint{}, handler.EnqueueRequestsFromMapFunc(mapFn)).
+		// Watch for nodes being cordoned or uncordoned, to drain the gateways
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.submarinersForCordonedNode),
+			builder.WithPredicates(predicate.Funcs{
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return e.ObjectOld.(*corev1.Node).Spec.Unschedulable != e.ObjectNew.(*corev1.Node).Spec.Unschedulable
+				},
+				CreateFunc:  func(_ event.CreateEvent) bool { return false },
+				DeleteFunc:  func(_ event.DeleteEvent) bool { return false },
+				GenericFunc: func(_ event.GenericEvent) bool { return false },
+			})).
+		// Watch for nodes labeled or unlabeled as gateways, to withdraw the Endpoints of former gateways
+		Watches(&corev1.Node{}, handler.EnqueueRequestsFromMapFunc(r.submarinersForGatewayNode),
+			builder.WithPredicates(gatewayLabelChanged)).
+		// Watch for changes to the image digests referenced by the image policy
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.submarinersForDigestConfigMap)).
+		// Watch for rotated broker tokens, to restart the broker secret syncer with the new token
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.submarinersForBrokerSecret)).
 		Complete(r)
 }
 
-func (r *Reconciler) setupSecretSyncer(instance *submopv1a1.Submariner, logger logr.Logger, namespace string) error {
+func (r *Reconciler) submarinersForBrokerSecret(ctx context.Context, object client.Object) []reconcile.Request {
+	submariners := &submopv1a1.SubmarinerList{}
+
+	err := r.config.ScopedClient.List(ctx, submariners, client.InNamespace(object.GetNamespace()))
+	if err != nil {
+		log.Error(err, "Error listing Submariner resources")
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+
+	for i := range submariners.Items {
+		if submariners.Items[i].Spec.BrokerK8sSecret == object.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&submariners.Items[i])})
+		}
+	}
+
+	return requests
+}
+
+func (r *Reconciler) setupSecretSyncer(ctx context.Context, instance *submopv1a1.Submariner, logger logr.Logger,
+	namespace string,
+) error {
 	r.syncerMutex.Lock()
 	defer r.syncerMutex.Unlock()
 
-	if instance.Spec.BrokerK8sSecret != "" {
-		if _, ok := r.secretSyncCancelFuncs[instance.Spec.BrokerK8sSecret]; !ok {
+	// Credentials supplied by an external secret manager are refreshed by it, not synced from the broker
+	if instance.Spec.BrokerK8sSecret != "" && instance.Spec.BrokerK8sSecretProviderClass == "" {
+		key := secretSyncerKey{
+			secret:          types.NamespacedName{Namespace: namespace, Name: instance.Spec.BrokerK8sSecret},
+			brokerNamespace: instance.Spec.BrokerK8sRemoteNamespace,
+			caBundle:        instance.Spec.BrokerK8sCABundle,
+		}
+
+		token, err := r.brokerToken(ctx, key.secret, instance.Spec.BrokerK8sApiServerToken)
+		if err != nil {
+			return err
+		}
+
+		tokenHash := sha256.Sum256([]byte(token))
+		key.tokenHash = hex.EncodeToString(tokenHash[:])
+
+		if _, ok := r.secretSyncers[key]; !ok {
+			// The broker namespace, CA bundle or token may have changed, stop the previous syncer
+			r.cancelSecretSyncersFor(key.secret)
+
 			_, gvr, err := util.ToUnstructuredResource(&corev1.Secret{}, r.config.ScopedClient.RESTMapper())
 			if err != nil {
 				return errors.Wrap(err, "error calculating the GVR for the Secret type")
@@ -331,8 +513,8 @@ func (r *Reconciler) setupSecretSyncer(instance *submopv1a1.Submariner, logger l
 			// We can't use files here, we don't have a mounted secret
 			brokerConfig, _, err := resource.GetAuthorizedRestConfigFromData(
 				instance.Spec.BrokerK8sApiServer,
-				instance.Spec.BrokerK8sApiServerToken, // TODO Read the secret
-				instance.Spec.BrokerK8sCA,
+				token,
+				brokerCA(&instance.Spec),
 				&rest.TLSClientConfig{Insecure: instance.Spec.BrokerK8sInsecure},
 				*gvr,
 				instance.Spec.BrokerK8sRemoteNamespace)
@@ -345,6 +527,11 @@ func (r *Reconciler) setupSecretSyncer(instance *submopv1a1.Submariner, logger l
 				return errors.Wrap(err, "error building a dynamic client for the broker")
 			}
 
+			brokerDiscovery, err := discovery.NewDiscoveryClientForConfig(brokerConfig)
+			if err != nil {
+				return errors.Wrap(err, "error building a discovery client for the broker")
+			}
+
 			secretSyncer, err := syncer.NewResourceSyncer(
 				&syncer.ResourceSyncerConfig{
 					Name:            "Broker secret syncer",
@@ -356,17 +543,29 @@ func (r *Reconciler) setupSecretSyncer(instance *submopv1a1.Submariner, logger l
 					Scheme:          r.config.Scheme,
 					Federator: federate.NewCreateOrUpdateFederator(
 						r.config.DynClient, r.config.ScopedClient.RESTMapper(), namespace, ""),
-					Transform: func(from runtime.Object, _ int, _ syncer.Operation) (runtime.Object, bool) {
+					Transform: func(from runtime.Object, _ int, op syncer.Operation) (runtime.Object, bool) {
+						// Superseded tokens are deleted once the broker has rotated them, which mustn't remove the local copy
+						if op == syncer.Delete {
+							return nil, false
+						}
+
 						secret := from.(*corev1.Secret)
 						logger.V(level.TRACE).Info("Transforming secret", "secret", secret)
-						if saName, ok := secret.ObjectMeta.Annotations["kubernetes.io/service-account.name"]; ok &&
+
+						// Keep the current token until its replacement has been populated
+						if _, ok := secret.Annotations[tokenSupersededAnnotation]; ok ||
+							len(secret.Data[corev1.ServiceAccountTokenKey]) == 0 {
+							return nil, false
+						}
+
+						if saName, ok := secret.ObjectMeta.Annotations[corev1.ServiceAccountNameKey]; ok &&
 							saName == names.ForClusterSA(instance.Spec.ClusterID) {
 							transformedSecret := &corev1.Secret{
 								ObjectMeta: metav1.ObjectMeta{
 									Name: instance.Spec.BrokerK8sSecret,
 								},
 								Type: corev1.SecretTypeOpaque,
-								Data: secret.Data,
+								Data: withBrokerCABundle(secret.Data, &instance.Spec),
 							}
 							logger.V(level.TRACE).Info("Transformed secret", "transformedSecret", transformedSecret)
 							return transformedSecret, false
@@ -378,27 +577,55 @@ func (r *Reconciler) setupSecretSyncer(instance *submopv1a1.Submariner, logger l
 				return errors.Wrap(err, "error building a resource syncer for secrets")
 			}
 
-			ctx, cancelFunc := context.WithCancel(context.TODO())
-			if err := secretSyncer.Start(ctx.Done()); err != nil {
+			syncerCtx, cancelFunc := context.WithCancel(context.TODO())
+			if err := secretSyncer.Start(syncerCtx.Done()); err != nil {
 				cancelFunc()
 				return errors.Wrap(err, "error starting the secret syncer")
 			}
 
-			r.secretSyncCancelFuncs[instance.Spec.BrokerK8sSecret] = cancelFunc
+			r.secretSyncers[key] = &brokerSecretSyncer{cancel: cancelFunc, brokerClient: brokerDiscovery.RESTClient()}
 		}
 	}
 
 	return nil
 }
 
+// brokerToken returns the token the broker secret syncer authenticates with: the token synced from the broker, which
+// follows its rotations, or the token the cluster joined with until then.
+func (r *Reconciler) brokerToken(ctx context.Context, secretName types.NamespacedName, joinToken string) (string, error) {
+	secret := &corev1.Secret{}
+
+	err := r.config.ScopedClient.Get(ctx, secretName, secret)
+	if apierrors.IsNotFound(err) {
+		return joinToken, nil
+	}
+
+	if err != nil {
+		return "", errors.Wrapf(err, "error retrieving the broker secret %q", secretName.Name)
+	}
+
+	if token := secret.Data[corev1.ServiceAccountTokenKey]; len(token) > 0 {
+		return string(token), nil
+	}
+
+	return joinToken, nil
+}
+
 func (r *Reconciler) cancelSecretSyncer(instance *submopv1a1.Submariner) {
 	r.syncerMutex.Lock()
 	defer r.syncerMutex.Unlock()
 
 	if instance.Spec.BrokerK8sSecret != "" {
-		if cancelFunc, ok := r.secretSyncCancelFuncs[instance.Spec.BrokerK8sSecret]; ok {
-			cancelFunc()
-			delete(r.secretSyncCancelFuncs, instance.Spec.BrokerK8sSecret)
+		r.cancelSecretSyncersFor(types.NamespacedName{Namespace: instance.Namespace, Name: instance.Spec.BrokerK8sSecret})
+	}
+}
+
+// cancelSecretSyncersFor stops the syncers maintaining the given secret. The caller must hold the syncer mutex.
+func (r *Reconciler) cancelSecretSyncersFor(secret types.NamespacedName) {
+	for key, running := range r.secretSyncers {
+		if key.secret == secret {
+			running.cancel()
+			delete(r.secretSyncers, key)
 		}
 	}
 }
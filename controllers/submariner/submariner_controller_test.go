@@ -20,25 +20,40 @@ package submariner_test
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1config "github.com/openshift/api/config/v1"
 	"github.com/submariner-io/admiral/pkg/fake"
 	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/admiral/pkg/syncer/broker"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	submarinerController "github.com/submariner-io/submariner-operator/controllers/submariner"
 	"github.com/submariner-io/submariner-operator/controllers/test"
 	"github.com/submariner-io/submariner-operator/controllers/uninstall"
 	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	submarinerv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"github.com/submariner-io/submariner/pkg/cni"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeClient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
@@ -56,8 +71,11 @@ const (
 	testDetectedClusterCIDR   = "10.244.0.0/16"
 	testConfiguredServiceCIDR = "192.168.66.0/24"
 	testConfiguredClusterCIDR = "192.168.67.0/24"
+	gatewayDrainingLabel      = "submariner.io/gateway-draining"
 )
 
+var gatewayNodeLabels = map[string]string{"submariner.io/gateway": "true"}
+
 func testReconciliation() {
 	t := newTestDriver()
 
@@ -66,127 +84,938 @@ func testReconciliation() {
 		t.awaitFinalizer()
 	})
 
-	Context("", func() {
+	Context("", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.NatEnabled = true
+			t.submariner.Spec.AirGappedDeployment = true
+		})
+
+		It("should populate general Submariner resource Status fields from the Spec", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			Expect(updated.Status.NatEnabled).To(BeTrue())
+			Expect(updated.Status.AirGappedDeployment).To(BeTrue())
+			Expect(updated.Status.ClusterID).To(Equal(t.submariner.Spec.ClusterID))
+			Expect(updated.Status.GlobalCIDR).To(Equal(t.submariner.Spec.GlobalCIDR))
+			Expect(updated.Status.NetworkPlugin).To(Equal(t.clusterNetwork.NetworkPlugin))
+			Expect(updated.Status.Version).To(Equal(t.submariner.Spec.Version))
+		})
+	})
+
+	When("the network details are not provided", func() {
+		It("should use the detected network", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			Expect(updated.Status.ServiceCIDR).To(Equal(testDetectedServiceCIDR))
+			Expect(updated.Status.ClusterCIDR).To(Equal(testDetectedClusterCIDR))
+		})
+	})
+
+	When("the network details are provided", func() {
+		It("should use the provided ones instead of the detected ones", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			initial := t.getSubmariner(ctx)
+			initial.Spec.ServiceCIDR = testConfiguredServiceCIDR
+			initial.Spec.ClusterCIDR = testConfiguredClusterCIDR
+
+			Expect(t.ScopedClient.Update(ctx, initial)).To(Succeed())
+
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			Expect(updated.Status.ServiceCIDR).To(Equal(testConfiguredServiceCIDR))
+			Expect(updated.Status.ClusterCIDR).To(Equal(testConfiguredClusterCIDR))
+		})
+	})
+
+	When("the network is discovered", func() {
+		It("should persist it in the ClusterNetwork resource", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			clusterNetwork := &v1alpha1.ClusterNetwork{}
+			Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: opnames.ClusterNetworkCrName, Namespace: submarinerNamespace},
+				clusterNetwork)).To(Succeed())
+			Expect(clusterNetwork.Status.NetworkPlugin).To(Equal(t.clusterNetwork.NetworkPlugin))
+			Expect(clusterNetwork.Status.ServiceCIDRs).To(Equal(t.clusterNetwork.ServiceCIDRs))
+			Expect(clusterNetwork.Status.PodCIDRs).To(Equal(t.clusterNetwork.PodCIDRs))
+		})
+	})
+
+	When("a previously discovered network was persisted", func() {
+		BeforeEach(func() {
+			t.clusterNetwork = nil
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, &v1alpha1.ClusterNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      opnames.ClusterNetworkCrName,
+					Namespace: submarinerNamespace,
+				},
+				Status: v1alpha1.ClusterNetworkStatus{
+					NetworkPlugin: cni.OVNKubernetes,
+					ServiceCIDRs:  []string{testDetectedServiceCIDR},
+					PodCIDRs:      []string{testDetectedClusterCIDR},
+				},
+			})
+		})
+
+		It("should use it instead of discovering the network", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			Expect(updated.Status.NetworkPlugin).To(Equal(cni.OVNKubernetes))
+			Expect(updated.Status.ServiceCIDR).To(Equal(testDetectedServiceCIDR))
+			Expect(updated.Status.ClusterCIDR).To(Equal(testDetectedClusterCIDR))
+		})
+	})
+
+	When("the persisted network is to be discovered again", func() {
+		var persisted *v1alpha1.ClusterNetwork
+
+		BeforeEach(func() {
+			persisted = &v1alpha1.ClusterNetwork{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      opnames.ClusterNetworkCrName,
+					Namespace: submarinerNamespace,
+				},
+				Status: v1alpha1.ClusterNetworkStatus{
+					NetworkPlugin: cni.OVNKubernetes,
+					ServiceCIDRs:  []string{"10.10.0.0/16"},
+					PodCIDRs:      []string{"10.20.0.0/16"},
+					DiscoveryTime: metav1.Now(),
+				},
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, persisted)
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs,
+				newComponentPod("kube-apiserver", "--service-cluster-ip-range="+testDetectedServiceCIDR),
+				newComponentPod("kube-controller-manager", "--cluster-cidr="+testDetectedClusterCIDR))
+		})
+
+		assertRediscovered := func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			updated := t.getSubmariner(ctx)
+			Expect(updated.Status.NetworkPlugin).ToNot(Equal(cni.OVNKubernetes))
+			Expect(updated.Status.ServiceCIDR).To(Equal(testDetectedServiceCIDR))
+			Expect(updated.Status.ClusterCIDR).To(Equal(testDetectedClusterCIDR))
+
+			clusterNetwork := &v1alpha1.ClusterNetwork{}
+			Expect(t.ScopedClient.Get(ctx, client.ObjectKeyFromObject(persisted), clusterNetwork)).To(Succeed())
+			Expect(clusterNetwork.Annotations).ToNot(HaveKey(v1alpha1.RediscoverNetworkAnnotation))
+			Expect(clusterNetwork.Status.ServiceCIDRs).To(Equal([]string{testDetectedServiceCIDR}))
+			Expect(clusterNetwork.Status.DiscoveryTime.Time).To(BeTemporally("~", time.Now(), time.Minute))
+		}
+
+		Context("on request", func() {
+			BeforeEach(func() {
+				persisted.Annotations = map[string]string{v1alpha1.RediscoverNetworkAnnotation: ""}
+			})
+
+			It("should discover the network again", assertRediscovered)
+		})
+
+		Context("because it's outdated", func() {
+			BeforeEach(func() {
+				persisted.Status.DiscoveryTime = metav1.NewTime(time.Now().Add(-25 * time.Hour))
+			})
+
+			It("should discover the network again", assertRediscovered)
+		})
+	})
+
+	When("reconciliation is paused", func() {
+		BeforeEach(func() {
+			t.submariner.Annotations = map[string]string{v1alpha1.PausedAnnotation: "true"}
+		})
+
+		It("should not reconcile the managed resources and report it in the Status", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+			t.AssertNoDaemonSet(ctx, names.GatewayComponent)
+			Expect(t.getSubmariner(ctx).Status.Paused).To(BeTrue())
+		})
+
+		Context("and is then resumed", func() {
+			It("should reconcile the managed resources", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				submariner := t.getSubmariner(ctx)
+				delete(submariner.Annotations, v1alpha1.PausedAnnotation)
+				Expect(t.ScopedClient.Update(ctx, submariner)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+				t.assertGatewayDaemonSet(ctx)
+				Expect(t.getSubmariner(ctx).Status.Paused).To(BeFalse())
+			})
+		})
+	})
+
+	When("the active gateway changes", func() {
+		BeforeEach(func() {
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs,
+				newGateway("gw1", submarinerv1.HAStatusActive), newGateway("gw2", submarinerv1.HAStatusPassive))
+		})
+
+		It("should record the failover in the Status", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			failover := t.getSubmariner(ctx).Status.GatewayFailover
+			Expect(failover).ToNot(BeNil())
+			Expect(failover.ActiveGateway).To(Equal("gw1"))
+			Expect(failover.FailoverCount).To(BeZero())
+
+			t.updateGatewayHAStatus(ctx, "gw1", submarinerv1.HAStatusPassive, "health check failed")
+			t.updateGatewayHAStatus(ctx, "gw2", submarinerv1.HAStatusActive, "")
+
+			t.AssertReconcileSuccess(ctx)
+
+			failover = t.getSubmariner(ctx).Status.GatewayFailover
+			Expect(failover.ActiveGateway).To(Equal("gw2"))
+			Expect(failover.FailoverCount).To(Equal(int32(1)))
+			Expect(failover.LastFailoverTime).ToNot(BeNil())
+			Expect(failover.LastFailoverReason).To(ContainSubstring("health check failed"))
+		})
+	})
+
+	When("a connectivity SLO is configured", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.ConnectivitySLO = &v1alpha1.ConnectivitySLO{
+				SampleInterval: &metav1.Duration{Duration: time.Nanosecond},
+				Windows:        []metav1.Duration{{Duration: time.Hour}},
+			}
+
+			gateway := newGateway("gw1", submarinerv1.HAStatusActive)
+			gateway.Status.Connections = []submarinerv1.Connection{
+				newConnection("east", submarinerv1.Connected, "2ms"),
+				newConnection("west", submarinerv1.ConnectionError, ""),
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, gateway)
+		})
+
+		It("should record the availability of the connections in the Status", func(ctx SpecContext) {
+			t.assertReconcileSample(ctx)
+
+			t.updateGatewayConnection(ctx, "gw1", newConnection("west", submarinerv1.Connected, "4ms"))
+
+			t.assertReconcileSample(ctx)
+
+			Expect(t.getSubmariner(ctx).Status.ConnectionAvailability).To(Equal([]v1alpha1.ConnectionAvailability{
+				{
+					ClusterID:      "east",
+					Window:         metav1.Duration{Duration: time.Hour},
+					Availability:   "100.00",
+					AverageLatency: "2ms",
+					Samples:        2,
+				},
+				{
+					ClusterID:      "west",
+					Window:         metav1.Duration{Duration: time.Hour},
+					Availability:   "50.00",
+					AverageLatency: "4ms",
+					Samples:        2,
+				},
+			}))
+		})
+
+		Context("and subsequently removed", func() {
+			It("should remove the availability from the Status", func(ctx SpecContext) {
+				t.assertReconcileSample(ctx)
+				Expect(t.getSubmariner(ctx).Status.ConnectionAvailability).To(HaveLen(2))
+
+				submariner := t.getSubmariner(ctx)
+				submariner.Spec.ConnectivitySLO = nil
+				Expect(t.ScopedClient.Update(ctx, submariner)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+				Expect(t.getSubmariner(ctx).Status.ConnectionAvailability).To(BeEmpty())
+			})
+		})
+	})
+
+	When("gateway drain is enabled and the node of the active gateway is cordoned", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.GatewayDrain = &v1alpha1.GatewayDrain{}
+
+			standby := newGateway("gw2", submarinerv1.HAStatusPassive)
+			standby.Status.Connections = []submarinerv1.Connection{newConnection("east", submarinerv1.Connected, "")}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newGateway("gw1", submarinerv1.HAStatusActive), standby)
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs,
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gw1", Labels: gatewayNodeLabels}, Spec: corev1.NodeSpec{Unschedulable: true}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gw2", Labels: gatewayNodeLabels}})
+		})
+
+		It("should move the gateway off the node until a standby gateway takes over", func(ctx SpecContext) {
+			t.assertReconcileDrain(ctx, v1alpha1.GatewayDraining)
+			t.assertGatewayAvoidsDrainingNodes(ctx)
+			t.assertNodeDraining(ctx, "gw1", true)
+
+			t.updateGatewayHAStatus(ctx, "gw1", submarinerv1.HAStatusPassive, "")
+			t.updateGatewayHAStatus(ctx, "gw2", submarinerv1.HAStatusActive, "")
+
+			t.assertReconcileDrain(ctx, v1alpha1.GatewayDrained)
+			t.assertNodeDraining(ctx, "gw1", true)
+			t.assertNodeDraining(ctx, "gw2", false)
+		})
+
+		It("should not change the gateway pod template", func(ctx SpecContext) {
+			t.setNodeUnschedulable(ctx, "gw1", false)
+			t.AssertReconcileSuccess(ctx)
+
+			template := t.AssertDaemonSet(ctx, names.GatewayComponent).Spec.Template
+
+			t.setNodeUnschedulable(ctx, "gw1", true)
+			t.assertReconcileDrain(ctx, v1alpha1.GatewayDraining)
+			Expect(t.AssertDaemonSet(ctx, names.GatewayComponent).Spec.Template).To(Equal(template))
+
+			t.updateGatewayHAStatus(ctx, "gw1", submarinerv1.HAStatusPassive, "")
+			t.updateGatewayHAStatus(ctx, "gw2", submarinerv1.HAStatusActive, "")
+
+			t.assertReconcileDrain(ctx, v1alpha1.GatewayDrained)
+			Expect(t.AssertDaemonSet(ctx, names.GatewayComponent).Spec.Template).To(Equal(template))
+
+			t.setNodeUnschedulable(ctx, "gw1", false)
+			t.AssertReconcileSuccess(ctx)
+			Expect(t.AssertDaemonSet(ctx, names.GatewayComponent).Spec.Template).To(Equal(template))
+		})
+
+		Context("and the node is subsequently uncordoned", func() {
+			It("should schedule the gateway on the node again", func(ctx SpecContext) {
+				t.assertReconcileDrain(ctx, v1alpha1.GatewayDraining)
+
+				t.setNodeUnschedulable(ctx, "gw1", false)
+
+				t.AssertReconcileSuccess(ctx)
+				Expect(t.getSubmariner(ctx).Status.GatewayDrain).To(BeNil())
+				t.assertNodeDraining(ctx, "gw1", false)
+			})
+		})
+
+		Context("and the standby gateway doesn't take over in time", func() {
+			BeforeEach(func() {
+				t.submariner.Spec.GatewayDrain.Timeout = &metav1.Duration{Duration: time.Nanosecond}
+			})
+
+			It("should restore the gateway on the node", func(ctx SpecContext) {
+				t.assertReconcileDrain(ctx, v1alpha1.GatewayDraining)
+
+				t.AssertReconcileSuccess(ctx)
+				Expect(t.getSubmariner(ctx).Status.GatewayDrain.Phase).To(Equal(v1alpha1.GatewayDrainTimedOut))
+				t.assertNodeDraining(ctx, "gw1", false)
+			})
+		})
+
+		Context("and the gateway host names aren't the node names", func() {
+			BeforeEach(func() {
+				// The nodes are named by the cloud provider, and matched by their host name or internal IP address
+				addresses := map[string]corev1.NodeAddress{
+					"gw1": {Type: corev1.NodeInternalIP, Address: "10.0.0.1"},
+					"gw2": {Type: corev1.NodeHostName, Address: "gw2"},
+				}
+
+				for _, obj := range t.InitScopedClientObjs {
+					if gateway, ok := obj.(*submarinerv1.Gateway); ok && gateway.Name == "gw1" {
+						gateway.Status.LocalEndpoint.PrivateIP = "10.0.0.1"
+					}
+				}
+
+				for _, obj := range t.InitGeneralClientObjs {
+					if node, ok := obj.(*corev1.Node); ok && addresses[node.Name].Address != "" {
+						node.Status.Addresses = []corev1.NodeAddress{addresses[node.Name]}
+						node.Name += ".example.com"
+					}
+				}
+			})
+
+			It("should match the gateways with their nodes by address", func(ctx SpecContext) {
+				t.assertReconcileDrain(ctx, v1alpha1.GatewayDraining)
+				Expect(t.getSubmariner(ctx).Status.GatewayDrain.Node).To(Equal("gw1.example.com"))
+				t.assertNodeDraining(ctx, "gw1.example.com", true)
+			})
+		})
+	})
+
+	When("the submariner gateway DaemonSet doesn't exist", func() {
+		It("should create it", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+			t.assertGatewayDaemonSet(ctx)
+		})
+	})
+
+	When("the submariner gateway DaemonSet already exists", func() {
+		BeforeEach(func() {
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, t.NewDaemonSet(names.GatewayComponent))
+		})
+
+		It("should update it", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			initial := t.getSubmariner(ctx)
+			initial.Spec.ServiceCIDR = "101.96.1.0/16"
+			Expect(t.ScopedClient.Update(ctx, initial)).To(Succeed())
+
+			t.AssertReconcileSuccess(ctx)
+
+			updatedDaemonSet := t.AssertDaemonSet(ctx, names.GatewayComponent)
+			Expect(test.EnvMapFrom(updatedDaemonSet)).To(HaveKeyWithValue("SUBMARINER_SERVICECIDR", initial.Spec.ServiceCIDR))
+		})
+	})
+
+	When("the submariner route-agent DaemonSet doesn't exist", func() {
+		It("should create it", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+			t.assertRouteAgentDaemonSet(ctx)
+		})
+	})
+
+	When("the submariner route-agent DaemonSet already exists", func() {
+		BeforeEach(func() {
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, t.NewDaemonSet(names.RouteAgentComponent))
+		})
+
+		It("should update it", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			initial := t.getSubmariner(ctx)
+			initial.Spec.ClusterCIDR = "11.245.1.0/16"
+			Expect(t.ScopedClient.Update(ctx, initial)).To(Succeed())
+
+			t.AssertReconcileSuccess(ctx)
+
+			updatedDaemonSet := t.AssertDaemonSet(ctx, names.RouteAgentComponent)
+			Expect(test.EnvMapFrom(updatedDaemonSet)).To(HaveKeyWithValue("SUBMARINER_CLUSTERCIDR", initial.Spec.ClusterCIDR))
+		})
+
+		When("a selected pod has a nil Started field", func() {
+			BeforeEach(func() {
+				t.InitScopedClientObjs = append(t.InitScopedClientObjs, &corev1.Pod{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: t.submariner.Namespace,
+						Name:      names.RouteAgentComponent + "-pod",
+						Labels:    map[string]string{"app": names.RouteAgentComponent},
+					},
+					Spec: corev1.PodSpec{},
+					Status: corev1.PodStatus{
+						ContainerStatuses: []corev1.ContainerStatus{
+							{
+								State: corev1.ContainerState{
+									Waiting: &corev1.ContainerStateWaiting{},
+								},
+							},
+						},
+					},
+				})
+			})
+
+			It("should not crash", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+			})
+		})
+	})
+
+	When("a node is no longer labeled as a gateway", func() {
+		BeforeEach(func() {
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newGateway("gw1", submarinerv1.HAStatusActive),
+				newGateway("gw2", submarinerv1.HAStatusPassive),
+				newNodeEndpoint("local-gw1", t.submariner.Spec.ClusterID, "gw1"),
+				newNodeEndpoint("local-gw2", t.submariner.Spec.ClusterID, "gw2"),
+				newNodeEndpoint("remote-gw1", "west", "gw1"))
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs,
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gw1", Labels: gatewayNodeLabels}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gw2", Labels: gatewayNodeLabels}})
+		})
+
+		It("should withdraw its local Endpoint and Gateway and emit events", func(ctx SpecContext) {
+			t.assertGatewayNodeWithdrawn(ctx, "gw1")
+		})
+
+		Context("and the gateway host names aren't the node names", func() {
+			BeforeEach(func() {
+				// The nodes are named by the cloud provider, and matched by their host name address
+				for _, obj := range t.InitGeneralClientObjs {
+					if node, ok := obj.(*corev1.Node); ok && strings.HasPrefix(node.Name, "gw") {
+						node.Status.Addresses = []corev1.NodeAddress{{Type: corev1.NodeHostName, Address: node.Name}}
+						node.Name += ".example.com"
+					}
+				}
+			})
+
+			It("should withdraw its local Endpoint and Gateway", func(ctx SpecContext) {
+				t.assertGatewayNodeWithdrawn(ctx, "gw1.example.com")
+			})
+		})
+	})
+
+	When("custom Endpoint metadata is specified", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.EndpointMetadata = &v1alpha1.EndpointMetadata{
+				Labels:      map[string]string{"region": "eu-west", "submariner-io/clusterID": "other"},
+				Annotations: map[string]string{"example.com/cost-center": "1234"},
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs,
+				newEndpoint(t.submariner.Spec.ClusterID), newEndpoint("remote"))
+		})
+
+		It("should apply it to the local Endpoint only", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			endpoint := t.getEndpoint(ctx, t.submariner.Spec.ClusterID)
+			Expect(endpoint.Labels).To(HaveKeyWithValue("region", "eu-west"))
+			Expect(endpoint.Labels).ToNot(HaveKey("submariner-io/clusterID"))
+			Expect(endpoint.Annotations).To(HaveKeyWithValue("example.com/cost-center", "1234"))
+
+			endpoint = t.getEndpoint(ctx, "remote")
+			Expect(endpoint.Labels).ToNot(HaveKey("region"))
+			Expect(endpoint.Annotations).ToNot(HaveKey("example.com/cost-center"))
+		})
+
+		Context("and subsequently removed", func() {
+			It("should remove it from the local Endpoint", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				submariner := t.getSubmariner(ctx)
+				submariner.Spec.EndpointMetadata = nil
+				Expect(t.ScopedClient.Update(ctx, submariner)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+
+				endpoint := t.getEndpoint(ctx, t.submariner.Spec.ClusterID)
+				Expect(endpoint.Labels).To(BeEmpty())
+				Expect(endpoint.Annotations).To(BeEmpty())
+			})
+		})
+	})
+
+	When("cluster metadata is specified", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.ClusterMetadata = &v1alpha1.ClusterMetadata{
+				DisplayName: "Frankfurt production",
+				Region:      "eu-central-1",
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newEndpoint(t.submariner.Spec.ClusterID))
+		})
+
+		It("should attach it to the local Endpoint and report it in the status", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			endpoint := t.getEndpoint(ctx, t.submariner.Spec.ClusterID)
+			Expect(endpoint.Annotations).To(HaveKeyWithValue("submariner.io/cluster-display-name", "Frankfurt production"))
+			Expect(endpoint.Annotations).To(HaveKeyWithValue("submariner.io/cluster-region", "eu-central-1"))
+			Expect(endpoint.Annotations).ToNot(HaveKey("submariner.io/cluster-environment"))
+
+			Expect(t.getSubmariner(ctx).Status.ClusterMetadata).To(Equal(t.submariner.Spec.ClusterMetadata))
+		})
+
+		Context("and subsequently removed", func() {
+			It("should remove it from the local Endpoint", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				submariner := t.getSubmariner(ctx)
+				submariner.Spec.ClusterMetadata = nil
+				Expect(t.ScopedClient.Update(ctx, submariner)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+
+				Expect(t.getEndpoint(ctx, t.submariner.Spec.ClusterID).Annotations).To(BeEmpty())
+			})
+		})
+	})
+
+	When("candidate gateway addresses are specified", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.GatewayAddresses = []v1alpha1.GatewayAddress{
+				{Address: "10.1.0.5", Type: v1alpha1.GatewayAddressPrivate, Priority: 20},
+				{Address: "203.0.113.10", Type: v1alpha1.GatewayAddressPublic, Priority: 10},
+				{Address: "fd00::5", Type: v1alpha1.GatewayAddressSecondary, Priority: 20},
+				{Address: "198.51.100.7", Type: v1alpha1.GatewayAddressLoadBalancer},
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, newEndpoint(t.submariner.Spec.ClusterID))
+		})
+
+		It("should advertise them on the local Endpoint in order of priority", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			Expect(t.getEndpoint(ctx, t.submariner.Spec.ClusterID).Annotations).To(HaveKeyWithValue("submariner.io/gateway-addresses",
+				"LoadBalancer=198.51.100.7,Public=203.0.113.10,Private=10.1.0.5,Secondary=fd00::5"))
+		})
+
+		Context("and subsequently removed", func() {
+			It("should remove them from the local Endpoint", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				submariner := t.getSubmariner(ctx)
+				submariner.Spec.GatewayAddresses = nil
+				Expect(t.ScopedClient.Update(ctx, submariner)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+
+				Expect(t.getEndpoint(ctx, t.submariner.Spec.ClusterID).Annotations).To(BeEmpty())
+			})
+		})
+
+		Context("with an invalid address", func() {
+			BeforeEach(func() {
+				t.submariner.Spec.GatewayAddresses[1].Address = "gateway.example.com"
+			})
+
+			It("should fail", func(ctx SpecContext) {
+				t.AssertReconcileError(ctx)
+			})
+		})
+	})
+
+	When("images are overridden for an architecture", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.ArchitectureImageOverrides = map[string]map[string]string{
+				"arm64": {names.GatewayComponent: "registry.example.com/submariner-gateway:arm64"},
+			}
+
+			t.InitGeneralClientObjs = append(t.InitGeneralClientObjs,
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{corev1.LabelArchStable: "amd64"}}},
+				&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{corev1.LabelArchStable: "arm64"}}})
+		})
+
+		It("should deploy the component on that architecture using its own DaemonSet", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			armArchitecture := corev1.NodeSelectorRequirement{
+				Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: []string{"arm64"},
+			}
+
+			variant, err := t.GetDaemonSet(ctx, names.GatewayComponent+"-arm64")
+			Expect(err).To(Succeed())
+			Expect(variant.Spec.Template.Labels).To(HaveKeyWithValue("app", names.GatewayComponent))
+			Expect(variant.Spec.Template.Spec.Containers[0].Image).To(Equal("registry.example.com/submariner-gateway:arm64"))
+			Expect(variant.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(
+				ConsistOf(HaveField("MatchExpressions", ContainElement(armArchitecture))))
+
+			armArchitecture.Operator = corev1.NodeSelectorOpNotIn
+			daemonSet := t.AssertDaemonSet(ctx, names.GatewayComponent)
+			Expect(daemonSet.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(
+				ConsistOf(HaveField("MatchExpressions", ContainElement(armArchitecture))))
+
+			t.AssertNoDaemonSet(ctx, names.RouteAgentComponent+"-arm64")
+			Expect(t.getSubmariner(ctx).Status.NodeArchitectures).To(Equal([]string{"amd64", "arm64"}))
+		})
+
+		Context("and the override is subsequently removed", func() {
+			It("should delete the architecture's DaemonSet", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+				_, err := t.GetDaemonSet(ctx, names.GatewayComponent+"-arm64")
+				Expect(err).To(Succeed())
+
+				t.submariner = t.getSubmariner(ctx)
+				t.submariner.Spec.ArchitectureImageOverrides = nil
+				Expect(t.ScopedClient.Update(ctx, t.submariner)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+				t.AssertNoDaemonSet(ctx, names.GatewayComponent+"-arm64")
+			})
+		})
+	})
+
+	When("an additional component is configured", func() {
+		var component *fakeComponent
+
+		BeforeEach(func() {
+			component = &fakeComponent{enabled: true}
+			t.components = []submarinerController.Component{component}
+		})
+
+		It("should reconcile it and record its status", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			Expect(component.reconciled).To(BeTrue())
+			Expect(component.cleanedUp).To(BeFalse())
+			Expect(t.getSubmariner(ctx).Status.ClusterID).To(Equal(component.observedClusterID))
+		})
+
+		Context("and it's disabled", func() {
+			BeforeEach(func() {
+				component.enabled = false
+			})
+
+			It("should clean it up", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				Expect(component.reconciled).To(BeFalse())
+				Expect(component.cleanedUp).To(BeTrue())
+			})
+		})
+
+		Context("and its configuration is invalid", func() {
+			BeforeEach(func() {
+				component.invalid = errors.NewBadRequest("bogus")
+			})
+
+			It("should not deploy any component", func(ctx SpecContext) {
+				t.AssertReconcileError(ctx)
+
+				Expect(component.reconciled).To(BeFalse())
+				t.AssertNoDaemonSet(ctx, names.GatewayComponent)
+			})
+		})
+	})
+
+	When("component update strategies are configured", func() {
+		onDelete := appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+		recreate := appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+
+		BeforeEach(func() {
+			t.submariner.Spec.ServiceDiscoveryEnabled = true
+			t.submariner.Spec.UpdateStrategies = map[string]v1alpha1.ComponentUpdateStrategy{
+				names.RouteAgentComponent:        {DaemonSet: &onDelete},
+				names.LighthouseCoreDNSComponent: {Deployment: &recreate},
+			}
+		})
+
+		It("should apply them to the components", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			Expect(t.AssertDaemonSet(ctx, names.RouteAgentComponent).Spec.UpdateStrategy).To(Equal(onDelete))
+			Expect(t.AssertDaemonSet(ctx, names.GatewayComponent).Spec.UpdateStrategy.Type).To(
+				Equal(appsv1.RollingUpdateDaemonSetStrategyType))
+
+			serviceDiscovery := &v1alpha1.ServiceDiscovery{}
+			Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: opnames.ServiceDiscoveryCrName, Namespace: submarinerNamespace},
+				serviceDiscovery)).To(Succeed())
+			Expect(serviceDiscovery.Spec.UpdateStrategies).To(Equal(t.submariner.Spec.UpdateStrategies))
+		})
+	})
+
+	When("eviction protection is enabled", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.EvictionProtection = &v1alpha1.EvictionProtection{}
+		})
+
+		It("should protect the gateways", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			daemonSet := t.AssertDaemonSet(ctx, names.GatewayComponent)
+			Expect(daemonSet.Spec.Template.Annotations).To(HaveKeyWithValue("cluster-autoscaler.kubernetes.io/safe-to-evict", "false"))
+
+			pdb := t.assertPodDisruptionBudget(ctx, names.GatewayComponent)
+			Expect(pdb.Spec.MinAvailable.IntValue()).To(Equal(1))
+			Expect(pdb.Spec.Selector.MatchLabels).To(Equal(daemonSet.Spec.Selector.MatchLabels))
+
+			Expect(t.AssertDaemonSet(ctx, names.RouteAgentComponent).Spec.Template.Annotations).To(BeEmpty())
+			t.assertNoPodDisruptionBudget(ctx, names.RouteAgentComponent)
+		})
+
+		Context("for the route agents", func() {
+			BeforeEach(func() {
+				t.submariner.Spec.EvictionProtection.RouteAgents = true
+			})
+
+			It("should also protect the route agents", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				daemonSet := t.AssertDaemonSet(ctx, names.RouteAgentComponent)
+				Expect(daemonSet.Spec.Template.Annotations).To(HaveKeyWithValue("cluster-autoscaler.kubernetes.io/safe-to-evict", "false"))
+
+				pdb := t.assertPodDisruptionBudget(ctx, names.RouteAgentComponent)
+				Expect(pdb.Spec.MaxUnavailable.IntValue()).To(Equal(1))
+			})
+		})
+
+		Context("and subsequently disabled", func() {
+			It("should remove the PodDisruptionBudgets", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+				t.assertPodDisruptionBudget(ctx, names.GatewayComponent)
+
+				t.submariner = t.getSubmariner(ctx)
+				t.submariner.Spec.EvictionProtection = nil
+				Expect(t.ScopedClient.Update(ctx, t.submariner)).To(Succeed())
+
+				t.AssertReconcileSuccess(ctx)
+				t.assertNoPodDisruptionBudget(ctx, names.GatewayComponent)
+			})
+		})
+	})
+
+	When("metrics proxy authentication is configured", func() {
 		BeforeEach(func() {
-			t.submariner.Spec.NatEnabled = true
-			t.submariner.Spec.AirGappedDeployment = true
+			t.submariner.Spec.MetricsProxyAuth = &v1alpha1.MetricsProxyAuth{
+				TLSSecret:          "metrics-tls",
+				ClientCertificates: true,
+			}
 		})
 
-		It("should populate general Submariner resource Status fields from the Spec", func(ctx SpecContext) {
+		It("should secure the metrics proxy DaemonSet", func(ctx SpecContext) {
 			t.AssertReconcileSuccess(ctx)
 
-			updated := t.getSubmariner(ctx)
-			Expect(updated.Status.NatEnabled).To(BeTrue())
-			Expect(updated.Status.AirGappedDeployment).To(BeTrue())
-			Expect(updated.Status.ClusterID).To(Equal(t.submariner.Spec.ClusterID))
-			Expect(updated.Status.GlobalCIDR).To(Equal(t.submariner.Spec.GlobalCIDR))
-			Expect(updated.Status.NetworkPlugin).To(Equal(t.clusterNetwork.NetworkPlugin))
-			Expect(updated.Status.Version).To(Equal(t.submariner.Spec.Version))
+			daemonSet := t.AssertDaemonSet(ctx, names.MetricsProxyComponent)
+			podSpec := &daemonSet.Spec.Template.Spec
+			Expect(podSpec.ServiceAccountName).To(Equal(names.MetricsProxyComponent))
+			Expect(podSpec.Volumes).To(ContainElement(HaveField("Secret.SecretName", "metrics-tls")))
+			Expect(podSpec.Containers[0].Image).To(Equal(opnames.KubeRBACProxyImage))
+			Expect(podSpec.Containers[0].Args).To(ConsistOf(
+				"--secure-listen-address=0.0.0.0:8080",
+				"--upstream=http://$(NODE_IP):32780/",
+				"--tls-cert-file=/etc/metrics-proxy/tls/tls.crt",
+				"--tls-private-key-file=/etc/metrics-proxy/tls/tls.key",
+				"--client-ca-file=/etc/metrics-proxy/tls/ca.crt"))
 		})
-	})
 
-	When("the network details are not provided", func() {
-		It("should use the detected network", func(ctx SpecContext) {
-			t.AssertReconcileSuccess(ctx)
+		Context("with client certificates but no TLS secret", func() {
+			BeforeEach(func() {
+				t.submariner.Spec.MetricsProxyAuth.TLSSecret = ""
+			})
 
-			updated := t.getSubmariner(ctx)
-			Expect(updated.Status.ServiceCIDR).To(Equal(testDetectedServiceCIDR))
-			Expect(updated.Status.ClusterCIDR).To(Equal(testDetectedClusterCIDR))
+			It("should fail", func(ctx SpecContext) {
+				t.AssertReconcileError(ctx)
+			})
 		})
 	})
 
-	When("the network details are provided", func() {
-		It("should use the provided ones instead of the detected ones", func(ctx SpecContext) {
-			t.AssertReconcileSuccess(ctx)
-
-			initial := t.getSubmariner(ctx)
-			initial.Spec.ServiceCIDR = testConfiguredServiceCIDR
-			initial.Spec.ClusterCIDR = testConfiguredClusterCIDR
-
-			Expect(t.ScopedClient.Update(ctx, initial)).To(Succeed())
+	When("the broker credentials are supplied by a SecretProviderClass", func() {
+		BeforeEach(func() {
+			t.submariner.Spec.BrokerK8sSecret = "broker-secret"
+			t.submariner.Spec.BrokerK8sSecretProviderClass = "broker-credentials"
+			t.submariner.Spec.ServiceDiscoveryEnabled = true
+		})
 
+		It("should mount them from the Secrets Store CSI driver", func(ctx SpecContext) {
 			t.AssertReconcileSuccess(ctx)
 
-			updated := t.getSubmariner(ctx)
-			Expect(updated.Status.ServiceCIDR).To(Equal(testConfiguredServiceCIDR))
-			Expect(updated.Status.ClusterCIDR).To(Equal(testConfiguredClusterCIDR))
+			daemonSet := t.AssertDaemonSet(ctx, names.GatewayComponent)
+			Expect(daemonSet.Spec.Template.Spec.Volumes).To(ContainElement(SatisfyAll(
+				HaveField("Name", "brokersecret"),
+				HaveField("CSI.Driver", "secrets-store.csi.k8s.io"),
+				HaveField("CSI.VolumeAttributes", HaveKeyWithValue("secretProviderClass", "broker-credentials")))))
+
+			serviceDiscovery := &v1alpha1.ServiceDiscovery{}
+			Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: opnames.ServiceDiscoveryCrName, Namespace: submarinerNamespace},
+				serviceDiscovery)).To(Succeed())
+			Expect(serviceDiscovery.Spec.BrokerK8sSecret).To(Equal("broker-secret"))
+			Expect(serviceDiscovery.Spec.BrokerK8sSecretProviderClass).To(Equal("broker-credentials"))
 		})
-	})
 
-	When("the submariner gateway DaemonSet doesn't exist", func() {
-		It("should create it", func(ctx SpecContext) {
+		It("should not check the connection to the broker", func(ctx SpecContext) {
 			t.AssertReconcileSuccess(ctx)
-			t.assertGatewayDaemonSet(ctx)
+			Expect(t.brokerConnectionCheck(ctx)).To(Succeed())
 		})
 	})
 
-	When("the submariner gateway DaemonSet already exists", func() {
+	When("the broker token has been rotated", func() {
+		var (
+			brokerAPIServer *httptest.Server
+			bearerTokens    chan string
+			brokerRevoked   *atomic.Bool
+		)
+
 		BeforeEach(func() {
-			t.InitScopedClientObjs = append(t.InitScopedClientObjs, t.NewDaemonSet(names.GatewayComponent))
+			bearerTokens = make(chan string, 100)
+			brokerRevoked = &atomic.Bool{}
+
+			brokerAPIServer = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				bearerTokens <- strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+				w.Header().Set("Content-Type", "application/json")
+
+				switch {
+				case brokerRevoked.Load():
+					w.WriteHeader(http.StatusUnauthorized)
+					_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"Unauthorized","code":401}`))
+				case r.URL.Path == "/version":
+					_, _ = w.Write([]byte(`{"major":"1","minor":"29"}`))
+				case r.URL.Query().Get("watch") == "true":
+					w.WriteHeader(http.StatusOK)
+					w.(http.Flusher).Flush()
+					<-r.Context().Done()
+				case strings.HasSuffix(r.URL.Path, "/secrets"):
+					_, _ = w.Write([]byte(`{"kind":"SecretList","apiVersion":"v1","metadata":{"resourceVersion":"1"},"items":[]}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+					_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Failure","reason":"NotFound","code":404}`))
+				}
+			}))
+
+			t.submariner.Spec.BrokerK8sApiServer = strings.TrimPrefix(brokerAPIServer.URL, "https://")
+			t.submariner.Spec.BrokerK8sInsecure = true
+			t.submariner.Spec.BrokerK8sSecret = "broker-secret"
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "broker-secret", Namespace: submarinerNamespace},
+				Data:       map[string][]byte{corev1.ServiceAccountTokenKey: []byte("rotated-token")},
+			})
+
+			// The secret syncer resolves the Secret resource through the REST mapper
+			restMapper := meta.NewDefaultRESTMapper(nil)
+			restMapper.Add(corev1.SchemeGroupVersion.WithKind("Secret"), meta.RESTScopeNamespace)
+
+			t.ScopedClient = fakeClient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(t.InitScopedClientObjs...).
+				WithStatusSubresource(&v1alpha1.Submariner{}).WithRESTMapper(restMapper).Build()
 		})
 
-		It("should update it", func(ctx SpecContext) {
-			t.AssertReconcileSuccess(ctx)
+		AfterEach(func(ctx SpecContext) {
+			// Deleting the Submariner resource stops the broker secret syncer
+			Expect(t.ScopedClient.Delete(ctx, t.getSubmariner(ctx))).To(Succeed())
+			_, _ = t.Controller.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{
+				Name: submarinerName, Namespace: submarinerNamespace,
+			}})
 
-			initial := t.getSubmariner(ctx)
-			initial.Spec.ServiceCIDR = "101.96.1.0/16"
-			Expect(t.ScopedClient.Update(ctx, initial)).To(Succeed())
+			brokerAPIServer.CloseClientConnections()
+			brokerAPIServer.Close()
+		})
 
+		It("should sync the broker secret with the rotated token", func(ctx SpecContext) {
 			t.AssertReconcileSuccess(ctx)
 
-			updatedDaemonSet := t.AssertDaemonSet(ctx, names.GatewayComponent)
-			Expect(test.EnvMapFrom(updatedDaemonSet)).To(HaveKeyWithValue("SUBMARINER_SERVICECIDR", initial.Spec.ServiceCIDR))
+			Expect(bearerTokens).ToNot(BeEmpty())
+
+			for len(bearerTokens) > 0 {
+				Expect(<-bearerTokens).To(Equal("rotated-token"))
+			}
 		})
-	})
 
-	When("the submariner route-agent DaemonSet doesn't exist", func() {
-		It("should create it", func(ctx SpecContext) {
+		It("should report the operator as ready", func(ctx SpecContext) {
 			t.AssertReconcileSuccess(ctx)
-			t.assertRouteAgentDaemonSet(ctx)
+			Expect(t.brokerConnectionCheck(ctx)).To(Succeed())
+		})
+
+		Context("and the broker revokes it", func() {
+			It("should report the operator as not ready", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				brokerRevoked.Store(true)
+				Expect(t.brokerConnectionCheck(ctx)).ToNot(Succeed())
+			})
 		})
 	})
 
-	When("the submariner route-agent DaemonSet already exists", func() {
+	When("a broker CA bundle is configured", func() {
 		BeforeEach(func() {
-			t.InitScopedClientObjs = append(t.InitScopedClientObjs, t.NewDaemonSet(names.RouteAgentComponent))
+			t.submariner.Spec.BrokerK8sCABundle = testBrokerCABundle
+			t.submariner.Spec.ServiceDiscoveryEnabled = true
 		})
 
-		It("should update it", func(ctx SpecContext) {
+		It("should be used by the components to connect to the broker", func(ctx SpecContext) {
 			t.AssertReconcileSuccess(ctx)
 
-			initial := t.getSubmariner(ctx)
-			initial.Spec.ClusterCIDR = "11.245.1.0/16"
-			Expect(t.ScopedClient.Update(ctx, initial)).To(Succeed())
+			expectedCA := base64.StdEncoding.EncodeToString([]byte(testBrokerCABundle))
 
-			t.AssertReconcileSuccess(ctx)
+			daemonSet := t.AssertDaemonSet(ctx, names.GatewayComponent)
+			Expect(test.EnvMapFrom(daemonSet)).To(HaveKeyWithValue(broker.EnvironmentVariable("CA"), expectedCA))
 
-			updatedDaemonSet := t.AssertDaemonSet(ctx, names.RouteAgentComponent)
-			Expect(test.EnvMapFrom(updatedDaemonSet)).To(HaveKeyWithValue("SUBMARINER_CLUSTERCIDR", initial.Spec.ClusterCIDR))
+			serviceDiscovery := &v1alpha1.ServiceDiscovery{}
+			Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: opnames.ServiceDiscoveryCrName, Namespace: submarinerNamespace},
+				serviceDiscovery)).To(Succeed())
+			Expect(serviceDiscovery.Spec.BrokerK8sCA).To(Equal(expectedCA))
 		})
 
-		When("a selected pod has a nil Started field", func() {
+		Context("without any certificate", func() {
 			BeforeEach(func() {
-				t.InitScopedClientObjs = append(t.InitScopedClientObjs, &corev1.Pod{
-					ObjectMeta: metav1.ObjectMeta{
-						Namespace: t.submariner.Namespace,
-						Name:      names.RouteAgentComponent + "-pod",
-						Labels:    map[string]string{"app": names.RouteAgentComponent},
-					},
-					Spec: corev1.PodSpec{},
-					Status: corev1.PodStatus{
-						ContainerStatuses: []corev1.ContainerStatus{
-							{
-								State: corev1.ContainerState{
-									Waiting: &corev1.ContainerStateWaiting{},
-								},
-							},
-						},
-					},
-				})
+				t.submariner.Spec.BrokerK8sCABundle = "not a certificate"
 			})
 
-			It("should not crash", func(ctx SpecContext) {
-				t.AssertReconcileSuccess(ctx)
+			It("should fail", func(ctx SpecContext) {
+				t.AssertReconcileError(ctx)
 			})
 		})
 	})
@@ -223,6 +1052,98 @@ func testReconciliation() {
 		})
 	})
 
+	When("node placement defaults are specified", func() {
+		infraToleration := corev1.Toleration{Key: "node-role.kubernetes.io/infra", Operator: corev1.TolerationOpExists}
+
+		BeforeEach(func() {
+			t.submariner.Spec.ServiceDiscoveryEnabled = true
+			t.submariner.Spec.NodeSelector = map[string]string{"zone": "east"}
+			t.submariner.Spec.NodePlacement = &v1alpha1.NodePlacement{
+				NodeSelector: map[string]string{"node-role.kubernetes.io/infra": "", "zone": "west"},
+				Tolerations:  []corev1.Toleration{infraToleration},
+			}
+		})
+
+		It("should apply them to the components which don't run on every node", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			for _, component := range []string{names.GatewayComponent, names.GlobalnetComponent, names.MetricsProxyComponent} {
+				podSpec := t.AssertDaemonSet(ctx, component).Spec.Template.Spec
+				Expect(podSpec.NodeSelector).To(Equal(map[string]string{
+					"submariner.io/gateway":         "true",
+					"node-role.kubernetes.io/infra": "",
+					"zone":                          "west",
+				}))
+				Expect(podSpec.Tolerations).To(ContainElement(infraToleration))
+			}
+
+			Expect(t.AssertDaemonSet(ctx, names.RouteAgentComponent).Spec.Template.Spec.NodeSelector).To(BeEmpty())
+
+			serviceDiscovery := &v1alpha1.ServiceDiscovery{}
+			Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: opnames.ServiceDiscoveryCrName, Namespace: submarinerNamespace},
+				serviceDiscovery)).To(Succeed())
+			Expect(serviceDiscovery.Spec.NodeSelector).To(Equal(map[string]string{"node-role.kubernetes.io/infra": "", "zone": "east"}))
+			Expect(serviceDiscovery.Spec.Tolerations).To(Equal([]corev1.Toleration{infraToleration}))
+		})
+	})
+
+	When("the image policy pins digests", func() {
+		const digest = "sha256:1234"
+
+		BeforeEach(func() {
+			t.submariner.Spec.ImagePolicy = &v1alpha1.ImagePolicy{
+				Mode:            v1alpha1.ImagePolicyDigest,
+				DigestConfigMap: "image-digests",
+			}
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "image-digests",
+					Namespace: submarinerNamespace,
+				},
+				Data: map[string]string{
+					names.GatewayComponent:    digest,
+					names.RouteAgentComponent: digest,
+				},
+			})
+		})
+
+		It("should pin the component images", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+
+			daemonSet := t.AssertDaemonSet(ctx, names.GatewayComponent)
+			Expect(daemonSet.Spec.Template.Spec.Containers[0].Image).To(
+				Equal(fmt.Sprintf("%s/%s@%s", t.submariner.Spec.Repository, opnames.GatewayImage, digest)))
+
+			daemonSet = t.AssertDaemonSet(ctx, names.RouteAgentComponent)
+			Expect(daemonSet.Spec.Template.Spec.Containers[0].Image).To(
+				Equal(fmt.Sprintf("%s/%s@%s", t.submariner.Spec.Repository, opnames.RouteAgentImage, digest)))
+		})
+
+		Context("and an image override is specified", func() {
+			BeforeEach(func() {
+				t.submariner.Spec.ImageOverrides = map[string]string{names.GatewayComponent: "overridden/gateway:test"}
+			})
+
+			It("should use the image override", func(ctx SpecContext) {
+				t.AssertReconcileSuccess(ctx)
+
+				daemonSet := t.AssertDaemonSet(ctx, names.GatewayComponent)
+				Expect(daemonSet.Spec.Template.Spec.Containers[0].Image).To(Equal("overridden/gateway:test"))
+			})
+		})
+
+		Context("and the digest ConfigMap doesn't exist", func() {
+			BeforeEach(func() {
+				t.submariner.Spec.ImagePolicy.DigestConfigMap = "missing"
+			})
+
+			It("should return an error", func(ctx SpecContext) {
+				t.AssertReconcileError(ctx)
+			})
+		})
+	})
+
 	When("load balancer is enabled", func() {
 		BeforeEach(func() {
 			t.submariner.Spec.LoadBalancerEnabled = true
@@ -298,6 +1219,14 @@ func testReconciliation() {
 			_, err := t.DoReconcile(ctx)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("should record the consecutive failures in the Submariner resource Status", func(ctx SpecContext) {
+			t.AssertReconcileError(ctx)
+			Expect(t.getSubmariner(ctx).Status.ReconcileFailures).To(Equal(int32(1)))
+
+			t.AssertReconcileError(ctx)
+			Expect(t.getSubmariner(ctx).Status.ReconcileFailures).To(Equal(int32(2)))
+		})
 	})
 
 	When("DaemonSet retrieval fails", func() {
@@ -371,6 +1300,18 @@ func restoreOrUnsetEnv(envVar string, wasSet bool, value string) {
 	}
 }
 
+func (t *testDriver) assertPodDisruptionBudget(ctx context.Context, name string) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{}
+	Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: name, Namespace: submarinerNamespace}, pdb)).To(Succeed())
+
+	return pdb
+}
+
+func (t *testDriver) assertNoPodDisruptionBudget(ctx context.Context, name string) {
+	err := t.ScopedClient.Get(ctx, types.NamespacedName{Name: name, Namespace: submarinerNamespace}, &policyv1.PodDisruptionBudget{})
+	Expect(errors.IsNotFound(err)).To(BeTrue(), "Unexpected error: %v", err)
+}
+
 func (t *testDriver) assertLoadBalancerService(ctx context.Context) *corev1.Service {
 	service := &corev1.Service{}
 	err := t.ScopedClient.Get(ctx, types.NamespacedName{Name: "submariner-gateway", Namespace: submarinerNamespace},
@@ -477,6 +1418,32 @@ func testDeletion() {
 		})
 	})
 
+	Context("and reconciliation is paused", func() {
+		BeforeEach(func() {
+			t.submariner.Annotations = map[string]string{v1alpha1.PausedAnnotation: "true"}
+			t.submariner.Spec.GlobalCIDR = ""
+			t.submariner.Spec.Version = "devel"
+
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs,
+				t.NewDaemonSet(names.GatewayComponent),
+				t.NewDaemonSet(names.RouteAgentComponent))
+		})
+
+		It("should still uninstall the components and remove the finalizer", func(ctx SpecContext) {
+			t.AssertReconcileRequeue(ctx)
+
+			t.AssertNoDaemonSet(ctx, names.GatewayComponent)
+			t.AssertNoDaemonSet(ctx, names.RouteAgentComponent)
+
+			t.UpdateDaemonSetToReady(ctx, t.assertUninstallGatewayDaemonSet(ctx))
+			t.UpdateDaemonSetToReady(ctx, t.assertUninstallRouteAgentDaemonSet(ctx))
+
+			t.AssertReconcileSuccess(ctx)
+
+			t.awaitSubmarinerDeleted()
+		})
+	})
+
 	Context("and an uninstall DaemonSet does not complete in time", func() {
 		BeforeEach(func() {
 			t.submariner.Spec.GlobalCIDR = ""
@@ -589,3 +1556,208 @@ func newInfrastructureCluster(platformType v1config.PlatformType) *v1config.Infr
 		},
 	}
 }
+
+func newGateway(hostname string, haStatus submarinerv1.HAStatus) *submarinerv1.Gateway {
+	return &submarinerv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hostname,
+			Namespace: submarinerNamespace,
+		},
+		Status: submarinerv1.GatewayStatus{
+			HAStatus: haStatus,
+			LocalEndpoint: submarinerv1.EndpointSpec{
+				Hostname: hostname,
+			},
+		},
+	}
+}
+
+func (t *testDriver) getEndpoint(ctx context.Context, name string) *submarinerv1.Endpoint {
+	endpoint := &submarinerv1.Endpoint{}
+	Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: name, Namespace: submarinerNamespace}, endpoint)).To(Succeed())
+
+	return endpoint
+}
+
+func newNodeEndpoint(name, clusterID, hostname string) *submarinerv1.Endpoint {
+	return &submarinerv1.Endpoint{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: submarinerNamespace,
+		},
+		Spec: submarinerv1.EndpointSpec{
+			ClusterID: clusterID,
+			Hostname:  hostname,
+		},
+	}
+}
+
+func newConnection(clusterID string, status submarinerv1.ConnectionStatus, latency string) submarinerv1.Connection {
+	connection := submarinerv1.Connection{
+		Status: status,
+		Endpoint: submarinerv1.EndpointSpec{
+			ClusterID: clusterID,
+		},
+	}
+
+	if latency != "" {
+		connection.LatencyRTT = &submarinerv1.LatencyRTTSpec{Last: latency}
+	}
+
+	return connection
+}
+
+func (t *testDriver) assertReconcileSample(ctx context.Context) {
+	r, err := t.DoReconcile(ctx)
+	Expect(err).To(Succeed())
+	Expect(r.RequeueAfter).To(BeNumerically(">", 0))
+}
+
+func (t *testDriver) assertReconcileDrain(ctx context.Context, phase v1alpha1.GatewayDrainPhase) {
+	r, err := t.DoReconcile(ctx)
+	Expect(err).To(Succeed())
+
+	drain := t.getSubmariner(ctx).Status.GatewayDrain
+	Expect(drain).ToNot(BeNil())
+	Expect(drain.Phase).To(Equal(phase))
+
+	if phase == v1alpha1.GatewayDraining {
+		Expect(r.RequeueAfter).To(BeNumerically(">", 0))
+	}
+}
+
+func (t *testDriver) assertGatewayAvoidsDrainingNodes(ctx context.Context) {
+	daemonSet := t.AssertDaemonSet(ctx, names.GatewayComponent)
+	Expect(daemonSet.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms).To(
+		ConsistOf(HaveField("MatchExpressions", ContainElement(corev1.NodeSelectorRequirement{
+			Key: gatewayDrainingLabel, Operator: corev1.NodeSelectorOpNotIn, Values: []string{"true"},
+		}))))
+}
+
+func (t *testDriver) assertGatewayNodeWithdrawn(ctx context.Context, nodeName string) {
+	t.AssertReconcileSuccess(ctx)
+	Expect(t.events.Events).To(BeEmpty())
+
+	node := &corev1.Node{}
+	Expect(t.GeneralClient.Get(ctx, types.NamespacedName{Name: nodeName}, node)).To(Succeed())
+	delete(node.Labels, "submariner.io/gateway")
+	Expect(t.GeneralClient.Update(ctx, node)).To(Succeed())
+
+	t.AssertReconcileSuccess(ctx)
+
+	err := t.ScopedClient.Get(ctx, types.NamespacedName{Name: "local-gw1", Namespace: submarinerNamespace},
+		&submarinerv1.Endpoint{})
+	Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	err = t.ScopedClient.Get(ctx, types.NamespacedName{Name: "gw1", Namespace: submarinerNamespace},
+		&submarinerv1.Gateway{})
+	Expect(errors.IsNotFound(err)).To(BeTrue())
+
+	Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: "local-gw2", Namespace: submarinerNamespace},
+		&submarinerv1.Endpoint{})).To(Succeed())
+	Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: "remote-gw1", Namespace: submarinerNamespace},
+		&submarinerv1.Endpoint{})).To(Succeed())
+
+	Expect(t.events.Events).To(Receive(ContainSubstring("GatewayNodeRemoved")))
+	Expect(t.events.Events).To(Receive(ContainSubstring("GatewayEndpointWithdrawn")))
+}
+
+func (t *testDriver) assertNodeDraining(ctx context.Context, name string, draining bool) {
+	node := &corev1.Node{}
+	Expect(t.GeneralClient.Get(ctx, types.NamespacedName{Name: name}, node)).To(Succeed())
+	Expect(node.Labels[gatewayDrainingLabel] == "true").To(Equal(draining))
+}
+
+func (t *testDriver) setNodeUnschedulable(ctx context.Context, name string, unschedulable bool) {
+	node := &corev1.Node{}
+	Expect(t.GeneralClient.Get(ctx, types.NamespacedName{Name: name}, node)).To(Succeed())
+	node.Spec.Unschedulable = unschedulable
+	Expect(t.GeneralClient.Update(ctx, node)).To(Succeed())
+}
+
+func (t *testDriver) updateGatewayConnection(ctx context.Context, name string, connection submarinerv1.Connection) {
+	gateway := &submarinerv1.Gateway{}
+	Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: name, Namespace: submarinerNamespace}, gateway)).To(Succeed())
+
+	for i := range gateway.Status.Connections {
+		if gateway.Status.Connections[i].Endpoint.ClusterID == connection.Endpoint.ClusterID {
+			gateway.Status.Connections[i] = connection
+		}
+	}
+
+	Expect(t.ScopedClient.Update(ctx, gateway)).To(Succeed())
+}
+
+func (t *testDriver) updateGatewayHAStatus(ctx context.Context, name string, haStatus submarinerv1.HAStatus, failure string) {
+	gateway := &submarinerv1.Gateway{}
+	Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Name: name, Namespace: submarinerNamespace}, gateway)).To(Succeed())
+
+	gateway.Status.HAStatus = haStatus
+	gateway.Status.StatusFailure = failure
+	Expect(t.ScopedClient.Update(ctx, gateway)).To(Succeed())
+}
+
+type fakeComponent struct {
+	enabled           bool
+	invalid           error
+	reconciled        bool
+	cleanedUp         bool
+	observedClusterID string
+}
+
+func (c *fakeComponent) Name() string {
+	return "fake"
+}
+
+func (c *fakeComponent) Enabled(_ *v1alpha1.Submariner) bool {
+	return c.enabled
+}
+
+func (c *fakeComponent) Validate(_ *v1alpha1.Submariner) error {
+	return c.invalid
+}
+
+func (c *fakeComponent) Render(_ *v1alpha1.Submariner) []client.Object {
+	return nil
+}
+
+func (c *fakeComponent) Reconcile(_ context.Context, _ *v1alpha1.Submariner, _ logr.Logger) error {
+	c.reconciled = true
+	return nil
+}
+
+func (c *fakeComponent) Cleanup(_ context.Context, _ *v1alpha1.Submariner, _ logr.Logger) error {
+	c.cleanedUp = true
+	return nil
+}
+
+func (c *fakeComponent) Status(_ context.Context, submariner *v1alpha1.Submariner) error {
+	c.observedClusterID = submariner.Status.ClusterID
+	return nil
+}
+
+const testBrokerCABundle = `-----BEGIN CERTIFICATE-----
+MIIBjzCCATWgAwIBAgIUawROJZ9lkY3zYrNBqdMUIRLnsncwCgYIKoZIzj0EAwIw
+HTEbMBkGA1UEAwwSYnJva2VyLmV4YW1wbGUuY29tMB4XDTI2MTAxNzAyNDk1NFoX
+DTM2MTAxNDAyNDk1NFowHTEbMBkGA1UEAwwSYnJva2VyLmV4YW1wbGUuY29tMFkw
+EwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEzVwfhAERlpmmLrp0J3nj/cWPHBdTXV+7
+mW02bGtcXcCISH0BlqXmQR6qQn9B8WG8ijD+E+xixbCoc+FOLILugaNTMFEwHQYD
+VR0OBBYEFCi6RvR+0j7zC7Z+xN4hzSUUxCUhMB8GA1UdIwQYMBaAFCi6RvR+0j7z
+C7Z+xN4hzSUUxCUhMA8GA1UdEwEB/wQFMAMBAf8wCgYIKoZIzj0EAwIDSAAwRQIh
+ALSz6zRBZRc0YUxrjBtpz9svfUzniHAc9Ejwhqqn7qNVAiBmoCc8aJMg/US1amop
+tmc3RFP32HVEtmoQ118UsHPUnQ==
+-----END CERTIFICATE-----
+`
+
+func newComponentPod(component, parameter string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kube-system",
+			Name:      component,
+			Labels:    map[string]string{"component": component},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Command: []string{component, parameter}}},
+		},
+	}
+}
@@ -19,9 +19,11 @@ limitations under the License.
 package submariner
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
 	submv1 "github.com/submariner-io/submariner/pkg/apis/submariner.io/v1"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
@@ -32,6 +34,11 @@ const (
 	connectionsRemoteClusterLabel  = "remote_cluster"
 	connectionsRemoteHostnameLabel = "remote_hostname"
 	connectionsStatusLabel         = "status"
+	connectionsAddressLabel        = "address"
+	connectionsNATLabel            = "nat"
+	brokerNamespaceLabel           = "namespace"
+	brokerKindLabel                = "kind"
+	windowLabel                    = "window"
 )
 
 var (
@@ -51,6 +58,42 @@ var (
 			connectionsLocalHostnameLabel,
 		},
 	)
+	gatewayFailoversGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "submariner_gateway_failovers",
+			Help: "Number of times the active gateway changed",
+		},
+	)
+	brokerObjectsGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "submariner_broker_objects",
+			Help: "Number of objects in the broker namespace (by kind)",
+		},
+		[]string{
+			brokerNamespaceLabel,
+			brokerKindLabel,
+		},
+	)
+	connectionAvailabilityGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "submariner_connection_availability_ratio",
+			Help: "Ratio of samples in which the connection to a remote cluster was available (by rolling window)",
+		},
+		[]string{
+			connectionsRemoteClusterLabel,
+			windowLabel,
+		},
+	)
+	connectionAverageLatencyGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "submariner_connection_average_latency_seconds",
+			Help: "Average round-trip time of the connection to a remote cluster (by rolling window)",
+		},
+		[]string{
+			connectionsRemoteClusterLabel,
+			windowLabel,
+		},
+	)
 	connectionsGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "submariner_requested_connections",
@@ -64,10 +107,25 @@ var (
 			connectionsStatusLabel,
 		},
 	)
+	connectionAddressGauge = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "submariner_connection_address",
+			Help: "Address chosen by NAT discovery for each connection (public, private or other), and whether NAT is used",
+		},
+		[]string{
+			connectionsLocalClusterLabel,
+			connectionsLocalHostnameLabel,
+			connectionsRemoteClusterLabel,
+			connectionsRemoteHostnameLabel,
+			connectionsAddressLabel,
+			connectionsNATLabel,
+		},
+	)
 )
 
 func init() {
-	metrics.Registry.MustRegister(gatewaysGauge, connectionsGauge, gatewayCreationTimeGauge)
+	metrics.Registry.MustRegister(gatewaysGauge, connectionsGauge, gatewayCreationTimeGauge, gatewayFailoversGauge,
+		brokerObjectsGauge, connectionAvailabilityGauge, connectionAverageLatencyGauge, connectionAddressGauge)
 }
 
 func recordGateways(count int) {
@@ -85,8 +143,18 @@ func recordGatewayCreationTime(localEndpoint *submv1.EndpointSpec, upTime time.T
 	}).Set(float64(upTime.Unix()))
 }
 
+func recordGatewayFailover(status *v1alpha1.GatewayFailoverStatus) {
+	if status == nil {
+		gatewayFailoversGauge.Set(0)
+		return
+	}
+
+	gatewayFailoversGauge.Set(float64(status.FailoverCount))
+}
+
 func recordNoConnections() {
 	connectionsGauge.Reset()
+	connectionAddressGauge.Reset()
 }
 
 func recordConnection(localEndpoint, remoteEndpoint *submv1.EndpointSpec, status string) {
@@ -98,3 +166,60 @@ func recordConnection(localEndpoint, remoteEndpoint *submv1.EndpointSpec, status
 		connectionsStatusLabel:         status,
 	}).Inc()
 }
+
+// recordConnectionAddress records which of the remote endpoint's addresses the connection uses. Connections which
+// haven't chosen an address yet aren't recorded.
+func recordConnectionAddress(localEndpoint *submv1.EndpointSpec, connection *submv1.Connection) {
+	if connection.UsingIP == "" {
+		return
+	}
+
+	address := "other"
+
+	switch connection.UsingIP {
+	case connection.Endpoint.PublicIP:
+		address = "public"
+	case connection.Endpoint.PrivateIP:
+		address = "private"
+	}
+
+	connectionAddressGauge.With(prometheus.Labels{
+		connectionsLocalClusterLabel:   localEndpoint.ClusterID,
+		connectionsLocalHostnameLabel:  localEndpoint.Hostname,
+		connectionsRemoteClusterLabel:  connection.Endpoint.ClusterID,
+		connectionsRemoteHostnameLabel: connection.Endpoint.Hostname,
+		connectionsAddressLabel:        address,
+		connectionsNATLabel:            strconv.FormatBool(connection.UsingNAT),
+	}).Set(1)
+}
+
+func recordBrokerObjects(namespace, kind string, count int) {
+	brokerObjectsGauge.With(prometheus.Labels{
+		brokerNamespaceLabel: namespace,
+		brokerKindLabel:      kind,
+	}).Set(float64(count))
+}
+
+func recordNoConnectionAvailability() {
+	connectionAvailabilityGauge.Reset()
+	connectionAverageLatencyGauge.Reset()
+}
+
+func recordConnectionAvailability(availability []v1alpha1.ConnectionAvailability) {
+	recordNoConnectionAvailability()
+
+	for i := range availability {
+		labels := prometheus.Labels{
+			connectionsRemoteClusterLabel: availability[i].ClusterID,
+			windowLabel:                   availability[i].Window.Duration.String(),
+		}
+
+		if percent, err := strconv.ParseFloat(availability[i].Availability, 64); err == nil {
+			connectionAvailabilityGauge.With(labels).Set(percent / 100)
+		}
+
+		if latency, err := time.ParseDuration(availability[i].AverageLatency); err == nil {
+			connectionAverageLatencyGauge.With(labels).Set(latency.Seconds())
+		}
+	}
+}
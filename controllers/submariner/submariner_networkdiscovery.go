@@ -21,19 +21,48 @@ package submariner
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	submopv1a1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
 	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
+	"github.com/submariner-io/submariner-operator/pkg/names"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+// The discovered cluster network is refreshed after this interval, to pick up CIDR or CNI changes.
+const clusterNetworkRefreshInterval = 24 * time.Hour
+
 func (r *Reconciler) getClusterNetwork(ctx context.Context, submariner *submopv1a1.Submariner) (*network.ClusterNetwork, error) {
 	const UnknownPlugin = "unknown"
 
-	// If a previously cached discovery exists, use that
-	if r.config.ClusterNetwork != nil && r.config.ClusterNetwork.NetworkPlugin != UnknownPlugin {
-		return r.config.ClusterNetwork, nil
+	persisted := r.getClusterNetworkResource(ctx, submariner.Namespace)
+
+	if needsRediscovery(persisted) {
+		log.Info("Discovering the cluster network again")
+
+		r.config.ClusterNetwork = nil
+	} else {
+		// If a previously cached discovery exists, use that
+		if r.config.ClusterNetwork != nil && r.config.ClusterNetwork.NetworkPlugin != UnknownPlugin {
+			r.persistClusterNetwork(ctx, submariner, r.config.ClusterNetwork, false)
+			return r.config.ClusterNetwork, nil
+		}
+
+		// Otherwise, if a previous discovery was persisted, use that
+		clusterNetwork := loadClusterNetwork(persisted)
+		if clusterNetwork != nil && clusterNetwork.NetworkPlugin != UnknownPlugin {
+			log.Info("Using previously discovered cluster network")
+
+			r.config.ClusterNetwork = clusterNetwork
+			clusterNetwork.Log(log)
+
+			return clusterNetwork, nil
+		}
 	}
 
 	clusterNetwork, err := network.Discover(ctx, r.config.GeneralClient, submariner.Namespace)
@@ -46,6 +75,7 @@ func (r *Reconciler) getClusterNetwork(ctx context.Context, submariner *submopv1
 
 		r.config.ClusterNetwork = clusterNetwork
 		clusterNetwork.Log(log)
+		r.persistClusterNetwork(ctx, submariner, clusterNetwork, true)
 	} else {
 		log.Info("No cluster network discovered")
 
@@ -55,6 +85,94 @@ func (r *Reconciler) getClusterNetwork(ctx context.Context, submariner *submopv1
 	return r.config.ClusterNetwork, errors.Wrap(err, "error discovering cluster network")
 }
 
+func (r *Reconciler) getClusterNetworkResource(ctx context.Context, namespace string) *submopv1a1.ClusterNetwork {
+	cr := &submopv1a1.ClusterNetwork{}
+
+	err := r.config.ScopedClient.Get(ctx, types.NamespacedName{Namespace: namespace, Name: names.ClusterNetworkCrName}, cr)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "Error retrieving the ClusterNetwork resource")
+		}
+
+		return nil
+	}
+
+	return cr
+}
+
+// needsRediscovery returns true if the persisted discovery was requested to be refreshed, or is too old.
+func needsRediscovery(cr *submopv1a1.ClusterNetwork) bool {
+	if cr == nil {
+		return false
+	}
+
+	if _, ok := cr.Annotations[submopv1a1.RediscoverNetworkAnnotation]; ok {
+		return true
+	}
+
+	return !cr.Status.DiscoveryTime.IsZero() && time.Since(cr.Status.DiscoveryTime.Time) > clusterNetworkRefreshInterval
+}
+
+func loadClusterNetwork(cr *submopv1a1.ClusterNetwork) *network.ClusterNetwork {
+	if cr == nil || cr.Status.NetworkPlugin == "" {
+		return nil
+	}
+
+	return &network.ClusterNetwork{
+		PodCIDRs:       cr.Status.PodCIDRs,
+		ServiceCIDRs:   cr.Status.ServiceCIDRs,
+		NetworkPlugin:  cr.Status.NetworkPlugin,
+		GlobalCIDR:     cr.Status.GlobalCIDR,
+		KubeProxyMode:  cr.Status.KubeProxyMode,
+		PluginSettings: cr.Status.PluginSettings,
+	}
+}
+
+// persistClusterNetwork records the discovered network in the ClusterNetwork resource so other consumers don't need to
+// run their own discovery. Failures aren't fatal, the discovery results are still cached in memory. The discovery time
+// is only updated if the network was just discovered, which also completes any rediscovery request.
+func (r *Reconciler) persistClusterNetwork(ctx context.Context, submariner *submopv1a1.Submariner,
+	clusterNetwork *network.ClusterNetwork, discovered bool,
+) {
+	cr := &submopv1a1.ClusterNetwork{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: submariner.Namespace,
+			Name:      names.ClusterNetworkCrName,
+		},
+	}
+
+	result, err := controllerutil.CreateOrUpdate(ctx, r.config.ScopedClient, cr, func() error {
+		discoveryTime := cr.Status.DiscoveryTime
+		if discovered || discoveryTime.IsZero() {
+			discoveryTime = metav1.Now()
+		}
+
+		if discovered {
+			delete(cr.Annotations, submopv1a1.RediscoverNetworkAnnotation)
+		}
+
+		cr.Status = submopv1a1.ClusterNetworkStatus{
+			NetworkPlugin:  clusterNetwork.NetworkPlugin,
+			PodCIDRs:       clusterNetwork.PodCIDRs,
+			ServiceCIDRs:   clusterNetwork.ServiceCIDRs,
+			GlobalCIDR:     clusterNetwork.GlobalCIDR,
+			KubeProxyMode:  clusterNetwork.KubeProxyMode,
+			PluginSettings: clusterNetwork.PluginSettings,
+			DiscoveryTime:  discoveryTime,
+		}
+
+		return controllerutil.SetControllerReference(submariner, cr, r.config.Scheme)
+	})
+	if err != nil {
+		log.Error(err, "Error persisting the discovered cluster network")
+		return
+	}
+
+	if result != controllerutil.OperationResultNone {
+		log.Info("Persisted the discovered cluster network", "Namespace", cr.Namespace, "Name", cr.Name, "result", result)
+	}
+}
+
 func (r *Reconciler) discoverNetwork(ctx context.Context, submariner *submopv1a1.Submariner, log logr.Logger,
 ) (*network.ClusterNetwork, error) {
 	clusterNetwork, err := r.getClusterNetwork(ctx, submariner)
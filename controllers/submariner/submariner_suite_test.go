@@ -21,6 +21,7 @@ package submariner_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strconv"
 	"testing"
 
@@ -39,8 +40,11 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -49,6 +53,11 @@ var _ = BeforeSuite(func() {
 	Expect(apiextensions.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(submarinerv1.AddToScheme(scheme.Scheme)).To(Succeed())
 	Expect(configv1.Install(scheme.Scheme)).To(Succeed())
+
+	// The MCS API types aren't a dependency, handle ServiceImports as unstructured
+	serviceImportGV := schema.GroupVersion{Group: "multicluster.x-k8s.io", Version: "v1alpha1"}
+	scheme.Scheme.AddKnownTypeWithName(serviceImportGV.WithKind("ServiceImport"), &unstructured.Unstructured{})
+	scheme.Scheme.AddKnownTypeWithName(serviceImportGV.WithKind("ServiceImportList"), &unstructured.UnstructuredList{})
 })
 
 var _ = Describe("", func() {
@@ -64,6 +73,8 @@ type testDriver struct {
 	test.Driver
 	submariner     *v1alpha1.Submariner
 	clusterNetwork *network.ClusterNetwork
+	events         *record.FakeRecorder
+	components     []submarinerController.Component
 }
 
 func newTestDriver() *testDriver {
@@ -75,6 +86,7 @@ func newTestDriver() *testDriver {
 	}
 
 	BeforeEach(func() {
+		t.components = nil
 		t.BeforeEach()
 		t.submariner = newSubmariner()
 		t.InitScopedClientObjs = []controllerClient.Object{t.submariner}
@@ -89,11 +101,15 @@ func newTestDriver() *testDriver {
 	JustBeforeEach(func() {
 		t.JustBeforeEach()
 
+		t.events = record.NewFakeRecorder(10)
+
 		t.Controller = submarinerController.NewReconciler(&submarinerController.Config{
 			ScopedClient:   t.ScopedClient,
 			GeneralClient:  t.GeneralClient,
 			Scheme:         scheme.Scheme,
 			ClusterNetwork: t.clusterNetwork,
+			EventRecorder:  t.events,
+			Components:     t.components,
 		})
 	})
 
@@ -116,6 +132,13 @@ func (t *testDriver) getSubmariner(ctx context.Context) *v1alpha1.Submariner {
 	return obj
 }
 
+func (t *testDriver) brokerConnectionCheck(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/readyz", http.NoBody)
+	Expect(err).To(Succeed())
+
+	return t.Controller.(*submarinerController.Reconciler).BrokerConnectionCheck()(req)
+}
+
 func (t *testDriver) assertRouteAgentDaemonSet(ctx context.Context) {
 	daemonSet := t.AssertDaemonSet(ctx, names.RouteAgentComponent)
 
@@ -71,12 +71,16 @@ func (d *Driver) JustBeforeEach() {
 
 func (d *Driver) NewScopedClient() client.Client {
 	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(d.InitScopedClientObjs...).
-		WithStatusSubresource(&v1alpha1.Submariner{}).WithInterceptorFuncs(d.InterceptorFuncs).Build()
+		WithStatusSubresource(&v1alpha1.Submariner{}, &v1alpha1.ServiceDiscovery{}, &v1alpha1.Broker{},
+			&v1alpha1.VerificationRun{}, &v1alpha1.JoinRequest{}).
+		WithInterceptorFuncs(d.InterceptorFuncs).Build()
 }
 
 func (d *Driver) NewGeneralClient() client.Client {
 	return fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(d.InitGeneralClientObjs...).
-		WithStatusSubresource(&v1alpha1.Submariner{}).WithInterceptorFuncs(d.InterceptorFuncs).Build()
+		WithStatusSubresource(&v1alpha1.Submariner{}, &v1alpha1.ServiceDiscovery{}, &v1alpha1.Broker{},
+			&v1alpha1.VerificationRun{}, &v1alpha1.JoinRequest{}).
+		WithInterceptorFuncs(d.InterceptorFuncs).Build()
 }
 
 func (d *Driver) DoReconcile(ctx context.Context) (reconcile.Result, error) {
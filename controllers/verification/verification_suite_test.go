@@ -0,0 +1,124 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verification_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/admiral/pkg/log/kzerolog"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/controllers/test"
+	"github.com/submariner-io/submariner-operator/controllers/verification"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	verificationRunName = "post-upgrade"
+	jobName             = "verification-" + verificationRunName
+	submarinerNamespace = "test-ns"
+)
+
+var _ = BeforeSuite(func() {
+	Expect(v1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+})
+
+var _ = Describe("", func() {
+	kzerolog.InitK8sLogging()
+})
+
+func TestVerification(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Verification Test Suite")
+}
+
+type testDriver struct {
+	test.Driver
+	verificationRun *v1alpha1.VerificationRun
+}
+
+func newTestDriver() *testDriver {
+	t := &testDriver{
+		Driver: test.Driver{
+			Namespace:    submarinerNamespace,
+			ResourceName: verificationRunName,
+		},
+	}
+
+	BeforeEach(func() {
+		t.BeforeEach()
+		t.verificationRun = &v1alpha1.VerificationRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      verificationRunName,
+				Namespace: submarinerNamespace,
+			},
+			Spec: v1alpha1.VerificationRunSpec{
+				Suites:                 []v1alpha1.VerificationSuite{v1alpha1.VerificationConnectivity},
+				RemoteKubeConfigSecret: "remote-cluster",
+				ServiceAccountName:     "verifier",
+				Timeout:                &metav1.Duration{Duration: 10 * time.Minute},
+			},
+		}
+		t.InitScopedClientObjs = []controllerClient.Object{t.verificationRun}
+	})
+
+	JustBeforeEach(func() {
+		t.JustBeforeEach()
+
+		t.Controller = &verification.Reconciler{
+			ScopedClient: t.ScopedClient,
+			Scheme:       scheme.Scheme,
+		}
+	})
+
+	return t
+}
+
+func (t *testDriver) getVerificationRun(ctx context.Context) *v1alpha1.VerificationRun {
+	verificationRun := &v1alpha1.VerificationRun{}
+	Expect(t.ScopedClient.Get(ctx, controllerClient.ObjectKeyFromObject(t.verificationRun), verificationRun)).To(Succeed())
+
+	return verificationRun
+}
+
+func (t *testDriver) getJob(ctx context.Context) *batchv1.Job {
+	job := &batchv1.Job{}
+	Expect(t.ScopedClient.Get(ctx, types.NamespacedName{Namespace: submarinerNamespace, Name: jobName}, job)).To(Succeed())
+
+	return job
+}
+
+func (t *testDriver) finishJob(ctx context.Context, conditionType batchv1.JobConditionType, reason string) {
+	job := t.getJob(ctx)
+	job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+		Type:               conditionType,
+		Status:             corev1.ConditionTrue,
+		Reason:             reason,
+		LastTransitionTime: metav1.Now(),
+	})
+	Expect(t.ScopedClient.Status().Update(ctx, job)).To(Succeed())
+}
@@ -0,0 +1,284 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	submarinerv1alpha1 "github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/images"
+	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var log = logf.Log.WithName("controller_verification")
+
+const (
+	jobNamePrefix        = "verification-"
+	remoteKubeConfigPath = "/etc/verification/remote"
+	remoteKubeConfigKey  = "kubeconfig"
+)
+
+// The subctl verify options matching each suite.
+var suiteOptions = map[submarinerv1alpha1.VerificationSuite]string{
+	submarinerv1alpha1.VerificationConnectivity:     "connectivity",
+	submarinerv1alpha1.VerificationServiceDiscovery: "service-discovery",
+	submarinerv1alpha1.VerificationGatewayFailover:  "gateway-failover",
+}
+
+// Reconciler reconciles a VerificationRun object.
+type Reconciler struct {
+	// This client is scoped to the operator namespace intended to only be used for resources created and maintained by this
+	// controller. Also it's a split client that reads objects from the cache and writes to the apiserver.
+	ScopedClient controllerClient.Client
+	Scheme       *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=submariner.io,resources=verificationruns,verbs=get;list;watch;update
+//+kubebuilder:rbac:groups=submariner.io,resources=verificationruns/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+
+// Reconcile runs the verification Job of a VerificationRun, once, and records its outcome.
+func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.V(2).WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling VerificationRun")
+
+	instance := &submarinerv1alpha1.VerificationRun{}
+
+	err := r.ScopedClient.Get(ctx, request.NamespacedName, instance)
+	if apierrors.IsNotFound(err) {
+		// The Job is garbage collected along with the VerificationRun
+		return reconcile.Result{}, nil
+	}
+
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "error retrieving VerificationRun resource")
+	}
+
+	if instance.Status.Phase == submarinerv1alpha1.VerificationRunSucceeded ||
+		instance.Status.Phase == submarinerv1alpha1.VerificationRunFailed {
+		return reconcile.Result{}, nil
+	}
+
+	job := &batchv1.Job{}
+
+	err = r.ScopedClient.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: jobNamePrefix + instance.Name}, job)
+	if apierrors.IsNotFound(err) {
+		return reconcile.Result{}, r.startJob(ctx, instance)
+	}
+
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "error retrieving the verification Job")
+	}
+
+	status := instance.Status.DeepCopy()
+
+	for i := range job.Status.Conditions {
+		condition := &job.Status.Conditions[i]
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type { //nolint:exhaustive // Only the final conditions are relevant
+		case batchv1.JobComplete:
+			status.Phase = submarinerv1alpha1.VerificationRunSucceeded
+			status.CompletionTime = ptr.To(condition.LastTransitionTime)
+		case batchv1.JobFailed:
+			status.Phase = submarinerv1alpha1.VerificationRunFailed
+			status.CompletionTime = ptr.To(condition.LastTransitionTime)
+
+			status.Message, err = r.failureMessage(ctx, job, condition)
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	if status.Phase == instance.Status.Phase {
+		return reconcile.Result{}, nil
+	}
+
+	reqLogger.Info("Verification finished", "phase", status.Phase)
+
+	instance.Status = *status
+
+	return reconcile.Result{}, errors.Wrap(r.ScopedClient.Status().Update(ctx, instance),
+		"error updating the VerificationRun status")
+}
+
+func (r *Reconciler) startJob(ctx context.Context, instance *submarinerv1alpha1.VerificationRun) error {
+	image := instance.Spec.Image
+	if image == "" {
+		var err error
+
+		image, err = r.defaultImage(ctx, instance.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	job := newVerificationJob(instance, image)
+
+	if err := controllerutil.SetControllerReference(instance, job, r.Scheme); err != nil {
+		return errors.Wrap(err, "error setting the owner of the verification Job")
+	}
+
+	if err := r.ScopedClient.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "error creating the verification Job %q", job.Name)
+	}
+
+	log.Info("Started verification", "namespace", instance.Namespace, "name", instance.Name, "job", job.Name)
+
+	instance.Status.Phase = submarinerv1alpha1.VerificationRunRunning
+	instance.Status.JobName = job.Name
+	instance.Status.StartTime = ptr.To(metav1.Now())
+
+	return errors.Wrap(r.ScopedClient.Status().Update(ctx, instance), "error updating the VerificationRun status")
+}
+
+// defaultImage returns the subctl image matching the Submariner deployment in the given namespace, if any.
+func (r *Reconciler) defaultImage(ctx context.Context, namespace string) (string, error) {
+	submariners := &submarinerv1alpha1.SubmarinerList{}
+
+	if err := r.ScopedClient.List(ctx, submariners, controllerClient.InNamespace(namespace)); err != nil {
+		return "", errors.Wrap(err, "error listing the Submariner resources")
+	}
+
+	if len(submariners.Items) == 0 {
+		return images.GetImagePath(submarinerv1alpha1.DefaultRepo, submarinerv1alpha1.DefaultSubmarinerVersion,
+			opnames.SubctlImage, opnames.SubctlImage, nil), nil
+	}
+
+	spec := &submariners.Items[0].Spec
+
+	return images.GetImagePath(spec.Repository, spec.Version, opnames.SubctlImage, opnames.SubctlImage, spec.ImageOverrides), nil
+}
+
+// failureMessage describes the failure of the Job, including the last lines of the verification logs which Kubernetes
+// records as the termination message of the failed container.
+func (r *Reconciler) failureMessage(ctx context.Context, job *batchv1.Job, condition *batchv1.JobCondition) (string, error) {
+	message := condition.Reason
+	if condition.Message != "" {
+		message = fmt.Sprintf("%s: %s", condition.Reason, condition.Message)
+	}
+
+	pods := &corev1.PodList{}
+
+	err := r.ScopedClient.List(ctx, pods, controllerClient.InNamespace(job.Namespace),
+		controllerClient.MatchingLabels{batchv1.JobNameLabel: job.Name})
+	if err != nil {
+		return "", errors.Wrap(err, "error listing the verification pods")
+	}
+
+	for i := range pods.Items {
+		for j := range pods.Items[i].Status.ContainerStatuses {
+			terminated := pods.Items[i].Status.ContainerStatuses[j].State.Terminated
+			if terminated != nil && terminated.ExitCode != 0 && terminated.Message != "" {
+				return message + "\n" + strings.TrimSpace(terminated.Message), nil
+			}
+		}
+	}
+
+	return message, nil
+}
+
+func newVerificationJob(instance *submarinerv1alpha1.VerificationRun, image string) *batchv1.Job {
+	suites := make([]string, len(instance.Spec.Suites))
+	for i, suite := range instance.Spec.Suites {
+		suites[i] = suiteOptions[suite]
+	}
+
+	// The local cluster is accessed through the in-cluster configuration
+	command := []string{
+		"subctl", "verify", "--toconfig", remoteKubeConfigPath + "/" + remoteKubeConfigKey,
+		"--only", strings.Join(suites, ","), "--verbose",
+	}
+
+	for _, suite := range instance.Spec.Suites {
+		if suite == submarinerv1alpha1.VerificationGatewayFailover {
+			command = append(command, "--enable-disruptive")
+		}
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: instance.Namespace,
+			Name:      jobNamePrefix + instance.Name,
+			Labels:    map[string]string{"app": "submariner-verification"},
+		},
+		Spec: batchv1.JobSpec{
+			// A failed verification isn't retried
+			BackoffLimit: ptr.To(int32(0)),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app": "submariner-verification"},
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: instance.Spec.ServiceAccountName,
+					Containers: []corev1.Container{{
+						Name:                     "verification",
+						Image:                    image,
+						ImagePullPolicy:          images.GetPullPolicy("", image),
+						Command:                  command,
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "remote-kubeconfig", MountPath: remoteKubeConfigPath, ReadOnly: true},
+						},
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "remote-kubeconfig",
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{SecretName: instance.Spec.RemoteKubeConfigSecret},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	if instance.Spec.Timeout != nil {
+		job.Spec.ActiveDeadlineSeconds = ptr.To(int64(instance.Spec.Timeout.Seconds()))
+	}
+
+	return job
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("verificationrun-controller").
+		For(&submarinerv1alpha1.VerificationRun{}).
+		// The outcome of the verification is tracked through its Job
+		Owns(&batchv1.Job{}).
+		Complete(r)
+}
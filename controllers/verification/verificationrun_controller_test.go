@@ -0,0 +1,128 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package verification_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+var _ = Describe("VerificationRun controller", func() {
+	t := newTestDriver()
+
+	It("should start the verification Job", func(ctx SpecContext) {
+		t.AssertReconcileSuccess(ctx)
+
+		job := t.getJob(ctx)
+		Expect(job.Spec.BackoffLimit).To(Equal(ptr.To(int32(0))))
+		Expect(job.Spec.ActiveDeadlineSeconds).To(Equal(ptr.To(int64(600))))
+		Expect(job.OwnerReferences).To(ContainElement(HaveField("Name", verificationRunName)))
+
+		podSpec := &job.Spec.Template.Spec
+		Expect(podSpec.ServiceAccountName).To(Equal("verifier"))
+		Expect(podSpec.Volumes).To(ContainElement(HaveField("Secret.SecretName", "remote-cluster")))
+		Expect(podSpec.Containers[0].Image).To(Equal(v1alpha1.DefaultRepo + "/subctl:" + v1alpha1.DefaultSubmarinerVersion))
+		Expect(podSpec.Containers[0].Command).To(ContainElements("verify", "--only", "connectivity"))
+		Expect(podSpec.Containers[0].Command).ToNot(ContainElement("--enable-disruptive"))
+		Expect(podSpec.Containers[0].TerminationMessagePolicy).To(Equal(corev1.TerminationMessageFallbackToLogsOnError))
+
+		status := t.getVerificationRun(ctx).Status
+		Expect(status.Phase).To(Equal(v1alpha1.VerificationRunRunning))
+		Expect(status.JobName).To(Equal(jobName))
+		Expect(status.StartTime).ToNot(BeNil())
+	})
+
+	When("Submariner is deployed", func() {
+		BeforeEach(func() {
+			t.InitScopedClientObjs = append(t.InitScopedClientObjs, &v1alpha1.Submariner{
+				ObjectMeta: metav1.ObjectMeta{Name: "submariner", Namespace: submarinerNamespace},
+				Spec:       v1alpha1.SubmarinerSpec{Repository: "quay.io/example", Version: "0.17.1"},
+			})
+		})
+
+		It("should run the matching subctl image", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+			Expect(t.getJob(ctx).Spec.Template.Spec.Containers[0].Image).To(Equal("quay.io/example/subctl:0.17.1"))
+		})
+	})
+
+	When("the gateway failover suite is requested", func() {
+		BeforeEach(func() {
+			t.verificationRun.Spec.Suites = append(t.verificationRun.Spec.Suites, v1alpha1.VerificationGatewayFailover)
+		})
+
+		It("should enable the disruptive tests", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+			Expect(t.getJob(ctx).Spec.Template.Spec.Containers[0].Command).To(ContainElements(
+				"connectivity,gateway-failover", "--enable-disruptive"))
+		})
+	})
+
+	When("the verification Job completes", func() {
+		It("should record the success", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+			t.finishJob(ctx, batchv1.JobComplete, "")
+
+			t.AssertReconcileSuccess(ctx)
+
+			status := t.getVerificationRun(ctx).Status
+			Expect(status.Phase).To(Equal(v1alpha1.VerificationRunSucceeded))
+			Expect(status.CompletionTime).ToNot(BeNil())
+		})
+	})
+
+	When("the verification Job fails", func() {
+		It("should record the failure with the verification logs", func(ctx SpecContext) {
+			t.AssertReconcileSuccess(ctx)
+			t.finishJob(ctx, batchv1.JobFailed, "BackoffLimitExceeded")
+
+			Expect(t.ScopedClient.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      jobName + "-abcde",
+					Namespace: submarinerNamespace,
+					Labels:    map[string]string{batchv1.JobNameLabel: jobName},
+				},
+				Status: corev1.PodStatus{
+					ContainerStatuses: []corev1.ContainerStatus{{
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 1,
+							Message:  "[FAIL] Basic TCP connectivity tests across clusters\n",
+						}},
+					}},
+				},
+			})).To(Succeed())
+
+			t.AssertReconcileSuccess(ctx)
+
+			status := t.getVerificationRun(ctx).Status
+			Expect(status.Phase).To(Equal(v1alpha1.VerificationRunFailed))
+			Expect(status.Message).To(Equal("BackoffLimitExceeded\n[FAIL] Basic TCP connectivity tests across clusters"))
+
+			By("not starting it again")
+
+			t.AssertReconcileSuccess(ctx)
+			Expect(t.getVerificationRun(ctx).Status.Phase).To(Equal(v1alpha1.VerificationRunFailed))
+		})
+	})
+})
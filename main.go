@@ -20,10 +20,14 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"runtime"
+	"strings"
+	"time"
 
 	configv1 "github.com/openshift/api/config/v1"
 	"github.com/operator-framework/operator-lib/leader"
@@ -31,9 +35,11 @@ import (
 	"github.com/submariner-io/admiral/pkg/names"
 	admversion "github.com/submariner-io/admiral/pkg/version"
 	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/controllers/join"
 	"github.com/submariner-io/submariner-operator/controllers/metrics"
 	"github.com/submariner-io/submariner-operator/controllers/servicediscovery"
 	"github.com/submariner-io/submariner-operator/controllers/submariner"
+	"github.com/submariner-io/submariner-operator/controllers/verification"
 	"github.com/submariner-io/submariner-operator/pkg/crd"
 	"github.com/submariner-io/submariner-operator/pkg/gateway"
 	"github.com/submariner-io/submariner-operator/pkg/lighthouse"
@@ -61,6 +67,8 @@ var (
 	metricsPort int32 = 8383
 )
 
+const cacheSyncCheckTimeout = time.Second
+
 var (
 	scheme      = apiruntime.NewScheme()
 	log         = logf.Log.WithName("cmd")
@@ -81,14 +89,30 @@ func init() {
 
 //nolint:gocyclo // No further refactors necessary
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case renderCommand:
+			os.Exit(runRender(os.Args[2:]))
+		case migrateHelmCommand:
+			os.Exit(runMigrateHelm(os.Args[2:]))
+		case rbacCommand:
+			os.Exit(runRBAC(os.Args[2:]))
+		}
+	}
+
 	var enableLeaderElection bool
 	var probeAddr string
 	var pprofAddr string
+	var crdServerSideApply, crdForceConflicts bool
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&pprofAddr, "pprof-bind-address", ":8082", "The address the profiling endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&crdServerSideApply, "crd-server-side-apply", false,
+		"Install the CRDs with server-side apply, leaving the fields managed by others, e.g. Helm or OLM, alone.")
+	flag.BoolVar(&crdForceConflicts, "crd-force-conflicts", false,
+		"Take over the CRD fields owned by other field managers when installing the CRDs with server-side apply.")
 
 	kzerolog.AddFlags(nil)
 	flag.Parse()
@@ -109,12 +133,20 @@ func main() {
 
 	printVersion()
 
-	namespace, err := getWatchNamespace()
+	namespaces, err := getWatchNamespaces()
 	if err != nil {
 		log.Error(err, "Failed to get watch namespace")
 		os.Exit(1)
 	}
 
+	// The operator's own namespace comes first
+	namespace := namespaces[0]
+
+	defaultNamespaces := map[string]cache.Config{}
+	for _, ns := range namespaces {
+		defaultNamespaces[ns] = cache.Config{}
+	}
+
 	// Get a config to talk to the apiserver
 	cfg, err := config.GetConfig()
 	if err != nil {
@@ -130,8 +162,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	var crdOptions []crd.Option
+	if crdServerSideApply {
+		crdOptions = append(crdOptions, crd.WithServerSideApply(crdForceConflicts))
+	}
+
 	// Set up the CRDs we need
-	crdUpdater, err := crd.UpdaterFromRestConfig(cfg)
+	crdUpdater, err := crd.UpdaterFromRestConfig(cfg, crdOptions...)
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -173,7 +210,7 @@ func main() {
 		// LeaderElectionID determines the name of the resource that leader election will use for holding the leader lock
 		LeaderElectionID: "2a1e5b0d.submariner.io", // autogenerated
 		Cache: cache.Options{
-			DefaultNamespaces: map[string]cache.Config{namespace: {}},
+			DefaultNamespaces: defaultNamespaces,
 		},
 		MapperProvider:   apiutil.NewDynamicRESTMapper,
 		PprofBindAddress: pprofAddr,
@@ -204,8 +241,9 @@ func main() {
 
 	// Setup all Controllers
 	if err = (&submariner.BrokerReconciler{
-		Client: mgr.GetClient(),
-		Config: mgr.GetConfig(),
+		Client:     mgr.GetClient(),
+		Config:     mgr.GetConfig(),
+		CRDOptions: crdOptions,
 	}).SetupWithManager(mgr); err != nil {
 		log.Error(err, "unable to create controller", "controller", "Broker")
 		os.Exit(1)
@@ -215,13 +253,16 @@ func main() {
 		Scheme: scheme,
 	})
 
-	if err = submariner.NewReconciler(&submariner.Config{
+	submarinerReconciler := submariner.NewReconciler(&submariner.Config{
 		ScopedClient:  mgr.GetClient(),
 		GeneralClient: generalClient,
 		RestConfig:    mgr.GetConfig(),
 		Scheme:        mgr.GetScheme(),
 		DynClient:     dynamic.NewForConfigOrDie(mgr.GetConfig()),
-	}).SetupWithManager(mgr); err != nil {
+		EventRecorder: mgr.GetEventRecorderFor("submariner-operator"),
+	})
+
+	if err = submarinerReconciler.SetupWithManager(mgr); err != nil {
 		log.Error(err, "unable to create controller", "controller", "Submariner")
 		os.Exit(1)
 	}
@@ -236,6 +277,22 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&verification.Reconciler{
+		ScopedClient: mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "unable to create controller", "controller", "VerificationRun")
+		os.Exit(1)
+	}
+
+	if err = (&join.Reconciler{
+		ScopedClient:  mgr.GetClient(),
+		GeneralClient: generalClient,
+	}).SetupWithManager(mgr); err != nil {
+		log.Error(err, "unable to create controller", "controller", "JoinRequest")
+		os.Exit(1)
+	}
+
 	// +kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -248,6 +305,16 @@ func main() {
 		os.Exit(1) // We might not want to exit here if ready checks are not setup.
 	}
 
+	if err := mgr.AddReadyzCheck("informers", cacheSyncCheck(mgr.GetCache())); err != nil {
+		log.Error(err, "unable to set up the informer cache ready check")
+		os.Exit(1)
+	}
+
+	if err := mgr.AddReadyzCheck("broker", submarinerReconciler.BrokerConnectionCheck()); err != nil {
+		log.Error(err, "unable to set up the broker connection ready check")
+		os.Exit(1)
+	}
+
 	// Start the Cmd
 	log.Info("Starting the Cmd.")
 
@@ -257,17 +324,41 @@ func main() {
 	}
 }
 
-// getWatchNamespace returns the Namespace the operator should be watching for changes.
-func getWatchNamespace() (string, error) {
+// cacheSyncCheck returns a ready check which fails until the manager's informer caches have synced, so that
+// an operator whose watches can't be established is reported as not ready instead of silently doing nothing.
+func cacheSyncCheck(informers cache.Informers) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), cacheSyncCheckTimeout)
+		defer cancel()
+
+		if !informers.WaitForCacheSync(ctx) {
+			return errors.New("the informer caches have not synced")
+		}
+
+		return nil
+	}
+}
+
+// getWatchNamespaces returns the Namespaces the operator should be watching for changes.
+func getWatchNamespaces() ([]string, error) {
 	// WatchNamespaceEnvVar is the constant for env variable WATCH_NAMESPACE
 	// which specifies the Namespace to watch.
 	// An empty value means the operator is running with cluster scope.
+	// A comma-separated list allows a hub to host the Brokers of several clustersets, each in its own namespace;
+	// the operator's own namespace must come first. Only Brokers can be spread across namespaces: a cluster can only
+	// run a single Submariner and ServiceDiscovery, since the route agents of several deployments would run on the same
+	// nodes and their ServiceDiscovery instances would rewrite the same clusterset.local CoreDNS zone.
 	watchNamespaceEnvVar := "WATCH_NAMESPACE"
 
 	ns, found := os.LookupEnv(watchNamespaceEnvVar)
 	if !found {
-		return "", fmt.Errorf("%s must be set", watchNamespaceEnvVar)
+		return nil, fmt.Errorf("%s must be set", watchNamespaceEnvVar)
+	}
+
+	namespaces := strings.Split(ns, ",")
+	for i := range namespaces {
+		namespaces[i] = strings.TrimSpace(namespaces[i])
 	}
 
-	return ns, nil
+	return namespaces, nil
 }
@@ -0,0 +1,214 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/names"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/helm"
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+const migrateHelmCommand = "migrate-helm"
+
+const migrateHelmUsage = `Usage: %s %s [flags]
+
+Hands a Submariner deployment installed with the legacy Helm chart over to the operator.
+
+The migration interrupts the connectivity between the clusters. The operator's gateways run on the same nodes as the
+legacy gateways and stay passive while the legacy gateways hold the IPsec ports (UDP 500 and 4500) and the gateway
+leader lease. Once the legacy gateways are deleted, the tunnels are down until the leader lease expires and an
+operator gateway re-establishes them. Run the migration during a maintenance window.
+
+Flags:
+`
+
+type helmMigration struct {
+	releaseName      string
+	releaseNamespace string
+	namespace        string
+	timeout          time.Duration
+}
+
+// runMigrateHelm implements the migrate-helm sub-command, which hands a Submariner deployment installed with the legacy
+// Helm chart over to the operator.
+func runMigrateHelm(args []string) int {
+	m := &helmMigration{}
+
+	flags := flag.NewFlagSet(migrateHelmCommand, flag.ContinueOnError)
+	flags.StringVar(&m.releaseName, "release", "submariner", "The name of the Helm release to migrate")
+	flags.StringVar(&m.releaseNamespace, "release-namespace", "submariner", "The namespace of the Helm release to migrate")
+	flags.StringVar(&m.namespace, "namespace", "submariner-operator", "The namespace in which to create the Submariner resource")
+	flags.DurationVar(&m.timeout, "timeout", 5*time.Minute, "How long to wait for the operator to deploy Submariner")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), migrateHelmUsage, os.Args[0], migrateHelmCommand)
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := config.GetConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	migrationScheme := apiruntime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(migrationScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(migrationScheme))
+
+	c, err := client.New(cfg, client.Options{Scheme: migrationScheme})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := m.run(context.Background(), c, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+// run imports the release's settings into a Submariner resource, waits for the operator to deploy the gateways, then
+// removes the release's workloads which the operator didn't adopt, and the release itself. The other resources deployed
+// by the release are kept, since the operator's deployment may rely on them.
+func (m *helmMigration) run(ctx context.Context, c client.Client, out io.Writer) error {
+	release, err := helm.FindRelease(ctx, c, m.releaseNamespace, m.releaseName)
+	if err != nil {
+		return err //nolint:wrapcheck // No need to wrap here
+	}
+
+	fmt.Fprintf(out, "Migrating the Helm release %s\n", release)
+
+	instance, err := helm.ImportSubmariner(release, m.namespace)
+	if err != nil {
+		return err //nolint:wrapcheck // No need to wrap here
+	}
+
+	err = c.Create(ctx, instance)
+	if apierrors.IsAlreadyExists(err) {
+		fmt.Fprintf(out, "The Submariner resource %s/%s already exists, keeping it\n", instance.Namespace, instance.Name)
+
+		err = c.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "error creating the Submariner resource")
+	}
+
+	if err := m.awaitGateways(ctx, c, instance); err != nil {
+		return err
+	}
+
+	objs, err := release.Objects()
+	if err != nil {
+		return err //nolint:wrapcheck // No need to wrap here
+	}
+
+	for _, obj := range objs {
+		if obj.GroupVersionKind().Group != appsv1.GroupName || (obj.GetKind() != "DaemonSet" && obj.GetKind() != "Deployment") {
+			continue
+		}
+
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(release.Namespace)
+		}
+
+		err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "error retrieving %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+
+		if owner := metav1.GetControllerOf(obj); owner != nil && owner.UID == instance.UID {
+			fmt.Fprintf(out, "%s %s/%s was adopted by the operator\n", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+			continue
+		}
+
+		err = c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "error deleting %s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+
+		fmt.Fprintf(out, "Deleted the legacy %s %s/%s\n", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+	}
+
+	if err := release.Delete(ctx, c); err != nil {
+		return err //nolint:wrapcheck // No need to wrap here
+	}
+
+	fmt.Fprintf(out, "Removed the Helm release %s/%s, Submariner is now managed by the operator\n", release.Namespace, release.Name)
+
+	return nil
+}
+
+// awaitGateways waits for the operator to deploy the gateway DaemonSet and for its rollout to be available, so that the
+// legacy gateways are only removed once their replacements are running on all the gateway nodes. The replacements only
+// take over once the legacy gateways are gone and the gateway leader lease has expired, see migrateHelmUsage.
+func (m *helmMigration) awaitGateways(ctx context.Context, c client.Client, instance *v1alpha1.Submariner) error {
+	err := wait.PollUntilContextTimeout(ctx, 2*time.Second, m.timeout, true, func(ctx context.Context) (bool, error) {
+		daemonSet := &appsv1.DaemonSet{}
+
+		err := c.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: names.GatewayComponent}, daemonSet)
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, errors.Wrap(err, "error retrieving the gateway DaemonSet")
+		}
+
+		return metav1.IsControlledBy(daemonSet, instance) && isRolledOut(daemonSet), nil
+	})
+
+	return errors.Wrap(err, "error waiting for the operator to deploy the gateways")
+}
+
+// isRolledOut returns true if the current generation of the given DaemonSet is available on all its nodes.
+func isRolledOut(daemonSet *appsv1.DaemonSet) bool {
+	status := &daemonSet.Status
+
+	return status.ObservedGeneration >= daemonSet.Generation && status.DesiredNumberScheduled > 0 &&
+		status.UpdatedNumberScheduled == status.DesiredNumberScheduled && status.NumberAvailable == status.DesiredNumberScheduled
+}
@@ -0,0 +1,133 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/admiral/pkg/util"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// FieldManager is the field manager owning the CRD fields installed with server-side apply.
+const FieldManager = "submariner-operator-crds"
+
+type serverSideApply struct {
+	force bool
+}
+
+// WithServerSideApply installs the CRDs with server-side apply as the FieldManager field manager, instead of replacing
+// them, so that the fields set by others, e.g. Helm or OLM, are left alone. If fields in the manifests are owned by
+// other managers, the installation fails with a FieldConflictError, unless force is set: the fields are then taken over.
+func WithServerSideApply(force bool) Option {
+	return func(u *updater) {
+		u.serverSideApply = &serverSideApply{force: force}
+	}
+}
+
+// FieldConflictError is returned when CRD fields can't be applied because they're owned by other field managers.
+type FieldConflictError struct {
+	CRD string
+	// The conflicting fields, with their managers.
+	Conflicts []string
+}
+
+func (e *FieldConflictError) Error() string {
+	return fmt.Sprintf("the fields of CRD %q are owned by other field managers, force the server-side apply to take them over: %s",
+		e.CRD, strings.Join(e.Conflicts, "; "))
+}
+
+func (u *updater) apply(ctx context.Context, crd *apiextensions.CustomResourceDefinition) (util.OperationResult, error) {
+	_, err := u.Get(ctx, crd.Name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return util.OperationResultNone, err
+	}
+
+	result := util.OperationResultUpdated
+	if err != nil {
+		result = util.OperationResultCreated
+	}
+
+	data, err := applyConfiguration(crd)
+	if err != nil {
+		return util.OperationResultNone, err
+	}
+
+	_, err = u.Patch(ctx, crd.Name, types.ApplyPatchType, data, metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &u.serverSideApply.force,
+	})
+	if apierrors.IsConflict(err) {
+		return util.OperationResultNone, fieldConflictError(crd.Name, err)
+	}
+
+	if err != nil {
+		return util.OperationResultNone, errors.Wrapf(err, "error applying the CRD %q", crd.Name)
+	}
+
+	return result, nil
+}
+
+// applyConfiguration returns the apply configuration of the given CRD: only the fields we own are included.
+func applyConfiguration(crd *apiextensions.CustomResourceDefinition) ([]byte, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(crd)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error converting the CRD %q", crd.Name)
+	}
+
+	applied := &unstructured.Unstructured{Object: obj}
+	applied.SetAPIVersion(apiextensions.SchemeGroupVersion.String())
+	applied.SetKind("CustomResourceDefinition")
+	applied.SetResourceVersion("")
+	applied.SetManagedFields(nil)
+	unstructured.RemoveNestedField(applied.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(applied.Object, "status")
+
+	data, err := json.Marshal(applied.Object)
+
+	return data, errors.Wrapf(err, "error marshaling the CRD %q", crd.Name)
+}
+
+func fieldConflictError(name string, err error) error {
+	conflictErr := &FieldConflictError{CRD: name}
+
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			if cause.Type == metav1.CauseTypeFieldManagerConflict {
+				conflictErr.Conflicts = append(conflictErr.Conflicts, cause.Field+": "+cause.Message)
+			}
+		}
+	}
+
+	if len(conflictErr.Conflicts) == 0 {
+		conflictErr.Conflicts = []string{err.Error()}
+	}
+
+	return conflictErr
+}
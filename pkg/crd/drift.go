@@ -0,0 +1,73 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+func manifestChecksum(crdYaml string) string {
+	checksum := sha256.Sum256([]byte(crdYaml))
+	return hex.EncodeToString(checksum[:])
+}
+
+// specDrift describes the differences between an installed CRD spec and the desired one; nil if there are none.
+func specDrift(installed, desired *apiextensions.CustomResourceDefinitionSpec) []string {
+	var drift []string
+
+	installedVersions := map[string]*apiextensions.CustomResourceDefinitionVersion{}
+	for i := range installed.Versions {
+		installedVersions[installed.Versions[i].Name] = &installed.Versions[i]
+	}
+
+	for i := range desired.Versions {
+		version, found := installedVersions[desired.Versions[i].Name]
+
+		switch {
+		case !found:
+			drift = append(drift, fmt.Sprintf("version %s is missing", desired.Versions[i].Name))
+		case !equality.Semantic.DeepEqual(version, &desired.Versions[i]):
+			drift = append(drift, fmt.Sprintf("the schema or settings of version %s differ", desired.Versions[i].Name))
+		}
+
+		delete(installedVersions, desired.Versions[i].Name)
+	}
+
+	for i := range installed.Versions {
+		if _, found := installedVersions[installed.Versions[i].Name]; found {
+			drift = append(drift, fmt.Sprintf("version %s is not in the manifest", installed.Versions[i].Name))
+		}
+	}
+
+	installedRest := installed.DeepCopy()
+	installedRest.Versions = nil
+	desiredRest := desired.DeepCopy()
+	desiredRest.Versions = nil
+
+	if !equality.Semantic.DeepEqual(installedRest, desiredRest) {
+		drift = append(drift, "the group, names, scope or conversion differ")
+	}
+
+	return drift
+}
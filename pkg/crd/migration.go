@@ -0,0 +1,159 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+//nolint:wrapcheck // These functions are basically wrappers for the k8s APIs.
+package crd
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// A rewriter reads all the resources of a kind and writes them back unchanged, which stores them in the current
+// storage version of their CRD, and prunes any fields which are no longer part of the schema.
+type rewriter func(ctx context.Context, gvk schema.GroupVersionKind, resource string) error
+
+func dynamicRewriter(dynClient dynamic.Interface) rewriter {
+	return func(ctx context.Context, gvk schema.GroupVersionKind, resource string) error {
+		resourceClient := dynClient.Resource(gvk.GroupVersion().WithResource(resource))
+
+		list, err := resourceClient.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return err
+		}
+
+		for i := range list.Items {
+			_, err := resourceClient.Namespace(list.Items[i].GetNamespace()).Update(ctx, &list.Items[i], metav1.UpdateOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func controllerClientRewriter(controllerClient client.Client) rewriter {
+	return func(ctx context.Context, gvk schema.GroupVersionKind, _ string) error {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+
+		if err := controllerClient.List(ctx, list); err != nil {
+			return err
+		}
+
+		for i := range list.Items {
+			if err := controllerClient.Update(ctx, &list.Items[i]); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// migrateStoredVersions ensures that all the resources of the given CRD are stored in its storage version. If the CRD
+// records other stored versions, the resources are rewritten and the other versions are removed from the CRD's status.
+func (u *updater) migrateStoredVersions(ctx context.Context, name string) error {
+	crd, err := u.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	storageVersion := getStorageVersion(crd)
+	if storageVersion == "" || !needsMigration(crd, storageVersion) {
+		return nil
+	}
+
+	err = u.rewriter(ctx, schema.GroupVersionKind{Group: crd.Spec.Group, Version: storageVersion, Kind: crd.Spec.Names.Kind},
+		crd.Spec.Names.Plural)
+	if err != nil {
+		return errors.Wrapf(err, "error migrating the %s resources to version %s", crd.Spec.Names.Kind, storageVersion)
+	}
+
+	crd.Status.StoredVersions = []string{storageVersion}
+
+	_, err = u.UpdateStatus(ctx, crd, metav1.UpdateOptions{})
+
+	return errors.Wrapf(err, "error updating the stored versions of CRD %q", name)
+}
+
+func getStorageVersion(crd *apiextensions.CustomResourceDefinition) string {
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Storage {
+			return crd.Spec.Versions[i].Name
+		}
+	}
+
+	return ""
+}
+
+func needsMigration(crd *apiextensions.CustomResourceDefinition, storageVersion string) bool {
+	for _, version := range crd.Status.StoredVersions {
+		if version != storageVersion {
+			return true
+		}
+	}
+
+	return false
+}
+
+// withStoredVersions returns a copy of the desired CRD which also serves the versions, dropped from the desired CRD,
+// which are still stored according to the existing CRD; nil if there are none.
+func withStoredVersions(desired, existing *apiextensions.CustomResourceDefinition) *apiextensions.CustomResourceDefinition {
+	if existing == nil {
+		return nil
+	}
+
+	desiredVersions := map[string]bool{}
+	for i := range desired.Spec.Versions {
+		desiredVersions[desired.Spec.Versions[i].Name] = true
+	}
+
+	storedVersions := map[string]bool{}
+	for _, version := range existing.Status.StoredVersions {
+		storedVersions[version] = true
+	}
+
+	var transitional *apiextensions.CustomResourceDefinition
+
+	for i := range existing.Spec.Versions {
+		version := existing.Spec.Versions[i]
+		if desiredVersions[version.Name] || !storedVersions[version.Name] {
+			continue
+		}
+
+		if transitional == nil {
+			transitional = desired.DeepCopy()
+		}
+
+		version.Served = true
+		version.Storage = false
+		transitional.Spec.Versions = append(transitional.Spec.Versions, version)
+	}
+
+	return transitional
+}
@@ -28,16 +28,29 @@ import (
 	"github.com/submariner-io/submariner-operator/pkg/embeddedyamls"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+// ManifestChecksumAnnotation records the checksum of the embedded manifest a CRD was installed from.
+const ManifestChecksumAnnotation = "submariner.io/manifest-checksum"
+
+var log = logf.Log.WithName("crd")
+
 type baseUpdater interface {
 	Create(context.Context, *apiextensions.CustomResourceDefinition, metav1.CreateOptions) (*apiextensions.CustomResourceDefinition, error)
 	Update(context.Context, *apiextensions.CustomResourceDefinition, metav1.UpdateOptions) (*apiextensions.CustomResourceDefinition, error)
+	UpdateStatus(context.Context, *apiextensions.CustomResourceDefinition, metav1.UpdateOptions) (
+		*apiextensions.CustomResourceDefinition, error)
 	Get(context.Context, string, metav1.GetOptions) (*apiextensions.CustomResourceDefinition, error)
 	Delete(context.Context, string, metav1.DeleteOptions) error
+	Patch(context.Context, string, types.PatchType, []byte, metav1.PatchOptions, ...string) (
+		*apiextensions.CustomResourceDefinition, error)
 }
 
 type Updater interface {
@@ -47,31 +60,67 @@ type Updater interface {
 
 type updater struct {
 	baseUpdater
+	// Used to migrate the stored resources when the storage version changes; migration is skipped if nil.
+	rewriter rewriter
+	// Used to install the CRDs with server-side apply; they're created or updated if nil.
+	serverSideApply *serverSideApply
 }
 
+// Option configures an Updater.
+type Option func(*updater)
+
 type controllerClientCreator struct {
 	client client.Client
 }
 
-func UpdaterFromRestConfig(config *rest.Config) (Updater, error) {
+func UpdaterFromRestConfig(config *rest.Config, options ...Option) (Updater, error) {
 	apiext, err := clientset.NewForConfig(config)
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating the api extensions client")
 	}
 
-	return UpdaterFromClientSet(apiext), nil
+	dynClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating the dynamic client")
+	}
+
+	return UpdaterFromClientSets(apiext, dynClient, options...), nil
 }
 
-func UpdaterFromClientSet(cs clientset.Interface) Updater {
-	return &updater{baseUpdater: cs.ApiextensionsV1().CustomResourceDefinitions()}
+// UpdaterFromClientSet returns an Updater which doesn't migrate the stored resources.
+func UpdaterFromClientSet(cs clientset.Interface, options ...Option) Updater {
+	return withOptions(&updater{baseUpdater: cs.ApiextensionsV1().CustomResourceDefinitions()}, options)
 }
 
-func UpdaterFromControllerClient(controllerClient client.Client) Updater {
-	return &updater{baseUpdater: &controllerClientCreator{
-		client: controllerClient,
-	}}
+// UpdaterFromClientSets returns an Updater which uses the dynamic client to migrate the stored resources.
+func UpdaterFromClientSets(cs clientset.Interface, dynClient dynamic.Interface, options ...Option) Updater {
+	return withOptions(&updater{
+		baseUpdater: cs.ApiextensionsV1().CustomResourceDefinitions(),
+		rewriter:    dynamicRewriter(dynClient),
+	}, options)
+}
+
+func UpdaterFromControllerClient(controllerClient client.Client, options ...Option) Updater {
+	return withOptions(&updater{
+		baseUpdater: &controllerClientCreator{
+			client: controllerClient,
+		},
+		rewriter: controllerClientRewriter(controllerClient),
+	}, options)
+}
+
+func withOptions(u *updater, options []Option) *updater {
+	for _, option := range options {
+		option(u)
+	}
+
+	return u
 }
 
+// CreateOrUpdateFromEmbedded creates or updates the embedded CRD. An installed CRD is only updated if it drifted from
+// the embedded manifest, e.g. because it was installed by a previous version or modified in the cluster; the drift is
+// logged. If the CRD's storage version changes, the existing resources are migrated to the new storage version, see
+// migrateStoredVersions.
 func (u *updater) CreateOrUpdateFromEmbedded(ctx context.Context, crdYaml string) (bool, error) {
 	crd := &apiextensions.CustomResourceDefinition{}
 
@@ -79,14 +128,75 @@ func (u *updater) CreateOrUpdateFromEmbedded(ctx context.Context, crdYaml string
 		return false, errors.Wrap(err, "error extracting embedded CRD")
 	}
 
-	result, err := util.CreateOrUpdate[*apiextensions.CustomResourceDefinition](
+	// The API server defaults the spec, defaulting it here too avoids reporting the defaults as drift
+	apiextensions.SetDefaults_CustomResourceDefinitionSpec(&crd.Spec)
+	metav1.SetMetaDataAnnotation(&crd.ObjectMeta, ManifestChecksumAnnotation, manifestChecksum(crdYaml))
+
+	if u.rewriter != nil {
+		// Versions which are dropped but still stored must be kept until the resources are migrated
+		existing, err := u.Get(ctx, crd.Name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return false, err
+		}
+
+		if transitional := withStoredVersions(crd, existing); transitional != nil {
+			if _, err := u.write(ctx, transitional); err != nil {
+				return false, err
+			}
+
+			if err := u.migrateStoredVersions(ctx, crd.Name); err != nil {
+				return false, err
+			}
+		}
+	}
+
+	result, err := u.write(ctx, crd)
+	if err != nil {
+		return false, err
+	}
+
+	if u.rewriter != nil {
+		err = u.migrateStoredVersions(ctx, crd.Name)
+	}
+
+	return result == util.OperationResultCreated, err
+}
+
+func (u *updater) write(ctx context.Context, crd *apiextensions.CustomResourceDefinition) (util.OperationResult, error) {
+	if u.serverSideApply != nil {
+		return u.apply(ctx, crd)
+	}
+
+	return u.createOrUpdate(ctx, crd)
+}
+
+func (u *updater) createOrUpdate(ctx context.Context, crd *apiextensions.CustomResourceDefinition) (util.OperationResult, error) {
+	return util.CreateOrUpdate[*apiextensions.CustomResourceDefinition](
 		ctx, &resource.InterfaceFuncs[*apiextensions.CustomResourceDefinition]{
 			GetFunc:    u.Get,
 			CreateFunc: u.Create,
 			UpdateFunc: u.Update,
-		}, crd, util.Replace(crd))
+		}, crd, func(existing *apiextensions.CustomResourceDefinition) (*apiextensions.CustomResourceDefinition, error) {
+			if drift := specDrift(&existing.Spec, &crd.Spec); len(drift) > 0 {
+				log.Info("Repairing the drift of the installed CRD from its manifest", "CRD", crd.Name,
+					"manifestChanged", existing.Annotations[ManifestChecksumAnnotation] != crd.Annotations[ManifestChecksumAnnotation],
+					"drift", drift)
+			}
 
-	return result == util.OperationResultCreated, err
+			// Keep the metadata set by others, e.g. OLM
+			updated := existing.DeepCopy()
+			updated.Spec = crd.Spec
+
+			for k, v := range crd.Labels {
+				metav1.SetMetaDataLabel(&updated.ObjectMeta, k, v)
+			}
+
+			for k, v := range crd.Annotations {
+				metav1.SetMetaDataAnnotation(&updated.ObjectMeta, k, v)
+			}
+
+			return updated, nil
+		})
 }
 
 func (c *controllerClientCreator) Create(ctx context.Context, crd *apiextensions.CustomResourceDefinition,
@@ -105,6 +215,13 @@ func (c *controllerClientCreator) Update(ctx context.Context, crd *apiextensions
 	return crd, err
 }
 
+func (c *controllerClientCreator) UpdateStatus(ctx context.Context, crd *apiextensions.CustomResourceDefinition,
+	_ metav1.UpdateOptions, //nolint:gocritic // hugeParam - match K8s API
+) (*apiextensions.CustomResourceDefinition, error) {
+	err := c.client.Status().Update(ctx, crd)
+	return crd, err
+}
+
 func (c *controllerClientCreator) Get(ctx context.Context, name string,
 	_ metav1.GetOptions,
 ) (*apiextensions.CustomResourceDefinition, error) {
@@ -118,6 +235,20 @@ func (c *controllerClientCreator) Get(ctx context.Context, name string,
 	return crd, nil
 }
 
+func (c *controllerClientCreator) Patch(ctx context.Context, name string, pt types.PatchType, data []byte,
+	options metav1.PatchOptions, //nolint:gocritic // hugeParam - match K8s API
+	_ ...string,
+) (*apiextensions.CustomResourceDefinition, error) {
+	crd := &apiextensions.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	err := c.client.Patch(ctx, crd, client.RawPatch(pt, data), &client.PatchOptions{
+		DryRun:       options.DryRun,
+		Force:        options.Force,
+		FieldManager: options.FieldManager,
+	})
+
+	return crd, err
+}
+
 func (c *controllerClientCreator) Delete(ctx context.Context, name string,
 	_ metav1.DeleteOptions, //nolint:gocritic // Match K8s API
 ) error {
@@ -20,13 +20,21 @@ package crd_test
 
 import (
 	"context"
+	"errors"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/submariner-io/submariner-operator/pkg/crd"
 	apiextensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	extendedfakeclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/testing"
 )
 
 const (
@@ -40,6 +48,38 @@ spec:
   names:
     kind: Submariner
 `
+
+	v1alpha1CRDYAML = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: submariners.submariner.io
+spec:
+  group: submariner.io
+  names:
+    kind: Submariner
+    plural: submariners
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+`
+
+	v1CRDYAML = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: submariners.submariner.io
+spec:
+  group: submariner.io
+  names:
+    kind: Submariner
+    plural: submariners
+  versions:
+  - name: v1
+    served: true
+    storage: true
+`
 )
 
 var _ = Describe("Updater", func() {
@@ -85,11 +125,12 @@ var _ = Describe("Updater", func() {
 			})
 		})
 
-		When("the CRD already exists", func() {
+		When("the CRD is already installed from the same manifest", func() {
 			It("should not update it", func(ctx SpecContext) {
-				_, err := updater.Create(ctx, crd, metav1.CreateOptions{})
+				_, err := updater.CreateOrUpdateFromEmbedded(ctx, crdYAML)
 				Expect(err).To(Succeed())
-				assertCRDExists(ctx, crd.Name)
+
+				client.ClearActions()
 
 				created, err := updater.CreateOrUpdateFromEmbedded(ctx, crdYAML)
 				Expect(created).To(BeFalse())
@@ -101,5 +142,176 @@ var _ = Describe("Updater", func() {
 				}
 			})
 		})
+
+		When("the installed CRD drifted from the manifest", func() {
+			It("should repair it and keep its other metadata", func(ctx SpecContext) {
+				existing := crd.DeepCopy()
+				existing.Annotations = map[string]string{"other": "value"}
+				existing.Spec.Names.Plural = "stale"
+
+				_, err := updater.Create(ctx, existing, metav1.CreateOptions{})
+				Expect(err).To(Succeed())
+
+				created, err := updater.CreateOrUpdateFromEmbedded(ctx, crdYAML)
+				Expect(created).To(BeFalse())
+				Expect(err).To(Succeed())
+
+				actual, err := updater.Get(ctx, crd.Name, metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(actual.Spec.Names.Plural).To(BeEmpty())
+				Expect(actual.Annotations).To(HaveKeyWithValue("other", "value"))
+				Expect(actual.Annotations).To(HaveKey("submariner.io/manifest-checksum"))
+			})
+		})
+	})
+
+	Context("on CreateOrUpdate with server-side apply", func() {
+		BeforeEach(func(ctx SpecContext) {
+			// The fake clientset can only apply to existing objects
+			_, err := updater.Create(ctx, &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "submariners.submariner.io",
+					Annotations: map[string]string{"meta.helm.sh/release-name": "submariner"},
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group: "submariner.io",
+					Names: apiextensions.CustomResourceDefinitionNames{Kind: "Stale"},
+				},
+			}, metav1.CreateOptions{})
+			Expect(err).To(Succeed())
+
+			client.ClearActions()
+
+			updater = crd.UpdaterFromClientSet(client, crd.WithServerSideApply(false))
+		})
+
+		It("should apply the CRD and keep the metadata set by others", func(ctx SpecContext) {
+			created, err := updater.CreateOrUpdateFromEmbedded(ctx, crdYAML)
+			Expect(err).To(Succeed())
+			Expect(created).To(BeFalse())
+
+			for _, action := range client.Actions() {
+				Expect(action.GetVerb()).ToNot(Equal("update"))
+
+				if patch, ok := action.(testing.PatchAction); ok {
+					Expect(patch.GetPatchType()).To(Equal(types.ApplyPatchType))
+				}
+			}
+
+			actual, err := updater.Get(ctx, "submariners.submariner.io", metav1.GetOptions{})
+			Expect(err).To(Succeed())
+			Expect(actual.Spec.Names.Kind).To(Equal("Submariner"))
+			Expect(actual.Annotations).To(HaveKey("meta.helm.sh/release-name"))
+			Expect(actual.Annotations).To(HaveKey("submariner.io/manifest-checksum"))
+		})
+
+		When("fields are owned by another field manager", func() {
+			BeforeEach(func() {
+				client.PrependReactor("patch", "customresourcedefinitions", func(_ testing.Action) (bool, runtime.Object, error) {
+					return true, nil, apierrors.NewApplyConflict([]metav1.StatusCause{{
+						Type:    metav1.CauseTypeFieldManagerConflict,
+						Field:   ".spec.names.kind",
+						Message: `conflict with "helm"`,
+					}}, "Apply failed with 1 conflict")
+				})
+			})
+
+			It("should report them", func(ctx SpecContext) {
+				_, err := updater.CreateOrUpdateFromEmbedded(ctx, crdYAML)
+
+				conflictErr := &crd.FieldConflictError{}
+				Expect(errors.As(err, &conflictErr)).To(BeTrue())
+				Expect(conflictErr.CRD).To(Equal("submariners.submariner.io"))
+				Expect(conflictErr.Conflicts).To(Equal([]string{`.spec.names.kind: conflict with "helm"`}))
+			})
+		})
+	})
+
+	Context("on CreateOrUpdate with migration", func() {
+		var dynClient *dynamicfake.FakeDynamicClient
+
+		gvr := schema.GroupVersionResource{Group: "submariner.io", Version: "v1", Resource: "submariners"}
+
+		BeforeEach(func() {
+			existing := &apiextensions.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "submariners.submariner.io",
+				},
+				Spec: apiextensions.CustomResourceDefinitionSpec{
+					Group: "submariner.io",
+					Names: apiextensions.CustomResourceDefinitionNames{
+						Kind:   "Submariner",
+						Plural: "submariners",
+					},
+					Versions: []apiextensions.CustomResourceDefinitionVersion{
+						{Name: "v1alpha1", Served: true, Storage: true},
+					},
+				},
+				Status: apiextensions.CustomResourceDefinitionStatus{
+					StoredVersions: []string{"v1alpha1"},
+				},
+			}
+
+			client = extendedfakeclientset.NewSimpleClientset(existing)
+
+			// Like the API server, ignore status changes on update
+			client.PrependReactor("update", "customresourcedefinitions", func(action testing.Action) (bool, runtime.Object, error) {
+				update := action.(testing.UpdateAction)
+				if update.GetSubresource() != "" {
+					return false, nil, nil
+				}
+
+				crd := update.GetObject().(*apiextensions.CustomResourceDefinition)
+				current, err := client.Tracker().Get(action.GetResource(), "", crd.Name)
+				Expect(err).To(Succeed())
+
+				crd.Status = current.(*apiextensions.CustomResourceDefinition).Status
+
+				return false, nil, nil
+			})
+
+			submariner := &unstructured.Unstructured{}
+			submariner.SetAPIVersion("submariner.io/v1")
+			submariner.SetKind("Submariner")
+			submariner.SetNamespace("submariner-operator")
+			submariner.SetName("submariner")
+
+			dynClient = dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{gvr: "SubmarinerList"}, submariner)
+
+			updater = crd.UpdaterFromClientSets(client, dynClient)
+		})
+
+		When("the storage version changes and the previous version is dropped", func() {
+			It("should migrate the stored resources", func(ctx SpecContext) {
+				_, err := updater.CreateOrUpdateFromEmbedded(ctx, v1CRDYAML)
+				Expect(err).To(Succeed())
+
+				updated, err := updater.Get(ctx, "submariners.submariner.io", metav1.GetOptions{})
+				Expect(err).To(Succeed())
+				Expect(updated.Status.StoredVersions).To(Equal([]string{"v1"}))
+				Expect(updated.Spec.Versions).To(HaveLen(1))
+				Expect(updated.Spec.Versions[0].Name).To(Equal("v1"))
+
+				var rewritten bool
+
+				for _, action := range dynClient.Actions() {
+					rewritten = rewritten || (action.GetVerb() == "update" && action.GetResource() == gvr)
+				}
+
+				Expect(rewritten).To(BeTrue())
+			})
+		})
+
+		When("the stored versions only contain the storage version", func() {
+			It("should not rewrite the resources", func(ctx SpecContext) {
+				_, err := updater.CreateOrUpdateFromEmbedded(ctx, v1alpha1CRDYAML)
+				Expect(err).To(Succeed())
+
+				for _, action := range dynClient.Actions() {
+					Expect(action.GetVerb()).ToNot(Equal("update"))
+				}
+			})
+		})
 	})
 })
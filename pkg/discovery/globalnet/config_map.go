@@ -32,7 +32,7 @@ import (
 )
 
 const (
-	globalCIDRConfigMapName     = "submariner-globalnet-info"
+	GlobalCIDRConfigMapName     = "submariner-globalnet-info"
 	globalnetEnabledKey         = "globalnetEnabled"
 	clusterInfoKey              = "clusterinfo"
 	globalnetCidrRange          = "globalnetCidrRange"
@@ -91,7 +91,7 @@ func NewGlobalnetConfigMap(globalnetEnabled bool, defaultGlobalCidrRange string,
 
 	cm := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      globalCIDRConfigMapName,
+			Name:      GlobalCIDRConfigMapName,
 			Namespace: namespace,
 			Labels:    labels,
 		},
@@ -137,13 +137,13 @@ func updateConfigMap(ctx context.Context, client controllerClient.Client, config
 //nolint:wrapcheck // No need to wrap here
 func GetConfigMap(ctx context.Context, client controllerClient.Client, namespace string) (*corev1.ConfigMap, error) {
 	cm := &corev1.ConfigMap{}
-	return cm, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: globalCIDRConfigMapName}, cm)
+	return cm, client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: GlobalCIDRConfigMapName}, cm)
 }
 
 //nolint:wrapcheck // No need to wrap here
 func DeleteConfigMap(ctx context.Context, client controllerClient.Client, namespace string) error {
 	return client.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
-		Name:      globalCIDRConfigMapName,
+		Name:      GlobalCIDRConfigMapName,
 		Namespace: namespace,
 	}})
 }
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"math/bits"
 	"net"
+	"sort"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/admiral/pkg/reporter"
@@ -87,6 +88,63 @@ func isOverlappingCIDR(cidrList []string, cidr string) (bool, error) {
 	return false, nil
 }
 
+// FindAllocationConflicts returns a description of each problem found in the allocated global CIDRs: CIDRs which can't be
+// parsed, CIDRs outside the globalnet CIDR range, and CIDRs overlapping those allocated to another cluster.
+func FindAllocationConflicts(globalnetInfo *Info) []string {
+	var (
+		conflicts []string
+		rangeNet  *net.IPNet
+	)
+
+	if globalnetInfo.CidrRange != "" {
+		_, rangeNet, _ = net.ParseCIDR(globalnetInfo.CidrRange)
+	}
+
+	clusterIDs := make([]string, 0, len(globalnetInfo.CidrInfo))
+	for clusterID := range globalnetInfo.CidrInfo {
+		clusterIDs = append(clusterIDs, clusterID)
+	}
+
+	sort.Strings(clusterIDs)
+
+	allocated := map[string]string{}
+
+	for _, clusterID := range clusterIDs {
+		for _, cidr := range globalnetInfo.CidrInfo[clusterID].GlobalCIDRs {
+			_, cidrNet, err := net.ParseCIDR(cidr)
+			if err != nil {
+				conflicts = append(conflicts, fmt.Sprintf("cluster %q has an invalid global CIDR %q", clusterID, cidr))
+				continue
+			}
+
+			if rangeNet != nil && !containsNet(rangeNet, cidrNet) {
+				conflicts = append(conflicts, fmt.Sprintf("the global CIDR %q of cluster %q is outside the globalnet CIDR range %q",
+					cidr, clusterID, globalnetInfo.CidrRange))
+			}
+
+			for other, otherCluster := range allocated {
+				if overlapping, _ := isOverlappingCIDR([]string{other}, cidr); overlapping && otherCluster != clusterID {
+					conflicts = append(conflicts, fmt.Sprintf("the global CIDR %q of cluster %q overlaps %q of cluster %q",
+						cidr, clusterID, other, otherCluster))
+				}
+			}
+
+			allocated[cidr] = clusterID
+		}
+	}
+
+	sort.Strings(conflicts)
+
+	return conflicts
+}
+
+func containsNet(outer, inner *net.IPNet) bool {
+	outerOnes, _ := outer.Mask.Size()
+	innerOnes, _ := inner.Mask.Size()
+
+	return outer.Contains(inner.IP) && innerOnes >= outerOnes
+}
+
 func NewCIDR(cidr string) (CIDR, error) {
 	_, network, err := net.ParseCIDR(cidr)
 	if err != nil {
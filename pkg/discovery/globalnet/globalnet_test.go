@@ -400,3 +400,58 @@ var _ = Describe("ValidateExistingGlobalNetworks", func() {
 		})
 	})
 })
+
+var _ = Describe("FindAllocationConflicts", func() {
+	var globalnetInfo *globalnet.Info
+
+	BeforeEach(func() {
+		globalnetInfo = &globalnet.Info{
+			Enabled:   true,
+			CidrRange: "242.0.0.0/8",
+			CidrInfo: map[string]*globalnet.GlobalNetwork{
+				"east": {ClusterID: "east", GlobalCIDRs: []string{"242.0.0.0/16"}},
+				"west": {ClusterID: "west", GlobalCIDRs: []string{"242.1.0.0/16"}},
+			},
+		}
+	})
+
+	When("the allocations are distinct and within the range", func() {
+		It("should not return any conflicts", func() {
+			Expect(globalnet.FindAllocationConflicts(globalnetInfo)).To(BeEmpty())
+		})
+	})
+
+	When("two clusters' allocations overlap", func() {
+		BeforeEach(func() {
+			globalnetInfo.CidrInfo["west"].GlobalCIDRs = []string{"242.0.128.0/17"}
+		})
+
+		It("should return the conflict", func() {
+			conflicts := globalnet.FindAllocationConflicts(globalnetInfo)
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0]).To(ContainSubstring("overlaps"))
+		})
+	})
+
+	When("an allocation is outside the range", func() {
+		BeforeEach(func() {
+			globalnetInfo.CidrInfo["west"].GlobalCIDRs = []string{"243.0.0.0/16"}
+		})
+
+		It("should return the conflict", func() {
+			conflicts := globalnet.FindAllocationConflicts(globalnetInfo)
+			Expect(conflicts).To(HaveLen(1))
+			Expect(conflicts[0]).To(ContainSubstring("outside the globalnet CIDR range"))
+		})
+	})
+
+	When("an allocation is invalid", func() {
+		BeforeEach(func() {
+			globalnetInfo.CidrInfo["west"].GlobalCIDRs = []string{"bogus"}
+		})
+
+		It("should return the conflict", func() {
+			Expect(globalnet.FindAllocationConflicts(globalnetInfo)).To(ConsistOf(ContainSubstring("invalid global CIDR")))
+		})
+	})
+})
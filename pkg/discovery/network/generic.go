@@ -20,16 +20,27 @@ package network
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/submariner/pkg/cni"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	kubeadmConfigNamespace = "kube-system"
+	kubeadmConfigName      = "kubeadm-config"
+	k3sNodeArgsAnnotation  = "k3s.io/node-args"
 )
 
 //nolint:nilnil // Intentional as the purpose is to discover.
@@ -82,6 +93,17 @@ func findClusterIPRange(ctx context.Context, client controllerClient.Client) (st
 		return clusterIPRange, err
 	}
 
+	clusterIPRange, err = findClusterIPRangeFromKubeadm(ctx, client)
+	if err != nil || clusterIPRange != "" {
+		return clusterIPRange, err
+	}
+
+	clusterIPRange, err = findClusterIPRangeFromK3sNodeArgs(ctx, client)
+	if err != nil || clusterIPRange != "" {
+		return clusterIPRange, err
+	}
+
+	// As a last resort, probe the apiserver; this works on managed clusters, e.g. EKS, which expose neither
 	clusterIPRange, err = findClusterIPRangeFromServiceCreation(ctx, client)
 	if err != nil || clusterIPRange != "" {
 		return clusterIPRange, err
@@ -94,9 +116,72 @@ func findClusterIPRangeFromApiserver(ctx context.Context, client controllerClien
 	return FindPodCommandParameter(ctx, client, "component=kube-apiserver", "--service-cluster-ip-range")
 }
 
+func findClusterIPRangeFromKubeadm(ctx context.Context, client controllerClient.Client) (string, error) {
+	configMap := &corev1.ConfigMap{}
+
+	err := client.Get(ctx, types.NamespacedName{Namespace: kubeadmConfigNamespace, Name: kubeadmConfigName}, configMap)
+	if apierrors.IsNotFound(err) {
+		return "", nil
+	}
+
+	if err != nil {
+		return "", errors.WithMessagef(err, "error retrieving the %q ConfigMap", kubeadmConfigName)
+	}
+
+	clusterConfiguration := struct {
+		Networking struct {
+			ServiceSubnet string `json:"serviceSubnet"`
+		} `json:"networking"`
+	}{}
+
+	// An unparseable configuration is ignored, the remaining methods are tried instead
+	if yaml.Unmarshal([]byte(configMap.Data["ClusterConfiguration"]), &clusterConfiguration) != nil {
+		return "", nil
+	}
+
+	return firstCIDR(clusterConfiguration.Networking.ServiceSubnet), nil
+}
+
+// findClusterIPRangeFromK3sNodeArgs looks for the --service-cidr argument which k3s servers record on their node.
+func findClusterIPRangeFromK3sNodeArgs(ctx context.Context, client controllerClient.Client) (string, error) {
+	nodes := &corev1.NodeList{}
+
+	err := client.List(ctx, nodes)
+	if err != nil {
+		return "", errors.WithMessagef(err, "error listing nodes")
+	}
+
+	for i := range nodes.Items {
+		var args []string
+
+		if json.Unmarshal([]byte(nodes.Items[i].Annotations[k3sNodeArgsAnnotation]), &args) != nil {
+			continue
+		}
+
+		for j, arg := range args {
+			if arg == "--service-cidr" && j+1 < len(args) {
+				return firstCIDR(args[j+1]), nil
+			}
+
+			if value, found := strings.CutPrefix(arg, "--service-cidr="); found {
+				return firstCIDR(value), nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// firstCIDR returns the first of a comma-separated list of CIDRs; dual-stack clusters list a CIDR for each IP family.
+func firstCIDR(cidrs string) string {
+	return strings.TrimSpace(strings.Split(cidrs, ",")[0])
+}
+
 func findClusterIPRangeFromServiceCreation(ctx context.Context, client controllerClient.Client) (string, error) {
-	ns := os.Getenv("WATCH_NAMESPACE")
-	// WATCH_NAMESPACE env should be set to operator's namespace, if running in operator
+	// WATCH_NAMESPACE env should be set to operator's namespace, if running in operator; it comes first if the operator
+	// watches several namespaces
+	ns, _, _ := strings.Cut(os.Getenv("WATCH_NAMESPACE"), ",")
+	ns = strings.TrimSpace(ns)
 	if ns == "" {
 		// otherwise, it should be called from subctl command, so use "default" namespace
 		ns = "default"
@@ -126,6 +211,11 @@ func findClusterIPRangeFromServiceCreation(ctx context.Context, client controlle
 
 	// creating invalid service didn't fail as expected
 	if err == nil {
+		if err := client.Delete(ctx, invalidSvcSpec); err != nil && !apierrors.IsNotFound(err) {
+			return "", errors.WithMessagef(err, "error deleting the %q Service created to probe the service IP range",
+				invalidSvcSpec.Name)
+		}
+
 		return "", fmt.Errorf("could not determine the service IP range via service creation - " +
 			"expected a specific error but none was returned")
 	}
@@ -140,7 +230,7 @@ func parseServiceCIDRFrom(msg string) (string, error) {
 	//   The range of valid IPs is 10.45.0.0/16"
 	// expected matched string is below:
 	//   10.45.0.0/16
-	re := regexp.MustCompile(".*valid IPs is (.*)$")
+	re := regexp.MustCompile(`valid IPs is ([0-9a-fA-F.:]+/[0-9]+)`)
 
 	match := re.FindStringSubmatch(msg)
 	if match == nil {
@@ -179,6 +269,10 @@ func findPodIPRangeKubeProxy(ctx context.Context, client controllerClient.Client
 	return FindPodCommandParameter(ctx, client, "component=kube-proxy", "--cluster-cidr")
 }
 
+func findKubeProxyMode(ctx context.Context, client controllerClient.Client) (string, error) {
+	return FindPodCommandParameter(ctx, client, "component=kube-proxy", "--proxy-mode")
+}
+
 func findPodIPRangeFromNodeSpec(ctx context.Context, client controllerClient.Client) (string, error) {
 	nodes := &corev1.NodeList{}
 
@@ -21,6 +21,7 @@ package network_test
 import (
 	"context"
 	"fmt"
+	"os"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -28,6 +29,7 @@ import (
 	"github.com/submariner-io/submariner-operator/pkg/discovery/network"
 	"github.com/submariner-io/submariner/pkg/cni"
 	corev1 "k8s.io/api/core/v1"
+	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
 	fakeClient "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -160,6 +162,22 @@ var _ = Describe("Generic Network", func() {
 		})
 	})
 
+	When("There is a kube-proxy pod with a proxy mode", func() {
+		var clusterNet *network.ClusterNetwork
+
+		BeforeEach(func(ctx SpecContext) {
+			clusterNet = testDiscoverGenericWith(
+				ctx,
+				fakePod("kube-proxy", []string{"kube-proxy", "--cluster-cidr=" + testPodCIDR, "--proxy-mode=ipvs"}, []corev1.EnvVar{}),
+			)
+			Expect(clusterNet).NotTo(BeNil())
+		})
+
+		It("Should return the ClusterNetwork structure with the kube-proxy mode", func() {
+			Expect(clusterNet.KubeProxyMode).To(Equal("ipvs"))
+		})
+	})
+
 	When("There is a kubeapi pod", func() {
 		var clusterNet *network.ClusterNetwork
 
@@ -290,12 +308,41 @@ var _ = Describe("Generic Network", func() {
 		})
 	})
 
+	When("No kube-api pod exists and the kubeadm configuration is available", func() {
+		It("Should return the ClusterNetwork structure with the kubeadm service subnet", func(ctx SpecContext) {
+			clusterNet := testDiscoverGenericWith(ctx, &corev1.ConfigMap{
+				ObjectMeta: v1meta.ObjectMeta{Name: "kubeadm-config", Namespace: "kube-system"},
+				Data: map[string]string{
+					"ClusterConfiguration": "apiVersion: kubeadm.k8s.io/v1beta3\nnetworking:\n  serviceSubnet: " +
+						testServiceCIDR + ",fd00:10:96::/112\n",
+				},
+			})
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+		})
+	})
+
+	When("No kube-api pod exists and a k3s server records its arguments", func() {
+		It("Should return the ClusterNetwork structure with the k3s service CIDR", func(ctx SpecContext) {
+			clusterNet := testDiscoverGenericWith(ctx, &corev1.Node{
+				ObjectMeta: v1meta.ObjectMeta{
+					Name:        "server",
+					Annotations: map[string]string{"k3s.io/node-args": `["server","--service-cidr","` + testServiceCIDR + `"]`},
+				},
+			})
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDR}))
+		})
+	})
+
 	When("No kube-api pod exists and invalid service creation returns no error", func() {
 		It("Should return error and nil cluster network", func(ctx SpecContext) {
 			client := fakeClient.NewClientBuilder().WithScheme(scheme.Scheme).Build()
 			clusterNet, err := network.Discover(ctx, client, "")
 			Expect(err).To(HaveOccurred())
 			Expect(clusterNet).To(BeNil())
+
+			services := &corev1.ServiceList{}
+			Expect(client.List(ctx, services)).To(Succeed())
+			Expect(services.Items).To(BeEmpty())
 		})
 	})
 
@@ -311,6 +358,25 @@ var _ = Describe("Generic Network", func() {
 		})
 	})
 
+	When("No kube-api pod exists and the operator watches several namespaces", func() {
+		It("Should create the invalid service in the operator's namespace", func(ctx SpecContext) {
+			Expect(os.Setenv("WATCH_NAMESPACE", "submariner-operator, clusterset-a")).To(Succeed())
+			DeferCleanup(os.Unsetenv, "WATCH_NAMESPACE")
+
+			namespace := ""
+			client := fake.NewReactingClient(fakeClient.NewClientBuilder().WithScheme(scheme.Scheme).Build()).
+				AddReactor(fake.Create, &corev1.Service{}, func(obj interface{}) (bool, error) {
+					namespace = obj.(*corev1.Service).Namespace
+					return true, invalidServiceError()
+				})
+
+			clusterNet, err := network.Discover(ctx, client, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusterNet.ServiceCIDRs).To(Equal([]string{testServiceCIDRFromService}))
+			Expect(namespace).To(Equal("submariner-operator"))
+		})
+	})
+
 	When("No kube-api pod exists and invalid service creation returns the expected error", func() {
 		var clusterNet *network.ClusterNetwork
 
@@ -343,8 +409,12 @@ func testDiscoverGenericWith(ctx context.Context, objects ...controllerClient.Ob
 func newTestClient(objects ...controllerClient.Object) controllerClient.Client {
 	// Inject error for create services to return expectedErr
 	return fake.NewReactingClient(fakeClient.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objects...).Build()).
-		AddReactor(fake.Create, &corev1.Service{}, fake.FailingReaction(fmt.Errorf("The Service \"invalid-svc\" is invalid: "+
-			"spec.clusterIPs: Invalid value: []string{\"1.1.1.1\"}: failed to "+
-			"allocated ip:1.1.1.1 with error:provided IP is not in the valid range. "+
-			"The range of valid IPs is %s", testServiceCIDRFromService)))
+		AddReactor(fake.Create, &corev1.Service{}, fake.FailingReaction(invalidServiceError()))
+}
+
+func invalidServiceError() error {
+	return fmt.Errorf("The Service \"invalid-svc\" is invalid: "+
+		"spec.clusterIPs: Invalid value: []string{\"1.1.1.1\"}: failed to "+
+		"allocated ip:1.1.1.1 with error:provided IP is not in the valid range. "+
+		"The range of valid IPs is %s", testServiceCIDRFromService)
 }
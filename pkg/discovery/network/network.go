@@ -28,13 +28,17 @@ import (
 	"github.com/submariner-io/submariner-operator/pkg/names"
 	"k8s.io/apimachinery/pkg/types"
 	controllerClient "sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+var log = logf.Log.WithName("network")
+
 type ClusterNetwork struct {
 	PodCIDRs       []string
 	ServiceCIDRs   []string
 	NetworkPlugin  string
 	GlobalCIDR     string
+	KubeProxyMode  string
 	PluginSettings map[string]string
 }
 
@@ -49,6 +53,10 @@ func (cn *ClusterNetwork) Show() {
 		if cn.GlobalCIDR != "" {
 			fmt.Printf("        Global CIDR:     %v\n", cn.GlobalCIDR)
 		}
+
+		if cn.KubeProxyMode != "" {
+			fmt.Printf("        Kube-proxy mode: %v\n", cn.KubeProxyMode)
+		}
 	}
 }
 
@@ -56,7 +64,8 @@ func (cn *ClusterNetwork) Log(logger logr.Logger) {
 	logger.Info("Discovered K8s network details",
 		"plugin", cn.NetworkPlugin,
 		"clusterCIDRs", cn.PodCIDRs,
-		"serviceCIDRs", cn.ServiceCIDRs)
+		"serviceCIDRs", cn.ServiceCIDRs,
+		"kubeProxyMode", cn.KubeProxyMode)
 }
 
 func (cn *ClusterNetwork) IsComplete() bool {
@@ -64,6 +73,21 @@ func (cn *ClusterNetwork) IsComplete() bool {
 }
 
 func Discover(ctx context.Context, client controllerClient.Client, operatorNamespace string) (*ClusterNetwork, error) {
+	discovery, err := discover(ctx, client, operatorNamespace)
+	if err != nil || discovery == nil {
+		return discovery, err
+	}
+
+	// The kube-proxy mode is informational, failing to find it doesn't fail the discovery
+	discovery.KubeProxyMode, err = findKubeProxyMode(ctx, client)
+	if err != nil {
+		log.Error(err, "Error finding the kube-proxy mode")
+	}
+
+	return discovery, nil
+}
+
+func discover(ctx context.Context, client controllerClient.Client, operatorNamespace string) (*ClusterNetwork, error) {
 	discovery, err := networkPluginsDiscovery(ctx, client)
 	if err != nil {
 		return nil, err
@@ -34,6 +34,9 @@ var files = []string{
 	"deploy/crds/submariner.io_brokers.yaml",
 	"deploy/crds/submariner.io_submariners.yaml",
 	"deploy/crds/submariner.io_servicediscoveries.yaml",
+	"deploy/crds/submariner.io_clusternetworks.yaml",
+	"deploy/crds/submariner.io_verificationruns.yaml",
+	"deploy/crds/submariner.io_joinrequests.yaml",
 	"deploy/submariner/crds/submariner.io_clusters.yaml",
 	"deploy/submariner/crds/submariner.io_endpoints.yaml",
 	"deploy/submariner/crds/submariner.io_gateways.yaml",
@@ -50,6 +53,7 @@ var files = []string{
 	"config/broker/broker-client/service_account.yaml",
 	"config/broker/broker-client/role.yaml",
 	"config/broker/broker-client/role_binding.yaml",
+	"config/broker/broker-observer/role.yaml",
 	"config/rbac/submariner-operator/service_account.yaml",
 	"config/rbac/submariner-operator/role.yaml",
 	"config/rbac/submariner-operator/role_binding.yaml",
@@ -78,6 +82,9 @@ var files = []string{
 	"config/rbac/submariner-globalnet/cluster_role_binding.yaml",
 	"config/rbac/submariner-globalnet/ocp_cluster_role.yaml",
 	"config/rbac/submariner-globalnet/ocp_cluster_role_binding.yaml",
+	"config/rbac/submariner-metrics-proxy/service_account.yaml",
+	"config/rbac/submariner-metrics-proxy/cluster_role.yaml",
+	"config/rbac/submariner-metrics-proxy/cluster_role_binding.yaml",
 	"config/rbac/submariner-diagnose/service_account.yaml",
 	"config/rbac/submariner-diagnose/role.yaml",
 	"config/rbac/submariner-diagnose/role_binding.yaml",
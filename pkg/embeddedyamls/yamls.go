@@ -30,13 +30,32 @@ metadata:
 spec:
   group: submariner.io
   names:
+    categories:
+    - submariner-io
     kind: Broker
     listKind: BrokerList
     plural: brokers
     singular: broker
   scope: Namespaced
   versions:
-  - name: v1alpha1
+  - additionalPrinterColumns:
+    - jsonPath: .spec.components
+      name: Components
+      type: string
+    - jsonPath: .spec.globalnetEnabled
+      name: Globalnet
+      type: boolean
+    - jsonPath: .spec.globalnetCIDRRange
+      name: Global CIDR Range
+      type: string
+    - jsonPath: .status.objectCountWarnings
+      name: Object Count Warnings
+      priority: 1
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
     schema:
       openAPIV3Schema:
         description: Broker is the Schema for the brokers API.
@@ -61,6 +80,67 @@ spec:
           spec:
             description: BrokerSpec defines the desired state of Broker.
             properties:
+              clusterEnrollment:
+                description: |-
+                  Require the enrollment of the clusters joining the broker to be approved, so that the broker connection
+                  information alone isn't enough to join the clusterset. Until a cluster is approved, the role bindings of its
+                  service account are removed and it's listed in the pendingClusters status. Clusters which joined before
+                  enrollment approval was required must be approved too, or they lose their access. Federated cluster subjects
+                  aren't clusters which can be approved, so they can't be used with cluster enrollment.
+                properties:
+                  approvedClusters:
+                    description: The IDs of the clusters approved by an administrator.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: set
+                  autoApprovePatterns:
+                    description: Patterns of the cluster IDs approved automatically,
+                      using shell file name syntax, e.g. "staging-*".
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: set
+                type: object
+              clusterIsolation:
+                description: |-
+                  Isolate the joined clusters from each other on the broker, so that a compromised cluster can't tamper with its
+                  peers' Cluster and Endpoint resources. Each cluster service account is bound to its own role, which only allows
+                  modifying or deleting the Cluster and Endpoint resources of that cluster. The resources of a cluster are identified
+                  by the cluster ID in their spec, which is set by the clusters themselves, and creating resources isn't restricted, so
+                  a compromised cluster can still create resources claiming a peer's cluster ID. Service discovery resources aren't
+                  isolated. Federated cluster subjects can't be isolated, so they can't be used with cluster isolation.
+                type: boolean
+              clusterTokenRotation:
+                description: |-
+                  Rotate the service account tokens the joined clusters use to access the broker. The joined clusters pick up their
+                  new token through their broker secret syncer, while the previous tokens remain valid for the overlap period.
+                properties:
+                  clusterIDs:
+                    description: The IDs of the clusters whose tokens are rotated;
+                      all the clusters' tokens are rotated if unset.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: set
+                  interval:
+                    description: The maximum age of the tokens; tokens aren't rotated
+                      periodically if unset.
+                    type: string
+                  overlapPeriod:
+                    description: |-
+                      How long the previous tokens remain valid after a rotation, which gives the joined clusters time to pick up the new
+                      token; the previous tokens are only deleted once the new token has been issued. Tokens issued before
+                      rotateIssuedBefore have no overlap period. Defaults to 1h.
+                    type: string
+                  rotateIssuedBefore:
+                    description: |-
+                      Rotate the tokens issued before this time, for example after a token leak. These tokens are deleted as soon as
+                      their replacements have been issued, without an overlap period; the joined clusters which haven't picked up their
+                      new token by then must rejoin.
+                    format: date-time
+                    type: string
+                type: object
               components:
                 description: List of the components to be installed - any of [service-discovery,
                   connectivity].
@@ -76,6 +156,42 @@ spec:
                 description: Default cluster size for GlobalCIDR allocated to each
                   cluster (amount of global IPs).
                 type: integer
+              federatedClusterSubjects:
+                description: |-
+                  Users and groups granted the same access to the broker as the joined clusters' service accounts, typically
+                  identities from an OIDC provider federated with the broker cluster. Clusters can then access the broker with
+                  short-lived credentials issued by the provider instead of long-lived service account tokens. Only User and Group
+                  subjects are supported; they can't be used with cluster isolation or cluster enrollment.
+                items:
+                  description: |-
+                    Subject contains a reference to the object or user identities a role binding applies to.  This can either hold a direct API object reference,
+                    or a value for non-objects such as user and group names.
+                  properties:
+                    apiGroup:
+                      description: |-
+                        APIGroup holds the API group of the referenced subject.
+                        Defaults to "" for ServiceAccount subjects.
+                        Defaults to "rbac.authorization.k8s.io" for User and Group subjects.
+                      type: string
+                    kind:
+                      description: |-
+                        Kind of object being referenced. Values defined by this API group are "User", "Group", and "ServiceAccount".
+                        If the Authorizer does not recognized the kind value, the Authorizer should report an error.
+                      type: string
+                    name:
+                      description: Name of the object being referenced.
+                      type: string
+                    namespace:
+                      description: |-
+                        Namespace of the referenced object.  If the object kind is non-namespace, such as "User" or "Group", and this value is not empty
+                        the Authorizer should report an error.
+                      type: string
+                  required:
+                  - kind
+                  - name
+                  type: object
+                  x-kubernetes-map-type: atomic
+                type: array
               globalnetCIDRRange:
                 description: GlobalCIDR supernet range for allocating GlobalCIDRs
                   to each cluster.
@@ -83,9 +199,237 @@ spec:
               globalnetEnabled:
                 description: Enable support for Overlapping CIDRs in connecting clusters.
                 type: boolean
+              objectCountWarningThreshold:
+                description: |-
+                  The number of objects of a single kind (Endpoints, ServiceImports, EndpointSlices) in the broker namespace above
+                  which a warning is raised. Defaults to 5000.
+                minimum: 0
+                type: integer
+              observers:
+                description: |-
+                  Read-only observers of the broker, such as dashboards or fleet inventory tools. Each observer is given a service
+                  account bound to the submariner-k8s-broker-observer role, which can list and watch the broker resources but not
+                  modify them, and a token recorded in the Broker status. Observer names must be DNS labels.
+                items:
+                  type: string
+                type: array
+                x-kubernetes-list-type: set
+              staleClusterCleanup:
+                description: |-
+                  Delete the service accounts, role bindings, tokens and EndpointSlices left on the broker by clusters which have left
+                  the clusterset. Requires the connectivity component, whose gateways register the clusters.
+                properties:
+                  gracePeriod:
+                    description: |-
+                      How long the resources of a cluster are kept once they are found without a Cluster resource, which gives joining
+                      clusters time to register; defaults to 24h. Clusters pending enrollment approval are kept.
+                    type: string
+                type: object
             type: object
           status:
             description: BrokerStatus defines the observed state of Broker.
+            properties:
+              clusterTokens:
+                description: The current token of each joined cluster.
+                items:
+                  description: ClusterToken describes the current service account
+                    token of a joined cluster.
+                  properties:
+                    clusterID:
+                      description: The ID of the cluster.
+                      type: string
+                    issueTime:
+                      description: When the token was issued.
+                      format: date-time
+                      type: string
+                    secretName:
+                      description: The Secret, in the broker namespace, holding the
+                        token.
+                      type: string
+                  required:
+                  - clusterID
+                  - issueTime
+                  - secretName
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - clusterID
+                x-kubernetes-list-type: map
+              conditions:
+                description: |-
+                  The readiness of the broker: whether its CRDs are installed, its namespace is usable, its RBAC is in place and
+                  Globalnet is configured. Clusters can join once all the conditions are true.
+                items:
+                  description: "Condition contains details for one aspect of the current
+                    state of this API Resource.\n---\nThis struct is intended for
+                    direct use as an array at the field path .status.conditions.  For
+                    example,\n\n\n\ttype FooStatus struct{\n\t    // Represents the
+                    observations of a foo's current state.\n\t    // Known .status.conditions.type
+                    are: \"Available\", \"Progressing\", and \"Degraded\"\n\t    //
+                    +patchMergeKey=type\n\t    // +patchStrategy=merge\n\t    // +listType=map\n\t
+                    \   // +listMapKey=type\n\t    Conditions []metav1.Condition ` + "``" + `json:\"conditions,omitempty\"
+                    patchStrategy:\"merge\" patchMergeKey:\"type\" protobuf:\"bytes,1,rep,name=conditions\"` + "``" + `\n\n\n\t
+                    \   // other fields\n\t}"
+                  properties:
+                    lastTransitionTime:
+                      description: |-
+                        lastTransitionTime is the last time the condition transitioned from one status to another.
+                        This should be when the underlying condition changed.  If that is not known, then using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: |-
+                        message is a human readable message indicating details about the transition.
+                        This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: |-
+                        observedGeneration represents the .metadata.generation that the condition was set based upon.
+                        For instance, if .metadata.generation is currently 12, but the .status.conditions[x].observedGeneration is 9, the condition is out of date
+                        with respect to the current state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: |-
+                        reason contains a programmatic identifier indicating the reason for the condition's last transition.
+                        Producers of specific condition types may define expected values and meanings for this field,
+                        and whether the values are considered a guaranteed API.
+                        The value should be a CamelCase string.
+                        This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: |-
+                        type of condition in CamelCase or in foo.example.com/CamelCase.
+                        ---
+                        Many .condition.type values are consistent across resources like Available, but because arbitrary conditions can be
+                        useful (see .node.status.conditions), the ability to deconflict is important.
+                        The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
+              globalnetAllocations:
+                description: The global CIDRs allocated to each cluster, as recorded
+                  in the globalnet ConfigMap.
+                items:
+                  description: GlobalnetAllocation describes the global CIDRs allocated
+                    to a cluster.
+                  properties:
+                    clusterID:
+                      description: The ID of the cluster.
+                      type: string
+                    globalCIDRs:
+                      description: The global CIDRs allocated to the cluster.
+                      items:
+                        type: string
+                      type: array
+                  required:
+                  - clusterID
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - clusterID
+                x-kubernetes-list-type: map
+              globalnetConflicts:
+                description: The conflicts detected between the allocated global CIDRs,
+                  or with the Globalnet CIDR range.
+                items:
+                  type: string
+                type: array
+              lastRBACRepair:
+                description: The most recent repair of the broker service accounts,
+                  roles and role bindings, after they were deleted or modified.
+                properties:
+                  problems:
+                    description: The problems which were found and fixed.
+                    items:
+                      type: string
+                    type: array
+                  time:
+                    description: When the repair happened.
+                    format: date-time
+                    type: string
+                required:
+                - problems
+                - time
+                type: object
+              objectCountWarnings:
+                description: The warnings raised for the monitored kinds whose object
+                  count exceeds the threshold.
+                items:
+                  type: string
+                type: array
+              objectCounts:
+                additionalProperties:
+                  type: integer
+                description: The number of objects of each monitored kind in the broker
+                  namespace.
+                type: object
+              observerTokens:
+                description: The token of each broker observer.
+                items:
+                  description: ObserverToken describes the read-only token of a broker
+                    observer.
+                  properties:
+                    name:
+                      description: The name of the observer.
+                      type: string
+                    secretName:
+                      description: The Secret, in the broker namespace, holding the
+                        token.
+                      type: string
+                  required:
+                  - name
+                  - secretName
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - name
+                x-kubernetes-list-type: map
+              pendingClusters:
+                description: The IDs of the clusters waiting for their enrollment
+                  to be approved.
+                items:
+                  type: string
+                type: array
+                x-kubernetes-list-type: set
+              staleClusterEndpointSlices:
+                description: |-
+                  The EndpointSlices exported by clusters which no longer have a Cluster resource, and haven't been cleaned up. The
+                  services they back remain imported in the clusterset until they're removed.
+                items:
+                  type: string
+                type: array
+                x-kubernetes-list-type: set
+              staleClusterServiceAccounts:
+                description: The service accounts of clusters which no longer have
+                  a Cluster resource, and haven't been cleaned up.
+                items:
+                  type: string
+                type: array
+                x-kubernetes-list-type: set
             type: object
         type: object
     served: true
@@ -103,13 +447,47 @@ metadata:
 spec:
   group: submariner.io
   names:
+    categories:
+    - submariner-io
     kind: Submariner
     listKind: SubmarinerList
     plural: submariners
     singular: submariner
   scope: Namespaced
   versions:
-  - name: v1alpha1
+  - additionalPrinterColumns:
+    - jsonPath: .status.clusterID
+      name: Cluster ID
+      type: string
+    - jsonPath: .status.version
+      name: Version
+      type: string
+    - jsonPath: .status.globalCIDR
+      name: Global CIDR
+      type: string
+    - jsonPath: .status.gateways[?(@.haStatus=="active")].connections[*].endpoint.cluster_id
+      name: Connected Clusters
+      type: string
+    - jsonPath: .status.gateways[?(@.haStatus=="active")].connections[*].status
+      name: Connection Status
+      priority: 1
+      type: string
+    - jsonPath: .status.gatewayFailover.activeGateway
+      name: Active Gateway
+      priority: 1
+      type: string
+    - jsonPath: .status.networkPlugin
+      name: Network Plugin
+      priority: 1
+      type: string
+    - jsonPath: .status.paused
+      name: Paused
+      priority: 1
+      type: boolean
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
     schema:
       openAPIV3Schema:
         description: Submariner is the Schema for the submariners API.
@@ -136,6 +514,16 @@ spec:
             properties:
               airGappedDeployment:
                 type: boolean
+              architectureImageOverrides:
+                additionalProperties:
+                  additionalProperties:
+                    type: string
+                  type: object
+                description: |-
+                  Override component images on the nodes of specific architectures, for registries which publish separate images per
+                  architecture instead of multi-architecture images. Keys are architectures, as in the kubernetes.io/arch node label,
+                  mapped to image overrides by component. Each overridden architecture is deployed using its own DaemonSets.
+                type: object
               broker:
                 description: Type of broker (must be "k8s").
                 type: string
@@ -148,6 +536,12 @@ spec:
               brokerK8sCA:
                 description: The broker certificate authority.
                 type: string
+              brokerK8sCABundle:
+                description: |-
+                  A PEM bundle of the certificate authorities to trust for the broker API server, overriding the broker cluster's
+                  service account CA. Needed when brokerK8sApiServer is an endpoint presenting another certificate, such as a private
+                  endpoint, a load balancer or a bastion. The bundle is injected into the broker secret synced from the broker.
+                type: string
               brokerK8sInsecure:
                 type: boolean
               brokerK8sRemoteNamespace:
@@ -155,6 +549,12 @@ spec:
                 type: string
               brokerK8sSecret:
                 type: string
+              brokerK8sSecretProviderClass:
+                description: |-
+                  The name of a Secrets Store CSI driver SecretProviderClass supplying the broker credentials from an external secret
+                  manager. When set, the credentials are mounted from the CSI driver instead of the brokerK8sSecret Secret, and aren't
+                  synced from the broker; brokerK8sSecret is still required to name them.
+                type: string
               cableDriver:
                 description: Cable driver implementation - any of [libreswan, wireguard,
                   vxlan].
@@ -187,7 +587,29 @@ spec:
               clusterID:
                 description: The cluster ID used to identify the tunnels.
                 type: string
+              clusterMetadata:
+                description: |-
+                  Metadata describing the cluster in the clusterset, such as its display name, region and environment. It is
+                  attached to the local Endpoint as submariner.io/cluster-* annotations, which are synchronized to the broker along
+                  with the Endpoint so the other clusters and the clusterset tooling can show it.
+                properties:
+                  displayName:
+                    description: The name shown for the cluster.
+                    maxLength: 63
+                    type: string
+                  environment:
+                    description: The environment the cluster belongs to, e.g. production
+                      or staging.
+                    maxLength: 63
+                    type: string
+                  region:
+                    description: The region the cluster runs in, e.g. eu-west-1.
+                    maxLength: 63
+                    type: string
+                type: object
               colorCodes:
+                description: 'Deprecated: use clusterMetadata instead. The color codes
+                  are still passed to the gateway.'
                 type: string
               connectionHealthCheck:
                 description: The gateway connection health check.
@@ -205,6 +627,21 @@ spec:
                     format: int64
                     type: integer
                 type: object
+              connectivitySLO:
+                description: |-
+                  Record the availability of the connections to the remote clusters over rolling windows, in metrics and in the
+                  status, by sampling the gateways' connection health checks.
+                properties:
+                  sampleInterval:
+                    description: The interval between samples. Defaults to 30s.
+                    type: string
+                  windows:
+                    description: The rolling windows over which the availability is
+                      computed. Defaults to 1h and 24h.
+                    items:
+                      type: string
+                    type: array
+                type: object
               coreDNSCustomConfig:
                 description: |-
                   Name of the custom CoreDNS configmap to configure forwarding to Lighthouse.
@@ -216,6 +653,33 @@ spec:
                   namespace:
                     description: Namespace of the custom CoreDNS configmap.
                     type: string
+                  serverBlocks:
+                    description: |-
+                      Additional server blocks written to the custom CoreDNS configmap after the Lighthouse ones, each forwarding a domain
+                      to its own upstream resolvers, e.g. for internal DNS zones. Their domains must not overlap the clusterset domains.
+                    items:
+                      description: CoreDNSServerBlock forwards the queries for a domain
+                        to upstream resolvers.
+                      properties:
+                        domain:
+                          description: The domain served by the block.
+                          type: string
+                        upstreams:
+                          description: |-
+                            The resolvers the queries are forwarded to, as IP addresses with an optional port, e.g. 10.0.0.10 or 10.0.0.10:5353,
+                            in order of preference.
+                          items:
+                            type: string
+                          minItems: 1
+                          type: array
+                      required:
+                      - domain
+                      - upstreams
+                      type: object
+                    type: array
+                    x-kubernetes-list-map-keys:
+                    - domain
+                    x-kubernetes-list-type: map
                 type: object
               customDomains:
                 description: List of domains to use for multi-cluster service discovery.
@@ -226,30 +690,302 @@ spec:
               debug:
                 description: Enable operator debugging.
                 type: boolean
-              globalCIDR:
-                description: The Global CIDR super-net range for allocating GlobalCIDRs
-                  to each cluster.
-                type: string
-              haltOnCertificateError:
-                description: Halt on certificate error (so the pod gets restarted).
-                type: boolean
-              imageOverrides:
-                additionalProperties:
-                  type: string
-                description: Override component images.
+              endpointMetadata:
+                description: |-
+                  Labels and annotations to attach to the local Endpoint, e.g. region, environment or cost center. They are
+                  synchronized to the broker along with the Endpoint, so they are visible from the other clusters.
+                properties:
+                  annotations:
+                    additionalProperties:
+                      type: string
+                    description: Annotations to attach to the local Endpoint.
+                    type: object
+                  labels:
+                    additionalProperties:
+                      type: string
+                    description: Labels to attach to the local Endpoint.
+                    type: object
                 type: object
-              loadBalancerEnabled:
-                description: Enable automatic Load Balancer in front of the gateways.
-                type: boolean
-              namespace:
-                description: The namespace in which to deploy the submariner operator.
-                type: string
-              natEnabled:
-                description: Enable NAT between clusters.
-                type: boolean
-              nodeSelector:
-                additionalProperties:
-                  type: string
+              evictionProtection:
+                description: |-
+                  Protect the gateways, and optionally the route agents, from voluntary disruptions such as node drains and
+                  cluster-autoscaler scale-downs.
+                properties:
+                  routeAgents:
+                    description: Also protect the route agents; only one route agent
+                      can then be evicted at a time.
+                    type: boolean
+                type: object
+              externalDNS:
+                description: Publish the clusterset DNS records of exported services
+                  to an external DNS provider, through external-dns.
+                properties:
+                  recordTTL:
+                    description: The TTL of the published records, in seconds; the
+                      provider's default is used if unset.
+                    format: int64
+                    minimum: 0
+                    type: integer
+                  serviceImportSelector:
+                    description: The labels selecting the ServiceImports to publish;
+                      all ServiceImports are published if unset.
+                    properties:
+                      matchExpressions:
+                        description: matchExpressions is a list of label selector
+                          requirements. The requirements are ANDed.
+                        items:
+                          description: |-
+                            A label selector requirement is a selector that contains values, a key, and an operator that
+                            relates the key and values.
+                          properties:
+                            key:
+                              description: key is the label key that the selector
+                                applies to.
+                              type: string
+                            operator:
+                              description: |-
+                                operator represents a key's relationship to a set of values.
+                                Valid operators are In, NotIn, Exists and DoesNotExist.
+                              type: string
+                            values:
+                              description: |-
+                                values is an array of string values. If the operator is In or NotIn,
+                                the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                                the values array must be empty. This array is replaced during a strategic
+                                merge patch.
+                              items:
+                                type: string
+                              type: array
+                          required:
+                          - key
+                          - operator
+                          type: object
+                        type: array
+                      matchLabels:
+                        additionalProperties:
+                          type: string
+                        description: |-
+                          matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                          map is equivalent to an element of matchExpressions, whose key field is "key", the
+                          operator is "In", and the values array contains only "value". The requirements are ANDed.
+                        type: object
+                    type: object
+                    x-kubernetes-map-type: atomic
+                type: object
+              gatewayAddresses:
+                description: |-
+                  Candidate addresses of the gateway, for the remote clusters to try in order of priority when the public IP alone
+                  isn't reachable from all of them, e.g. in hybrid topologies. They are advertised on the local Endpoint, in the
+                  submariner.io/gateway-addresses annotation.
+                items:
+                  description: GatewayAddress is a candidate address advertised for
+                    the gateway.
+                  properties:
+                    address:
+                      description: The IP address.
+                      type: string
+                    priority:
+                      description: |-
+                        The priority of the address; addresses with lower values are tried first. Addresses with the same priority are
+                        tried in the order they are listed.
+                      format: int32
+                      minimum: 0
+                      type: integer
+                    type:
+                      description: The kind of address - any of [Public, Private,
+                        LoadBalancer, Secondary].
+                      enum:
+                      - Public
+                      - Private
+                      - LoadBalancer
+                      - Secondary
+                      type: string
+                  required:
+                  - address
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - address
+                x-kubernetes-list-type: map
+              gatewayDrain:
+                description: |-
+                  Hand the active gateway role over to a standby gateway when the node of the active gateway is cordoned for
+                  maintenance, before the node is drained.
+                properties:
+                  timeout:
+                    description: |-
+                      How long to wait for the standby gateway to take over and re-establish its connections before giving up and
+                      restoring the gateway on the cordoned node. Defaults to 2m.
+                    type: string
+                type: object
+              globalCIDR:
+                description: The Global CIDR super-net range for allocating GlobalCIDRs
+                  to each cluster.
+                type: string
+              haltOnCertificateError:
+                description: Halt on certificate error (so the pod gets restarted).
+                type: boolean
+              imageOverrides:
+                additionalProperties:
+                  type: string
+                description: Override component images.
+                type: object
+              imagePolicy:
+                description: The policy used to reference component images.
+                properties:
+                  digestConfigMap:
+                    description: |-
+                      Name of the ConfigMap, in the operator namespace, mapping component names to image digests. Values can also be
+                      complete image references. Changes to the ConfigMap roll out the affected components.
+                    type: string
+                  mode:
+                    description: |-
+                      How component images are referenced - any of [Tag, Digest]. With Tag, images follow the configured version.
+                      With Digest, images are pinned to the digests listed in the digest ConfigMap. Resolving digests through an external
+                      resolver webhook isn't supported; an external resolver can maintain the digest ConfigMap instead.
+                    enum:
+                    - Tag
+                    - Digest
+                    type: string
+                type: object
+              istioServiceEntries:
+                description: Mirror the imported services as Istio ServiceEntry resources,
+                  so that mesh workloads can route to them.
+                properties:
+                  exportTo:
+                    description: The namespaces the ServiceEntries are visible in;
+                      they are visible in all namespaces if unset.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: set
+                  serviceImportSelector:
+                    description: The labels selecting the ServiceImports to mirror;
+                      all ServiceImports are mirrored if unset.
+                    properties:
+                      matchExpressions:
+                        description: matchExpressions is a list of label selector
+                          requirements. The requirements are ANDed.
+                        items:
+                          description: |-
+                            A label selector requirement is a selector that contains values, a key, and an operator that
+                            relates the key and values.
+                          properties:
+                            key:
+                              description: key is the label key that the selector
+                                applies to.
+                              type: string
+                            operator:
+                              description: |-
+                                operator represents a key's relationship to a set of values.
+                                Valid operators are In, NotIn, Exists and DoesNotExist.
+                              type: string
+                            values:
+                              description: |-
+                                values is an array of string values. If the operator is In or NotIn,
+                                the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                                the values array must be empty. This array is replaced during a strategic
+                                merge patch.
+                              items:
+                                type: string
+                              type: array
+                          required:
+                          - key
+                          - operator
+                          type: object
+                        type: array
+                      matchLabels:
+                        additionalProperties:
+                          type: string
+                        description: |-
+                          matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                          map is equivalent to an element of matchExpressions, whose key field is "key", the
+                          operator is "In", and the values array contains only "value". The requirements are ANDed.
+                        type: object
+                    type: object
+                    x-kubernetes-map-type: atomic
+                type: object
+              loadBalancerEnabled:
+                description: Enable automatic Load Balancer in front of the gateways.
+                type: boolean
+              metricsProxyAuth:
+                description: 'Secure the metrics proxies: metrics are then only served
+                  over HTTPS, to authenticated and authorized clients.'
+                properties:
+                  clientCertificates:
+                    description: |-
+                      Also authenticate clients presenting a certificate signed by the CA in the ca.crt entry of the TLS secret (mutual
+                      TLS). Requires a TLS secret.
+                    type: boolean
+                  tlsSecret:
+                    description: |-
+                      Name of a kubernetes.io/tls Secret, in the Submariner namespace, holding the serving certificate of the metrics
+                      proxies, e.g. as issued by a cert-manager Certificate. Rotated certificates are picked up without restarting the
+                      proxies. If empty, the proxies generate a self-signed certificate when they start.
+                    type: string
+                type: object
+              namespace:
+                description: The namespace in which to deploy the submariner operator.
+                type: string
+              natEnabled:
+                description: Enable NAT between clusters.
+                type: boolean
+              nodePlacement:
+                description: |-
+                  Node placement defaults applied to all the components which don't need to run on every node, for example
+                  to confine an installation to an infrastructure node pool. The nodeSelector and tolerations fields take
+                  precedence for service discovery.
+                properties:
+                  nodeSelector:
+                    additionalProperties:
+                      type: string
+                    description: The node labels the pods require, in addition to
+                      those required by each component.
+                    type: object
+                  tolerations:
+                    description: The tolerations added to the pods.
+                    items:
+                      description: |-
+                        The pod this Toleration is attached to tolerates any taint that matches
+                        the triple <key,value,effect> using the matching operator <operator>.
+                      properties:
+                        effect:
+                          description: |-
+                            Effect indicates the taint effect to match. Empty means match all taint effects.
+                            When specified, allowed values are NoSchedule, PreferNoSchedule and NoExecute.
+                          type: string
+                        key:
+                          description: |-
+                            Key is the taint key that the toleration applies to. Empty means match all taint keys.
+                            If the key is empty, operator must be Exists; this combination means to match all values and all keys.
+                          type: string
+                        operator:
+                          description: |-
+                            Operator represents a key's relationship to the value.
+                            Valid operators are Exists and Equal. Defaults to Equal.
+                            Exists is equivalent to wildcard for value, so that a pod can
+                            tolerate all taints of a particular category.
+                          type: string
+                        tolerationSeconds:
+                          description: |-
+                            TolerationSeconds represents the period of time the toleration (which must be
+                            of effect NoExecute, otherwise this field is ignored) tolerates the taint. By default,
+                            it is not set, which means tolerate the taint forever (do not evict). Zero and
+                            negative values will be treated as 0 (evict immediately) by the system.
+                          format: int64
+                          type: integer
+                        value:
+                          description: |-
+                            Value is the taint value the toleration matches to.
+                            If the operator is Exists, the value should be empty, otherwise just a regular string.
+                          type: string
+                      type: object
+                    type: array
+                type: object
+              nodeSelector:
+                additionalProperties:
+                  type: string
                 type: object
               repository:
                 description: The image repository.
@@ -298,6 +1034,131 @@ spec:
                       type: string
                   type: object
                 type: array
+              updateStrategies:
+                additionalProperties:
+                  description: |-
+                    ComponentUpdateStrategy configures how a component is updated. Only the strategy matching the kind of workload
+                    used for the component is applied.
+                  properties:
+                    daemonSet:
+                      description: The update strategy for components deployed as
+                        DaemonSets.
+                      properties:
+                        rollingUpdate:
+                          description: |-
+                            Rolling update config params. Present only if type = "RollingUpdate".
+                            ---
+                            TODO: Update this to follow our convention for oneOf, whatever we decide it
+                            to be. Same as Deployment ` + "``" + `strategy.rollingUpdate` + "``" + `.
+                            See https://github.com/kubernetes/kubernetes/issues/35345
+                          properties:
+                            maxSurge:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                The maximum number of nodes with an existing available DaemonSet pod that
+                                can have an updated DaemonSet pod during during an update.
+                                Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+                                This can not be 0 if MaxUnavailable is 0.
+                                Absolute number is calculated from percentage by rounding up to a minimum of 1.
+                                Default value is 0.
+                                Example: when this is set to 30%, at most 30% of the total number of nodes
+                                that should be running the daemon pod (i.e. status.desiredNumberScheduled)
+                                can have their a new pod created before the old pod is marked as deleted.
+                                The update starts by launching new pods on 30% of nodes. Once an updated
+                                pod is available (Ready for at least minReadySeconds) the old DaemonSet pod
+                                on that node is marked deleted. If the old pod becomes unavailable for any
+                                reason (Ready transitions to false, is evicted, or is drained) an updated
+                                pod is immediatedly created on that node without considering surge limits.
+                                Allowing surge implies the possibility that the resources consumed by the
+                                daemonset on any given node can double if the readiness check fails, and
+                                so resource intensive daemonsets should take into account that they may
+                                cause evictions during disruption.
+                              x-kubernetes-int-or-string: true
+                            maxUnavailable:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                The maximum number of DaemonSet pods that can be unavailable during the
+                                update. Value can be an absolute number (ex: 5) or a percentage of total
+                                number of DaemonSet pods at the start of the update (ex: 10%). Absolute
+                                number is calculated from percentage by rounding up.
+                                This cannot be 0 if MaxSurge is 0
+                                Default value is 1.
+                                Example: when this is set to 30%, at most 30% of the total number of nodes
+                                that should be running the daemon pod (i.e. status.desiredNumberScheduled)
+                                can have their pods stopped for an update at any given time. The update
+                                starts by stopping at most 30% of those DaemonSet pods and then brings
+                                up new DaemonSet pods in their place. Once the new pods are available,
+                                it then proceeds onto other DaemonSet pods, thus ensuring that at least
+                                70% of original number of DaemonSet pods are available at all times during
+                                the update.
+                              x-kubernetes-int-or-string: true
+                          type: object
+                        type:
+                          description: Type of daemon set update. Can be "RollingUpdate"
+                            or "OnDelete". Default is RollingUpdate.
+                          type: string
+                      type: object
+                    deployment:
+                      description: The strategy for components deployed as Deployments.
+                      properties:
+                        rollingUpdate:
+                          description: |-
+                            Rolling update config params. Present only if DeploymentStrategyType =
+                            RollingUpdate.
+                            ---
+                            TODO: Update this to follow our convention for oneOf, whatever we decide it
+                            to be.
+                          properties:
+                            maxSurge:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                The maximum number of pods that can be scheduled above the desired number of
+                                pods.
+                                Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+                                This can not be 0 if MaxUnavailable is 0.
+                                Absolute number is calculated from percentage by rounding up.
+                                Defaults to 25%.
+                                Example: when this is set to 30%, the new ReplicaSet can be scaled up immediately when
+                                the rolling update starts, such that the total number of old and new pods do not exceed
+                                130% of desired pods. Once old pods have been killed,
+                                new ReplicaSet can be scaled up further, ensuring that total number of pods running
+                                at any time during the update is at most 130% of desired pods.
+                              x-kubernetes-int-or-string: true
+                            maxUnavailable:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                The maximum number of pods that can be unavailable during the update.
+                                Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+                                Absolute number is calculated from percentage by rounding down.
+                                This can not be 0 if MaxSurge is 0.
+                                Defaults to 25%.
+                                Example: when this is set to 30%, the old ReplicaSet can be scaled down to 70% of desired pods
+                                immediately when the rolling update starts. Once new pods are ready, old ReplicaSet
+                                can be scaled down further, followed by scaling up the new ReplicaSet, ensuring
+                                that the total number of pods available at all times during the update is at
+                                least 70% of desired pods.
+                              x-kubernetes-int-or-string: true
+                          type: object
+                        type:
+                          description: Type of deployment. Can be "Recreate" or "RollingUpdate".
+                            Default is RollingUpdate.
+                          type: string
+                      type: object
+                  type: object
+                description: |-
+                  Override the update strategy of components, keyed by component as for image overrides. DaemonSet strategies
+                  apply to the gateway, route agent, globalnet and metrics proxy components, Deployment strategies to the
+                  Lighthouse agent and CoreDNS components. The OnDelete DaemonSet strategy allows dataplane updates to be
+                  staged node by node, by deleting the pods manually.
+                type: object
               version:
                 description: The image tag.
                 type: string
@@ -324,8 +1185,55 @@ spec:
               clusterID:
                 description: The current cluster ID.
                 type: string
+              clusterMetadata:
+                description: The current cluster metadata.
+                properties:
+                  displayName:
+                    description: The name shown for the cluster.
+                    maxLength: 63
+                    type: string
+                  environment:
+                    description: The environment the cluster belongs to, e.g. production
+                      or staging.
+                    maxLength: 63
+                    type: string
+                  region:
+                    description: The region the cluster runs in, e.g. eu-west-1.
+                    maxLength: 63
+                    type: string
+                type: object
               colorCodes:
                 type: string
+              connectionAvailability:
+                description: The availability of the connections to the remote clusters,
+                  over the rolling windows of the connectivity SLO.
+                items:
+                  properties:
+                    availability:
+                      description: The percentage of samples in the window in which
+                        the connection was available, with two decimals.
+                      type: string
+                    averageLatency:
+                      description: The average round-trip time measured by the health
+                        checks over the window, if any.
+                      type: string
+                    clusterID:
+                      description: The ID of the remote cluster.
+                      type: string
+                    samples:
+                      description: The number of samples in the window.
+                      format: int32
+                      type: integer
+                    window:
+                      description: The rolling window.
+                      type: string
+                  required:
+                  - availability
+                  - clusterID
+                  - samples
+                  - window
+                  type: object
+                type: array
               deploymentInfo:
                 description: Information about the deployment.
                 properties:
@@ -512,6 +1420,44 @@ spec:
                 required:
                 - mismatchedContainerImages
                 type: object
+              gatewayDrain:
+                description: The drain of the gateway on the last cordoned gateway
+                  node.
+                properties:
+                  node:
+                    description: The cordoned node.
+                    type: string
+                  phase:
+                    description: The phase of the drain.
+                    type: string
+                  startTime:
+                    description: When the drain started.
+                    format: date-time
+                    type: string
+                required:
+                - node
+                - phase
+                - startTime
+                type: object
+              gatewayFailover:
+                description: The failover history of the gateways in the cluster.
+                properties:
+                  activeGateway:
+                    description: The host name of the currently active gateway.
+                    type: string
+                  failoverCount:
+                    description: The number of times the active gateway changed.
+                    format: int32
+                    type: integer
+                  lastFailoverReason:
+                    description: The reason recorded for the last change of the active
+                      gateway.
+                    type: string
+                  lastFailoverTime:
+                    description: When the active gateway last changed.
+                    format: date-time
+                    type: string
+                type: object
               gateways:
                 description: Status of the gateways in the cluster.
                 items:
@@ -896,6 +1842,19 @@ spec:
               networkPlugin:
                 description: The current network plugin.
                 type: string
+              nodeArchitectures:
+                description: The architectures of the cluster's nodes.
+                items:
+                  type: string
+                type: array
+              paused:
+                description: Whether reconciliation is paused by the submariner.io/paused
+                  annotation.
+                type: boolean
+              reconcileFailures:
+                description: The number of consecutive failed reconciles of this resource.
+                format: int32
+                type: integer
               routeAgentDaemonSetStatus:
                 description: The status of the route agent DaemonSet.
                 properties:
@@ -1097,13 +2056,32 @@ metadata:
 spec:
   group: submariner.io
   names:
+    categories:
+    - submariner-io
     kind: ServiceDiscovery
     listKind: ServiceDiscoveryList
     plural: servicediscoveries
     singular: servicediscovery
   scope: Namespaced
   versions:
-  - name: v1alpha1
+  - additionalPrinterColumns:
+    - jsonPath: .spec.clusterID
+      name: Cluster ID
+      type: string
+    - jsonPath: .spec.version
+      name: Version
+      type: string
+    - jsonPath: .spec.globalnetEnabled
+      name: Globalnet
+      type: boolean
+    - jsonPath: .status.paused
+      name: Paused
+      priority: 1
+      type: boolean
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
     schema:
       openAPIV3Schema:
         description: ServiceDiscovery is the Schema for the servicediscoveries API.
@@ -1140,6 +2118,8 @@ spec:
                 type: string
               brokerK8sSecret:
                 type: string
+              brokerK8sSecretProviderClass:
+                type: string
               clusterID:
                 type: string
               coreDNSCustomConfig:
@@ -1150,6 +2130,33 @@ spec:
                   namespace:
                     description: Namespace of the custom CoreDNS configmap.
                     type: string
+                  serverBlocks:
+                    description: |-
+                      Additional server blocks written to the custom CoreDNS configmap after the Lighthouse ones, each forwarding a domain
+                      to its own upstream resolvers, e.g. for internal DNS zones. Their domains must not overlap the clusterset domains.
+                    items:
+                      description: CoreDNSServerBlock forwards the queries for a domain
+                        to upstream resolvers.
+                      properties:
+                        domain:
+                          description: The domain served by the block.
+                          type: string
+                        upstreams:
+                          description: |-
+                            The resolvers the queries are forwarded to, as IP addresses with an optional port, e.g. 10.0.0.10 or 10.0.0.10:5353,
+                            in order of preference.
+                          items:
+                            type: string
+                          minItems: 1
+                          type: array
+                      required:
+                      - domain
+                      - upstreams
+                      type: object
+                    type: array
+                    x-kubernetes-list-map-keys:
+                    - domain
+                    x-kubernetes-list-type: map
                 type: object
               customDomains:
                 items:
@@ -1158,6 +2165,64 @@ spec:
                 x-kubernetes-list-type: set
               debug:
                 type: boolean
+              externalDNS:
+                description: |-
+                  ExternalDNSConfig configures the publication of the clusterset DNS records of exported services as external-dns
+                  DNSEndpoint resources, so that clients outside the clusterset can resolve them. Only services with a clusterset IP
+                  are published.
+                properties:
+                  recordTTL:
+                    description: The TTL of the published records, in seconds; the
+                      provider's default is used if unset.
+                    format: int64
+                    minimum: 0
+                    type: integer
+                  serviceImportSelector:
+                    description: The labels selecting the ServiceImports to publish;
+                      all ServiceImports are published if unset.
+                    properties:
+                      matchExpressions:
+                        description: matchExpressions is a list of label selector
+                          requirements. The requirements are ANDed.
+                        items:
+                          description: |-
+                            A label selector requirement is a selector that contains values, a key, and an operator that
+                            relates the key and values.
+                          properties:
+                            key:
+                              description: key is the label key that the selector
+                                applies to.
+                              type: string
+                            operator:
+                              description: |-
+                                operator represents a key's relationship to a set of values.
+                                Valid operators are In, NotIn, Exists and DoesNotExist.
+                              type: string
+                            values:
+                              description: |-
+                                values is an array of string values. If the operator is In or NotIn,
+                                the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                                the values array must be empty. This array is replaced during a strategic
+                                merge patch.
+                              items:
+                                type: string
+                              type: array
+                          required:
+                          - key
+                          - operator
+                          type: object
+                        type: array
+                      matchLabels:
+                        additionalProperties:
+                          type: string
+                        description: |-
+                          matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                          map is equivalent to an element of matchExpressions, whose key field is "key", the
+                          operator is "In", and the values array contains only "value". The requirements are ANDed.
+                        type: object
+                    type: object
+                    x-kubernetes-map-type: atomic
+                type: object
               globalnetEnabled:
                 type: boolean
               haltOnCertificateError:
@@ -1166,6 +2231,64 @@ spec:
                 additionalProperties:
                   type: string
                 type: object
+              istioServiceEntries:
+                description: |-
+                  IstioServiceEntriesConfig configures the mirroring of imported services as Istio ServiceEntry resources, so that mesh
+                  workloads can route to the services exported by the other clusters through their mesh configuration.
+                properties:
+                  exportTo:
+                    description: The namespaces the ServiceEntries are visible in;
+                      they are visible in all namespaces if unset.
+                    items:
+                      type: string
+                    type: array
+                    x-kubernetes-list-type: set
+                  serviceImportSelector:
+                    description: The labels selecting the ServiceImports to mirror;
+                      all ServiceImports are mirrored if unset.
+                    properties:
+                      matchExpressions:
+                        description: matchExpressions is a list of label selector
+                          requirements. The requirements are ANDed.
+                        items:
+                          description: |-
+                            A label selector requirement is a selector that contains values, a key, and an operator that
+                            relates the key and values.
+                          properties:
+                            key:
+                              description: key is the label key that the selector
+                                applies to.
+                              type: string
+                            operator:
+                              description: |-
+                                operator represents a key's relationship to a set of values.
+                                Valid operators are In, NotIn, Exists and DoesNotExist.
+                              type: string
+                            values:
+                              description: |-
+                                values is an array of string values. If the operator is In or NotIn,
+                                the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                                the values array must be empty. This array is replaced during a strategic
+                                merge patch.
+                              items:
+                                type: string
+                              type: array
+                          required:
+                          - key
+                          - operator
+                          type: object
+                        type: array
+                      matchLabels:
+                        additionalProperties:
+                          type: string
+                        description: |-
+                          matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                          map is equivalent to an element of matchExpressions, whose key field is "key", the
+                          operator is "In", and the values array contains only "value". The requirements are ANDed.
+                        type: object
+                    type: object
+                    x-kubernetes-map-type: atomic
+                type: object
               namespace:
                 type: string
               nodeSelector:
@@ -1207,34 +2330,539 @@ spec:
                       type: integer
                     value:
                       description: |-
-                        Value is the taint value the toleration matches to.
-                        If the operator is Exists, the value should be empty, otherwise just a regular string.
+                        Value is the taint value the toleration matches to.
+                        If the operator is Exists, the value should be empty, otherwise just a regular string.
+                      type: string
+                  type: object
+                type: array
+              updateStrategies:
+                additionalProperties:
+                  description: |-
+                    ComponentUpdateStrategy configures how a component is updated. Only the strategy matching the kind of workload
+                    used for the component is applied.
+                  properties:
+                    daemonSet:
+                      description: The update strategy for components deployed as
+                        DaemonSets.
+                      properties:
+                        rollingUpdate:
+                          description: |-
+                            Rolling update config params. Present only if type = "RollingUpdate".
+                            ---
+                            TODO: Update this to follow our convention for oneOf, whatever we decide it
+                            to be. Same as Deployment ` + "``" + `strategy.rollingUpdate` + "``" + `.
+                            See https://github.com/kubernetes/kubernetes/issues/35345
+                          properties:
+                            maxSurge:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                The maximum number of nodes with an existing available DaemonSet pod that
+                                can have an updated DaemonSet pod during during an update.
+                                Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+                                This can not be 0 if MaxUnavailable is 0.
+                                Absolute number is calculated from percentage by rounding up to a minimum of 1.
+                                Default value is 0.
+                                Example: when this is set to 30%, at most 30% of the total number of nodes
+                                that should be running the daemon pod (i.e. status.desiredNumberScheduled)
+                                can have their a new pod created before the old pod is marked as deleted.
+                                The update starts by launching new pods on 30% of nodes. Once an updated
+                                pod is available (Ready for at least minReadySeconds) the old DaemonSet pod
+                                on that node is marked deleted. If the old pod becomes unavailable for any
+                                reason (Ready transitions to false, is evicted, or is drained) an updated
+                                pod is immediatedly created on that node without considering surge limits.
+                                Allowing surge implies the possibility that the resources consumed by the
+                                daemonset on any given node can double if the readiness check fails, and
+                                so resource intensive daemonsets should take into account that they may
+                                cause evictions during disruption.
+                              x-kubernetes-int-or-string: true
+                            maxUnavailable:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                The maximum number of DaemonSet pods that can be unavailable during the
+                                update. Value can be an absolute number (ex: 5) or a percentage of total
+                                number of DaemonSet pods at the start of the update (ex: 10%). Absolute
+                                number is calculated from percentage by rounding up.
+                                This cannot be 0 if MaxSurge is 0
+                                Default value is 1.
+                                Example: when this is set to 30%, at most 30% of the total number of nodes
+                                that should be running the daemon pod (i.e. status.desiredNumberScheduled)
+                                can have their pods stopped for an update at any given time. The update
+                                starts by stopping at most 30% of those DaemonSet pods and then brings
+                                up new DaemonSet pods in their place. Once the new pods are available,
+                                it then proceeds onto other DaemonSet pods, thus ensuring that at least
+                                70% of original number of DaemonSet pods are available at all times during
+                                the update.
+                              x-kubernetes-int-or-string: true
+                          type: object
+                        type:
+                          description: Type of daemon set update. Can be "RollingUpdate"
+                            or "OnDelete". Default is RollingUpdate.
+                          type: string
+                      type: object
+                    deployment:
+                      description: The strategy for components deployed as Deployments.
+                      properties:
+                        rollingUpdate:
+                          description: |-
+                            Rolling update config params. Present only if DeploymentStrategyType =
+                            RollingUpdate.
+                            ---
+                            TODO: Update this to follow our convention for oneOf, whatever we decide it
+                            to be.
+                          properties:
+                            maxSurge:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                The maximum number of pods that can be scheduled above the desired number of
+                                pods.
+                                Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+                                This can not be 0 if MaxUnavailable is 0.
+                                Absolute number is calculated from percentage by rounding up.
+                                Defaults to 25%.
+                                Example: when this is set to 30%, the new ReplicaSet can be scaled up immediately when
+                                the rolling update starts, such that the total number of old and new pods do not exceed
+                                130% of desired pods. Once old pods have been killed,
+                                new ReplicaSet can be scaled up further, ensuring that total number of pods running
+                                at any time during the update is at most 130% of desired pods.
+                              x-kubernetes-int-or-string: true
+                            maxUnavailable:
+                              anyOf:
+                              - type: integer
+                              - type: string
+                              description: |-
+                                The maximum number of pods that can be unavailable during the update.
+                                Value can be an absolute number (ex: 5) or a percentage of desired pods (ex: 10%).
+                                Absolute number is calculated from percentage by rounding down.
+                                This can not be 0 if MaxSurge is 0.
+                                Defaults to 25%.
+                                Example: when this is set to 30%, the old ReplicaSet can be scaled down to 70% of desired pods
+                                immediately when the rolling update starts. Once new pods are ready, old ReplicaSet
+                                can be scaled down further, followed by scaling up the new ReplicaSet, ensuring
+                                that the total number of pods available at all times during the update is at
+                                least 70% of desired pods.
+                              x-kubernetes-int-or-string: true
+                          type: object
+                        type:
+                          description: Type of deployment. Can be "Recreate" or "RollingUpdate".
+                            Default is RollingUpdate.
+                          type: string
+                      type: object
+                  type: object
+                type: object
+              version:
+                type: string
+            required:
+            - brokerK8sApiServer
+            - brokerK8sRemoteNamespace
+            - clusterID
+            - debug
+            - namespace
+            type: object
+          status:
+            description: ServiceDiscoveryStatus defines the observed state of ServiceDiscovery.
+            properties:
+              deploymentInfo:
+                properties:
+                  cloudProvider:
+                    type: string
+                  kubernetesType:
+                    type: string
+                  kubernetesTypeVersion:
+                    type: string
+                  kubernetesVersion:
+                    type: string
+                type: object
+              paused:
+                description: Whether reconciliation is paused by the submariner.io/paused
+                  annotation.
+                type: boolean
+              reconcileFailures:
+                description: The number of consecutive failed reconciles of this resource.
+                format: int32
+                type: integer
+            type: object
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+`
+	Deploy_crds_submariner_io_clusternetworks_yaml = `---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.14.0
+  name: clusternetworks.submariner.io
+spec:
+  group: submariner.io
+  names:
+    categories:
+    - submariner-io
+    kind: ClusterNetwork
+    listKind: ClusterNetworkList
+    plural: clusternetworks
+    singular: clusternetwork
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .status.networkPlugin
+      name: Plugin
+      type: string
+    - jsonPath: .status.podCIDRs
+      name: Pod CIDRs
+      type: string
+    - jsonPath: .status.serviceCIDRs
+      name: Service CIDRs
+      type: string
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        description: |-
+          ClusterNetwork records the results of the cluster network discovery performed by the operator so they can be
+          reused instead of being discovered again. They are refreshed daily, or on demand with the
+          submariner.io/rediscover-network annotation.
+        properties:
+          apiVersion:
+            description: |-
+              APIVersion defines the versioned schema of this representation of an object.
+              Servers should convert recognized schemas to the latest internal value, and
+              may reject unrecognized values.
+              More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+            type: string
+          kind:
+            description: |-
+              Kind is a string value representing the REST resource this object represents.
+              Servers may infer this from the endpoint the client submits requests to.
+              Cannot be updated.
+              In CamelCase.
+              More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+            type: string
+          metadata:
+            type: object
+          status:
+            description: ClusterNetworkStatus holds the network details discovered
+              in the cluster.
+            properties:
+              discoveryTime:
+                description: |-
+                  When the network was discovered. The results are discovered again once they are older than a day, or when the
+                  submariner.io/rediscover-network annotation is set.
+                format: date-time
+                type: string
+              globalCIDR:
+                description: The global CIDR configured in the cluster, if any.
+                type: string
+              kubeProxyMode:
+                description: The kube-proxy mode detected in the cluster, if any.
+                type: string
+              networkPlugin:
+                description: The network plugin (CNI) detected in the cluster.
+                type: string
+              pluginSettings:
+                additionalProperties:
+                  type: string
+                description: Plugin-specific settings detected in the cluster.
+                type: object
+              podCIDRs:
+                description: The pod CIDRs detected in the cluster.
+                items:
+                  type: string
+                type: array
+              serviceCIDRs:
+                description: The service CIDRs detected in the cluster.
+                items:
+                  type: string
+                type: array
+            type: object
+        type: object
+    served: true
+    storage: true
+    subresources: {}
+`
+	Deploy_crds_submariner_io_verificationruns_yaml = `---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.14.0
+  name: verificationruns.submariner.io
+spec:
+  group: submariner.io
+  names:
+    categories:
+    - submariner-io
+    kind: VerificationRun
+    listKind: VerificationRunList
+    plural: verificationruns
+    singular: verificationrun
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.suites
+      name: Suites
+      type: string
+    - jsonPath: .status.phase
+      name: Phase
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        description: |-
+          VerificationRun runs the Submariner verification suites against a remote cluster, as a Job in the local cluster, and
+          records their outcome. Verifications can be scheduled, for example after upgrades, by creating VerificationRuns.
+        properties:
+          apiVersion:
+            description: |-
+              APIVersion defines the versioned schema of this representation of an object.
+              Servers should convert recognized schemas to the latest internal value, and
+              may reject unrecognized values.
+              More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+            type: string
+          kind:
+            description: |-
+              Kind is a string value representing the REST resource this object represents.
+              Servers may infer this from the endpoint the client submits requests to.
+              Cannot be updated.
+              In CamelCase.
+              More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+            type: string
+          metadata:
+            type: object
+          spec:
+            description: VerificationRunSpec defines the verification suites to run
+              against a remote cluster.
+            properties:
+              image:
+                description: The subctl image running the verification; defaults to
+                  the image matching the deployed Submariner version.
+                type: string
+              remoteKubeConfigSecret:
+                description: |-
+                  The Secret, in the VerificationRun's namespace, containing the kubeconfig of the remote cluster in its
+                  kubeconfig entry.
+                type: string
+              serviceAccountName:
+                description: |-
+                  The service account the verification Job runs as. It must be allowed to deploy the test workloads in the local
+                  cluster.
+                type: string
+              suites:
+                description: The verification suites to run.
+                items:
+                  enum:
+                  - Connectivity
+                  - ServiceDiscovery
+                  - GatewayFailover
+                  type: string
+                minItems: 1
+                type: array
+                x-kubernetes-list-type: set
+              timeout:
+                default: 30m
+                description: The maximum duration of the run, after which it fails.
+                type: string
+            required:
+            - remoteKubeConfigSecret
+            - serviceAccountName
+            - suites
+            type: object
+          status:
+            description: VerificationRunStatus defines the observed state of VerificationRun.
+            properties:
+              completionTime:
+                description: When the run succeeded or failed.
+                format: date-time
+                type: string
+              jobName:
+                description: The Job running the verification.
+                type: string
+              message:
+                description: The reason of a failure, including the last lines of
+                  the verification logs.
+                type: string
+              phase:
+                description: The phase of the run.
+                type: string
+              startTime:
+                description: When the run started.
+                format: date-time
+                type: string
+            type: object
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+`
+	Deploy_crds_submariner_io_joinrequests_yaml = `---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.14.0
+  name: joinrequests.submariner.io
+spec:
+  group: submariner.io
+  names:
+    categories:
+    - submariner-io
+    kind: JoinRequest
+    listKind: JoinRequestList
+    plural: joinrequests
+    singular: joinrequest
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.clusterID
+      name: Cluster ID
+      type: string
+    - jsonPath: .status.phase
+      name: Phase
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        description: |-
+          JoinRequest makes the operator join the cluster to a clusterset, as subctl join does: it labels the gateway nodes and
+          creates the Submariner resource, whose controller then detects the network settings. This lets cluster provisioners
+          onboard clusters declaratively, by creating a JoinRequest along with the broker credentials Secret. Once joined, the
+          deployment is managed through the Submariner resource. Unlike subctl join, a JoinRequest doesn't allocate a global
+          CIDR from the broker's globalnet ConfigMap, which the cluster credentials can't update: clusters joining a broker with
+          Globalnet enabled must be joined with subctl.
+        properties:
+          apiVersion:
+            description: |-
+              APIVersion defines the versioned schema of this representation of an object.
+              Servers should convert recognized schemas to the latest internal value, and
+              may reject unrecognized values.
+              More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources
+            type: string
+          kind:
+            description: |-
+              Kind is a string value representing the REST resource this object represents.
+              Servers may infer this from the endpoint the client submits requests to.
+              Cannot be updated.
+              In CamelCase.
+              More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds
+            type: string
+          metadata:
+            type: object
+          spec:
+            description: JoinRequestSpec defines how the cluster joins the clusterset.
+            properties:
+              brokerK8sApiServer:
+                description: The broker API URL.
+                type: string
+              brokerK8sRemoteNamespace:
+                description: The broker namespace.
+                type: string
+              brokerSecret:
+                description: |-
+                  The Secret, in the JoinRequest's namespace, holding the broker credentials: the broker service account token and
+                  CA in its token and ca.crt entries, and the IPsec Pre-Shared Key in its psk entry. The join waits for the Pre-Shared
+                  Key unless the cable driver is vxlan or wireguard.
+                type: string
+              cableDriver:
+                description: Cable driver implementation - any of [libreswan, wireguard,
+                  vxlan].
+                type: string
+              clusterCIDR:
+                description: The cluster CIDR; detected if unset.
+                type: string
+              clusterID:
+                description: The ID identifying the cluster in the clusterset; must
+                  be a DNS label.
+                maxLength: 63
+                pattern: ^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+                type: string
+              gatewayNodeSelector:
+                description: |-
+                  Selects the nodes to label as gateways. If unset, the nodes already labeled as gateways are used, or the first
+                  worker node is labeled if there are none.
+                properties:
+                  matchExpressions:
+                    description: matchExpressions is a list of label selector requirements.
+                      The requirements are ANDed.
+                    items:
+                      description: |-
+                        A label selector requirement is a selector that contains values, a key, and an operator that
+                        relates the key and values.
+                      properties:
+                        key:
+                          description: key is the label key that the selector applies
+                            to.
+                          type: string
+                        operator:
+                          description: |-
+                            operator represents a key's relationship to a set of values.
+                            Valid operators are In, NotIn, Exists and DoesNotExist.
+                          type: string
+                        values:
+                          description: |-
+                            values is an array of string values. If the operator is In or NotIn,
+                            the values array must be non-empty. If the operator is Exists or DoesNotExist,
+                            the values array must be empty. This array is replaced during a strategic
+                            merge patch.
+                          items:
+                            type: string
+                          type: array
+                      required:
+                      - key
+                      - operator
+                      type: object
+                    type: array
+                  matchLabels:
+                    additionalProperties:
                       type: string
-                  type: object
-                type: array
-              version:
+                    description: |-
+                      matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels
+                      map is equivalent to an element of matchExpressions, whose key field is "key", the
+                      operator is "In", and the values array contains only "value". The requirements are ANDed.
+                    type: object
+                type: object
+                x-kubernetes-map-type: atomic
+              natEnabled:
+                description: Enable NAT between clusters.
+                type: boolean
+              serviceCIDR:
+                description: The service CIDR; detected if unset.
                 type: string
+              serviceDiscoveryEnabled:
+                description: Enable support for Service Discovery (Lighthouse).
+                type: boolean
             required:
             - brokerK8sApiServer
             - brokerK8sRemoteNamespace
+            - brokerSecret
             - clusterID
-            - debug
-            - namespace
             type: object
           status:
-            description: ServiceDiscoveryStatus defines the observed state of ServiceDiscovery.
+            description: JoinRequestStatus defines the observed state of JoinRequest.
             properties:
-              deploymentInfo:
-                properties:
-                  cloudProvider:
-                    type: string
-                  kubernetesType:
-                    type: string
-                  kubernetesTypeVersion:
-                    type: string
-                  kubernetesVersion:
-                    type: string
-                type: object
+              gatewayNodes:
+                description: The nodes labeled as gateways.
+                items:
+                  type: string
+                type: array
+              message:
+                description: What the join is waiting for, or why it failed.
+                type: string
+              phase:
+                description: The phase of the join.
+                type: string
             type: object
         type: object
     served: true
@@ -2582,6 +4210,47 @@ roleRef:
 subjects:
   - kind: ServiceAccount
     name: submariner-k8s-broker-client
+`
+	Config_broker_broker_observer_role_yaml = `---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: Role
+metadata:
+  name: submariner-k8s-broker-observer
+rules:
+  - apiGroups:
+      - submariner.io
+    resources:
+      - brokers
+      - clusters
+      - endpoints
+    verbs:
+      - get
+      - list
+      - watch
+  - apiGroups:
+      - multicluster.x-k8s.io
+    resources:
+      - '*'
+    verbs:
+      - get
+      - list
+      - watch
+  - apiGroups:
+      - discovery.k8s.io
+    resources:
+      - endpointslices
+    verbs:
+      - get
+      - list
+      - watch
+  - apiGroups:
+      - ""
+    resources:
+      - configmaps
+    verbs:
+      - get
+      - list
+      - watch
 `
 	Config_rbac_submariner_operator_service_account_yaml = `---
 apiVersion: v1
@@ -2609,11 +4278,31 @@ rules:
       - secrets
     verbs:
       - '*'
+  # service accounts of removed components and departed clusters are cleaned up, the broker RBAC is restored, and
+  # departed clusters are annotated with when they were found stale
   - apiGroups:
       - ""
     resources:
       - serviceaccounts
     verbs:
+      - get
+      - list
+      - watch
+      - create
+      - update
+      - patch
+      - delete
+  - apiGroups:
+      - rbac.authorization.k8s.io
+    resources:
+      - roles
+      - rolebindings
+    verbs:
+      - get
+      - list
+      - watch
+      - create
+      - update
       - delete
   - apiGroups:
       - apps
@@ -2640,23 +4329,76 @@ rules:
     verbs:
       - update
   - apiGroups:
-      - ""
+      - submariner.io
     resources:
-      - pods
+      - '*'
+    verbs:
+      - '*'
+  - apiGroups:  # clusterset DNS records are published through external-dns
+      - externaldns.k8s.io
+    resources:
+      - dnsendpoints
     verbs:
       - get
-  - apiGroups:
-      - apps
+      - list
+      - create
+      - update
+      - delete
+      - deletecollection
+  - apiGroups:  # verification suites run as Jobs
+      - batch
     resources:
-      - replicasets
+      - jobs
     verbs:
       - get
-  - apiGroups:
-      - submariner.io
+      - list
+      - watch
+      - create
+  - apiGroups:  # imported services are mirrored as Istio ServiceEntries
+      - networking.istio.io
     resources:
-      - '*'
+      - serviceentries
+    verbs:
+      - get
+      - list
+      - create
+      - update
+      - delete
+      - deletecollection
+  - apiGroups:  # gateways and route agents can be protected against eviction
+      - policy
+    resources:
+      - poddisruptionbudgets
     verbs:
+      - get
+      - create
+      - update
+      - delete
+  - apiGroups:  # restoring the broker roles requires holding the permissions they grant, and broker objects are counted
+      - multicluster.x-k8s.io
+    resources:
       - '*'
+    verbs:
+      - create
+      - get
+      - list
+      - watch
+      - patch
+      - update
+      - delete
+  - apiGroups:
+      - discovery.k8s.io
+    resources:
+      - endpointslices
+      - endpointslices/restricted
+    verbs:
+      - create
+      - get
+      - list
+      - watch
+      - patch
+      - update
+      - delete
 `
 	Config_rbac_submariner_operator_role_binding_yaml = `---
 kind: RoleBinding
@@ -2699,8 +4441,23 @@ rules:
       - list
       - create
       - update
+      - patch
       - delete
       - watch
+  - apiGroups:
+      - apiextensions.k8s.io
+    resources:
+      - customresourcedefinitions/status
+    verbs:
+      - update
+  - apiGroups:  # resources are rewritten when the storage version of their CRD changes
+      - submariner.io
+      - multicluster.x-k8s.io
+    resources:
+      - '*'
+    verbs:
+      - list
+      - update
   - apiGroups:  # pods, services and nodes are looked up to figure out network settings
       - ""
     resources:
@@ -2711,6 +4468,12 @@ rules:
       - get
       - list
       - watch
+  - apiGroups:  # gateway nodes are labeled when joining through a JoinRequest, and while their gateway is drained
+      - ""
+    resources:
+      - nodes
+    verbs:
+      - patch
   - apiGroups:
       - operator.openshift.io
     resources:
@@ -3402,6 +5165,44 @@ roleRef:
 subjects:
   - kind: ServiceAccount
     name: submariner-globalnet
+`
+	Config_rbac_submariner_metrics_proxy_service_account_yaml = `---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: submariner-metrics-proxy
+`
+	Config_rbac_submariner_metrics_proxy_cluster_role_yaml = `---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: submariner-metrics-proxy
+rules:
+  - apiGroups:  # metrics clients are authenticated and authorized by the API server
+      - authentication.k8s.io
+    resources:
+      - tokenreviews
+    verbs:
+      - create
+  - apiGroups:
+      - authorization.k8s.io
+    resources:
+      - subjectaccessreviews
+    verbs:
+      - create
+`
+	Config_rbac_submariner_metrics_proxy_cluster_role_binding_yaml = `---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: submariner-metrics-proxy
+subjects:
+  - kind: ServiceAccount
+    name: submariner-metrics-proxy
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: submariner-metrics-proxy
 `
 	Config_rbac_submariner_diagnose_service_account_yaml = `---
 apiVersion: v1
@@ -20,65 +20,50 @@ package gateway
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/submariner-io/submariner-operator/pkg/crd"
 	"github.com/submariner-io/submariner-operator/pkg/embeddedyamls"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 // Ensure ensures that the required resources are deployed on the target system.
-// The resources handled here are the gateway CRDs: Cluster and Endpoint.
-//
-//nolint:gocyclo // No further refactors necessary
+// The resources handled here are the gateway CRDs: Cluster and Endpoint, and the Gateway, Globalnet and route CRDs.
+// Installed CRDs which drifted from the embedded manifests are repaired. The CRDs are independent, so they're
+// provisioned concurrently; all the failures are reported.
 func Ensure(ctx context.Context, crdUpdater crd.Updater) error {
-	_, err := crdUpdater.CreateOrUpdateFromEmbedded(ctx,
-		embeddedyamls.Deploy_submariner_crds_submariner_io_clusters_yaml)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return errors.Wrap(err, "error provisioning the Cluster CRD")
+	crds := []struct {
+		yaml string
+		kind string
+	}{
+		{embeddedyamls.Deploy_submariner_crds_submariner_io_clusters_yaml, "Cluster"},
+		{embeddedyamls.Deploy_submariner_crds_submariner_io_endpoints_yaml, "Endpoint"},
+		{embeddedyamls.Deploy_submariner_crds_submariner_io_gateways_yaml, "Gateway"},
+		{embeddedyamls.Deploy_submariner_crds_submariner_io_clusterglobalegressips_yaml, "ClusterGlobalEgressIP"},
+		{embeddedyamls.Deploy_submariner_crds_submariner_io_globalegressips_yaml, "GlobalEgressIP"},
+		{embeddedyamls.Deploy_submariner_crds_submariner_io_globalingressips_yaml, "GlobalIngressIP"},
+		{embeddedyamls.Deploy_submariner_crds_submariner_io_gatewayroutes_yaml, "GatewayRoute"},
+		{embeddedyamls.Deploy_submariner_crds_submariner_io_nongatewayroutes_yaml, "NonGatewayRoute"},
 	}
 
-	_, err = crdUpdater.CreateOrUpdateFromEmbedded(ctx,
-		embeddedyamls.Deploy_submariner_crds_submariner_io_endpoints_yaml)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return errors.Wrap(err, "error provisioning the Endpoint CRD")
-	}
+	errs := make([]error, len(crds))
 
-	_, err = crdUpdater.CreateOrUpdateFromEmbedded(ctx,
-		embeddedyamls.Deploy_submariner_crds_submariner_io_gateways_yaml)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return errors.Wrap(err, "error provisioning the Gateway CRD")
-	}
+	var wg sync.WaitGroup
 
-	_, err = crdUpdater.CreateOrUpdateFromEmbedded(ctx,
-		embeddedyamls.Deploy_submariner_crds_submariner_io_clusterglobalegressips_yaml)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return errors.Wrap(err, "error provisioning the ClusterGlobalEgressIP CRD")
-	}
+	for i := range crds {
+		wg.Add(1)
 
-	_, err = crdUpdater.CreateOrUpdateFromEmbedded(ctx,
-		embeddedyamls.Deploy_submariner_crds_submariner_io_globalegressips_yaml)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return errors.Wrap(err, "error provisioning the GlobalEgressIP CRD")
-	}
+		go func(i int) {
+			defer wg.Done()
 
-	_, err = crdUpdater.CreateOrUpdateFromEmbedded(ctx,
-		embeddedyamls.Deploy_submariner_crds_submariner_io_globalingressips_yaml)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return errors.Wrap(err, "error provisioning the GlobalIngressIP CRD")
+			if _, err := crdUpdater.CreateOrUpdateFromEmbedded(ctx, crds[i].yaml); err != nil {
+				errs[i] = errors.Wrapf(err, "error provisioning the %s CRD", crds[i].kind)
+			}
+		}(i)
 	}
 
-	_, err = crdUpdater.CreateOrUpdateFromEmbedded(ctx,
-		embeddedyamls.Deploy_submariner_crds_submariner_io_gatewayroutes_yaml)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return errors.Wrap(err, "error getting Gateway routes")
-	}
-
-	_, err = crdUpdater.CreateOrUpdateFromEmbedded(ctx,
-		embeddedyamls.Deploy_submariner_crds_submariner_io_nongatewayroutes_yaml)
-	if err != nil && !apierrors.IsAlreadyExists(err) {
-		return errors.Wrap(err, "error getting non-Gateway routes")
-	}
+	wg.Wait()
 
-	return nil
+	return utilerrors.NewAggregate(errs)
 }
@@ -0,0 +1,217 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/helm"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+const (
+	releaseName      = "submariner"
+	releaseNamespace = "submariner"
+)
+
+var _ = Describe("FindRelease", func() {
+	var (
+		c       client.Client
+		secrets []client.Object
+	)
+
+	BeforeEach(func() {
+		secrets = []client.Object{
+			newReleaseSecret(1, "superseded", map[string]interface{}{}),
+			newReleaseSecret(2, "deployed", map[string]interface{}{"submariner": map[string]interface{}{"clusterId": "east"}}),
+		}
+	})
+
+	JustBeforeEach(func() {
+		c = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(secrets...).Build()
+	})
+
+	It("should return the deployed revision with the merged values", func(ctx SpecContext) {
+		release, err := helm.FindRelease(ctx, c, releaseNamespace, releaseName)
+		Expect(err).To(Succeed())
+		Expect(release.Version).To(Equal(2))
+		Expect(release.Chart).To(Equal(helm.SubmarinerChart))
+		Expect(release.Values).To(HaveKeyWithValue("submariner", map[string]interface{}{
+			"clusterId":  "east",
+			"natEnabled": false,
+		}))
+	})
+
+	It("should parse the deployed resources", func(ctx SpecContext) {
+		release, err := helm.FindRelease(ctx, c, releaseNamespace, releaseName)
+		Expect(err).To(Succeed())
+
+		objs, err := release.Objects()
+		Expect(err).To(Succeed())
+		Expect(objs).To(HaveLen(2))
+		Expect(objs[0].GetKind()).To(Equal("DaemonSet"))
+		Expect(objs[0].GetName()).To(Equal("submariner"))
+		Expect(objs[1].GetKind()).To(Equal("ServiceAccount"))
+	})
+
+	It("should delete all the revisions", func(ctx SpecContext) {
+		release, err := helm.FindRelease(ctx, c, releaseNamespace, releaseName)
+		Expect(err).To(Succeed())
+		Expect(release.Delete(ctx, c)).To(Succeed())
+
+		_, err = helm.FindRelease(ctx, c, releaseNamespace, releaseName)
+		Expect(errors.Is(err, helm.ErrReleaseNotFound)).To(BeTrue())
+	})
+
+	When("no revision is deployed", func() {
+		BeforeEach(func() {
+			secrets = secrets[:1]
+		})
+
+		It("should return ErrReleaseNotFound", func(ctx SpecContext) {
+			_, err := helm.FindRelease(ctx, c, releaseNamespace, releaseName)
+			Expect(errors.Is(err, helm.ErrReleaseNotFound)).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("ImportSubmariner", func() {
+	var release *helm.Release
+
+	BeforeEach(func() {
+		release = &helm.Release{
+			Name:  releaseName,
+			Chart: helm.SubmarinerChart,
+			Values: map[string]interface{}{
+				"submariner": map[string]interface{}{
+					"clusterId":   "east",
+					"clusterCidr": "10.0.0.0/16",
+					"serviceCidr": "100.0.0.0/16",
+					"natEnabled":  true,
+					"cableDriver": "wireguard",
+				},
+				"broker": map[string]interface{}{
+					"server":    "broker.example.com:6443",
+					"token":     "token",
+					"namespace": "submariner-k8s-broker",
+				},
+				"ipsec": map[string]interface{}{
+					"psk":     "secret",
+					"ikePort": float64(500),
+				},
+				"image": map[string]interface{}{
+					"repository": "registry.example.com/submariner/submariner",
+					"tag":        "0.7.0",
+				},
+			},
+		}
+	})
+
+	It("should import the chart values", func() {
+		instance, err := helm.ImportSubmariner(release, "submariner-operator")
+		Expect(err).To(Succeed())
+		Expect(instance.Namespace).To(Equal("submariner-operator"))
+		Expect(instance.Spec.ClusterID).To(Equal("east"))
+		Expect(instance.Spec.ClusterCIDR).To(Equal("10.0.0.0/16"))
+		Expect(instance.Spec.ServiceCIDR).To(Equal("100.0.0.0/16"))
+		Expect(instance.Spec.NatEnabled).To(BeTrue())
+		Expect(instance.Spec.CableDriver).To(Equal("wireguard"))
+		Expect(instance.Spec.BrokerK8sApiServer).To(Equal("broker.example.com:6443"))
+		Expect(instance.Spec.BrokerK8sApiServerToken).To(Equal("token"))
+		Expect(instance.Spec.BrokerK8sRemoteNamespace).To(Equal("submariner-k8s-broker"))
+		Expect(instance.Spec.CeIPSecPSK).To(Equal("secret"))
+		Expect(instance.Spec.CeIPSecIKEPort).To(Equal(500))
+		Expect(instance.Spec.Repository).To(Equal("registry.example.com/submariner"))
+		Expect(instance.Spec.Version).To(Equal(v1alpha1.DefaultSubmarinerVersion))
+	})
+
+	When("a value has the wrong type", func() {
+		BeforeEach(func() {
+			release.Values["ipsec"] = map[string]interface{}{"ikePort": "500"}
+		})
+
+		It("should return an error", func() {
+			_, err := helm.ImportSubmariner(release, "submariner-operator")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	When("the release uses another chart", func() {
+		BeforeEach(func() {
+			release.Chart = "submariner-operator"
+		})
+
+		It("should return an error", func() {
+			_, err := helm.ImportSubmariner(release, "submariner-operator")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+func newReleaseSecret(version int, status string, config map[string]interface{}) *corev1.Secret {
+	data, err := json.Marshal(map[string]interface{}{
+		"name":      releaseName,
+		"namespace": releaseNamespace,
+		"version":   version,
+		"chart": map[string]interface{}{
+			"metadata": map[string]interface{}{"name": helm.SubmarinerChart},
+			"values": map[string]interface{}{
+				"submariner": map[string]interface{}{"clusterId": "", "natEnabled": false},
+			},
+		},
+		"config": config,
+		"manifest": "---\napiVersion: apps/v1\nkind: DaemonSet\nmetadata:\n  name: submariner\n" +
+			"---\napiVersion: v1\nkind: ServiceAccount\nmetadata:\n  name: submariner-engine\n",
+	})
+	Expect(err).To(Succeed())
+
+	var compressed bytes.Buffer
+
+	writer := gzip.NewWriter(&compressed)
+	_, err = writer.Write(data)
+	Expect(err).To(Succeed())
+	Expect(writer.Close()).To(Succeed())
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: releaseNamespace,
+			Name:      "sh.helm.release.v1.submariner.v" + strconv.Itoa(version),
+			Labels: map[string]string{
+				"owner":   "helm",
+				"name":    releaseName,
+				"status":  status,
+				"version": strconv.Itoa(version),
+			},
+		},
+		Type: "helm.sh/release.v1",
+		Data: map[string][]byte{"release": []byte(base64.StdEncoding.EncodeToString(compressed.Bytes()))},
+	}
+}
@@ -0,0 +1,216 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helm reads the releases stored in the cluster by Helm, to migrate Submariner deployments installed with the
+// legacy Helm charts to the operator.
+package helm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	releaseKey        = "release"
+	ownerLabel        = "owner"
+	nameLabel         = "name"
+	statusLabel       = "status"
+	versionLabel      = "version"
+	deployedStatus    = "deployed"
+	helmOwner         = "helm"
+	releaseSecretType = corev1.SecretType("helm.sh/release.v1")
+)
+
+var (
+	ErrReleaseNotFound = errors.New("no deployed Helm release found")
+	gzipMagic          = []byte{0x1f, 0x8b, 0x08}
+)
+
+// Release is a deployed Helm release.
+type Release struct {
+	Name      string
+	Namespace string
+	Version   int
+	Chart     string
+	// The chart's default values, overridden by the values supplied when installing or upgrading the release.
+	Values   map[string]interface{}
+	Manifest string
+}
+
+type storedRelease struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Version   int    `json:"version"`
+	Chart     struct {
+		Metadata struct {
+			Name string `json:"name"`
+		} `json:"metadata"`
+		Values map[string]interface{} `json:"values"`
+	} `json:"chart"`
+	Config   map[string]interface{} `json:"config"`
+	Manifest string                 `json:"manifest"`
+}
+
+// FindRelease returns the deployed revision of the named release, or ErrReleaseNotFound if there is none.
+func FindRelease(ctx context.Context, c client.Client, namespace, name string) (*Release, error) {
+	secrets := &corev1.SecretList{}
+
+	err := c.List(ctx, secrets, client.InNamespace(namespace),
+		client.MatchingLabels{ownerLabel: helmOwner, nameLabel: name, statusLabel: deployedStatus})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing the secrets of the Helm release %q", name)
+	}
+
+	var latest *corev1.Secret
+
+	for i := range secrets.Items {
+		if secrets.Items[i].Type != releaseSecretType {
+			continue
+		}
+
+		if latest == nil || revision(&secrets.Items[i]) > revision(latest) {
+			latest = &secrets.Items[i]
+		}
+	}
+
+	if latest == nil {
+		return nil, errors.Wrapf(ErrReleaseNotFound, "release %q in namespace %q", name, namespace)
+	}
+
+	release, err := DecodeRelease(latest.Data[releaseKey])
+
+	return release, errors.Wrapf(err, "error decoding the Helm release secret %q", latest.Name)
+}
+
+func revision(secret *corev1.Secret) int {
+	v, _ := strconv.Atoi(secret.Labels[versionLabel])
+	return v
+}
+
+// DecodeRelease decodes a release as stored by Helm: base64-encoded, optionally gzipped, JSON.
+func DecodeRelease(data []byte) (*Release, error) {
+	decoded := make([]byte, base64.StdEncoding.DecodedLen(len(data)))
+
+	n, err := base64.StdEncoding.Decode(decoded, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "error decoding the release")
+	}
+
+	decoded = decoded[:n]
+
+	if bytes.HasPrefix(decoded, gzipMagic) {
+		reader, err := gzip.NewReader(bytes.NewReader(decoded))
+		if err != nil {
+			return nil, errors.Wrap(err, "error decompressing the release")
+		}
+
+		defer reader.Close()
+
+		if decoded, err = io.ReadAll(reader); err != nil {
+			return nil, errors.Wrap(err, "error decompressing the release")
+		}
+	}
+
+	stored := &storedRelease{}
+	if err := json.Unmarshal(decoded, stored); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the release")
+	}
+
+	return &Release{
+		Name:      stored.Name,
+		Namespace: stored.Namespace,
+		Version:   stored.Version,
+		Chart:     stored.Chart.Metadata.Name,
+		Values:    mergeValues(stored.Chart.Values, stored.Config),
+		Manifest:  stored.Manifest,
+	}, nil
+}
+
+// mergeValues returns the default values, recursively overridden by the supplied values.
+func mergeValues(defaults, overrides map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(defaults))
+
+	for k, v := range defaults {
+		merged[k] = v
+	}
+
+	for k, v := range overrides {
+		defaultMap, defaultIsMap := merged[k].(map[string]interface{})
+		overrideMap, overrideIsMap := v.(map[string]interface{})
+
+		if defaultIsMap && overrideIsMap {
+			merged[k] = mergeValues(defaultMap, overrideMap)
+		} else {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
+// Objects returns the resources deployed by the release.
+func (r *Release) Objects() ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(r.Manifest), 4096)
+
+	for {
+		obj := &unstructured.Unstructured{}
+
+		err := decoder.Decode(&obj.Object)
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "error parsing the manifest of the Helm release %q", r.Name)
+		}
+
+		// Empty documents are decoded as empty objects
+		if len(obj.Object) == 0 {
+			continue
+		}
+
+		objs = append(objs, obj)
+	}
+}
+
+// Delete removes the release's history, i.e. all its revisions, so that Helm no longer manages its resources. The
+// resources themselves are left as-is.
+func (r *Release) Delete(ctx context.Context, c client.Client) error {
+	err := c.DeleteAllOf(ctx, &corev1.Secret{}, client.InNamespace(r.Namespace),
+		client.MatchingLabels{ownerLabel: helmOwner, nameLabel: r.Name})
+
+	return errors.Wrapf(err, "error deleting the history of the Helm release %q", r.Name)
+}
+
+func (r *Release) String() string {
+	return fmt.Sprintf("%s/%s (chart %s, revision %d)", r.Namespace, r.Name, r.Chart, r.Version)
+}
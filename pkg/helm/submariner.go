@@ -0,0 +1,150 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/pkg/images"
+	opnames "github.com/submariner-io/submariner-operator/pkg/names"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// SubmarinerChart is the name of the legacy chart which deployed Submariner before the operator.
+const SubmarinerChart = "submariner"
+
+// ImportSubmariner returns a Submariner resource, in the given namespace, configured like the given release of the
+// legacy Submariner chart. Only the image repository is imported: the components are deployed at the operator's version.
+func ImportSubmariner(release *Release, namespace string) (*v1alpha1.Submariner, error) {
+	if release.Chart != SubmarinerChart {
+		return nil, fmt.Errorf("the Helm release %q uses the %q chart, expected %q", release.Name, release.Chart, SubmarinerChart)
+	}
+
+	v := &values{values: release.Values}
+
+	instance := &v1alpha1.Submariner{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      opnames.SubmarinerCrName,
+		},
+		Spec: v1alpha1.SubmarinerSpec{
+			Broker:                   "k8s",
+			BrokerK8sApiServer:       v.string("broker", "server"),
+			BrokerK8sApiServerToken:  v.string("broker", "token"),
+			BrokerK8sCA:              v.string("broker", "ca"),
+			BrokerK8sRemoteNamespace: v.string("broker", "namespace"),
+			BrokerK8sInsecure:        v.bool("broker", "insecure"),
+			ClusterID:                v.string("submariner", "clusterId"),
+			ClusterCIDR:              v.string("submariner", "clusterCidr"),
+			ServiceCIDR:              v.string("submariner", "serviceCidr"),
+			GlobalCIDR:               v.string("submariner", "globalCidr"),
+			NatEnabled:               v.bool("submariner", "natEnabled"),
+			Debug:                    v.bool("submariner", "debug"),
+			ColorCodes:               v.string("submariner", "colorCodes"),
+			CableDriver:              v.string("submariner", "cableDriver"),
+			ServiceDiscoveryEnabled:  v.bool("submariner", "serviceDiscovery"),
+			CeIPSecPSK:               v.string("ipsec", "psk"),
+			CeIPSecDebug:             v.bool("ipsec", "debug"),
+			CeIPSecForceUDPEncaps:    v.bool("ipsec", "forceUDPEncaps"),
+			CeIPSecIKEPort:           v.int("ipsec", "ikePort"),
+			CeIPSecNATTPort:          v.int("ipsec", "natPort"),
+			Version:                  v1alpha1.DefaultSubmarinerVersion,
+			Repository:               v1alpha1.DefaultRepo,
+		},
+	}
+
+	if _, repository := images.ParseOperatorImage(v.string("image", "repository")); repository != "" {
+		instance.Spec.Repository = repository
+	}
+
+	if v.err != nil {
+		return nil, errors.Wrapf(v.err, "error importing the values of the Helm release %q", release.Name)
+	}
+
+	if instance.Spec.ClusterID == "" || instance.Spec.BrokerK8sApiServer == "" {
+		return nil, fmt.Errorf("the Helm release %q doesn't specify the cluster ID and the broker", release.Name)
+	}
+
+	return instance, nil
+}
+
+// values reads chart values, recording the first type mismatch.
+type values struct {
+	values map[string]interface{}
+	err    error
+}
+
+func (v *values) field(path ...string) (interface{}, bool) {
+	if v.err != nil {
+		return nil, false
+	}
+
+	value, found, err := unstructured.NestedFieldNoCopy(v.values, path...)
+	if err != nil {
+		v.err = err
+	}
+
+	return value, found && err == nil && value != nil
+}
+
+func (v *values) string(path ...string) string {
+	value, found := v.field(path...)
+	if !found {
+		return ""
+	}
+
+	s, ok := value.(string)
+	if !ok {
+		v.err = fmt.Errorf("%v is a %T, expected a string", path, value)
+	}
+
+	return s
+}
+
+func (v *values) bool(path ...string) bool {
+	value, found := v.field(path...)
+	if !found {
+		return false
+	}
+
+	b, ok := value.(bool)
+	if !ok {
+		v.err = fmt.Errorf("%v is a %T, expected a boolean", path, value)
+	}
+
+	return b
+}
+
+func (v *values) int(path ...string) int {
+	value, found := v.field(path...)
+	if !found {
+		return 0
+	}
+
+	// JSON numbers are decoded as floats
+	f, ok := value.(float64)
+	if !ok || f != float64(int(f)) {
+		v.err = fmt.Errorf("%v is a %T (%v), expected an integer", path, value, value)
+	}
+
+	return int(f)
+}
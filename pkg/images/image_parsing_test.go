@@ -56,6 +56,25 @@ var _ = Describe("image parsing", func() {
 	})
 })
 
+var _ = Describe("image digest pinning", func() {
+	It("Should replace the tag with the digest", func() {
+		Expect(images.PinToDigest("quay.io/submariner/submariner-gateway:0.17.0", "sha256:1234")).To(
+			Equal("quay.io/submariner/submariner-gateway@sha256:1234"))
+		Expect(images.PinToDigest("localhost:5000/submariner-gateway:local", "sha256:1234")).To(
+			Equal("localhost:5000/submariner-gateway@sha256:1234"))
+	})
+
+	It("Should replace an existing digest", func() {
+		Expect(images.PinToDigest("quay.io/submariner/submariner-gateway@sha256:1234", "sha256:5678")).To(
+			Equal("quay.io/submariner/submariner-gateway@sha256:5678"))
+	})
+
+	It("Should use a complete image reference as-is", func() {
+		Expect(images.PinToDigest("quay.io/submariner/submariner-gateway:0.17.0", "mirror.io/gateway@sha256:1234")).To(
+			Equal("mirror.io/gateway@sha256:1234"))
+	})
+})
+
 func TestParseOperatorImage(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "image parsing")
@@ -52,6 +52,22 @@ func GetImagePath(repo, version, image, component string, imageOverrides map[str
 	return logIfChanged(repo, version, image, component, path, "Calculated path")
 }
 
+// PinToDigest pins the given image path to the given digest, replacing any tag or digest it references. If the digest
+// is a complete image reference, it is returned as-is.
+func PinToDigest(path, digest string) string {
+	if strings.Contains(digest, "/") {
+		return digest
+	}
+
+	if i := strings.Index(path, "@"); i != -1 {
+		path = path[:i]
+	} else if i := strings.LastIndex(path, ":"); i > strings.LastIndex(path, "/") {
+		path = path[:i]
+	}
+
+	return path + "@" + digest
+}
+
 type imageParameters struct {
 	repo      string
 	version   string
@@ -33,6 +33,7 @@ const (
 
 // Ensure ensures that the required resources are deployed on the target system
 // The resources handled here are the lighthouse CRDs: ServiceImport, ServiceExport and ServiceDiscovery.
+// Installed CRDs which drifted from the embedded manifests are repaired.
 func Ensure(ctx context.Context, crdUpdater crd.Updater, isBroker bool) (bool, error) {
 	installedMCSSI, err := crdUpdater.CreateOrUpdateFromEmbedded(ctx,
 		embeddedyamls.Deploy_mcsapi_crds_multicluster_x_k8s_io_serviceimports_yaml)
@@ -24,6 +24,7 @@ import "fmt"
 const (
 	ServiceDiscoveryCrName = "service-discovery"
 	SubmarinerCrName       = "submariner"
+	ClusterNetworkCrName   = "cluster-network"
 	CleanupFinalizer       = "controllers.submariner.io/cleanup"
 )
 
@@ -40,6 +41,9 @@ var (
 	SubctlImage            = "subctl"
 )
 
+/* The image securing the metrics proxies, which downstream distributions can also override. */
+var KubeRBACProxyImage = "quay.io/brancz/kube-rbac-proxy:v0.16.0"
+
 func AppendUninstall(name string) string {
 	return name + "-uninstall"
 }
@@ -47,3 +51,7 @@ func AppendUninstall(name string) string {
 func ForClusterSA(clusterID string) string {
 	return fmt.Sprintf("cluster-%s", clusterID)
 }
+
+func ForBrokerObserverSA(observer string) string {
+	return fmt.Sprintf("broker-observer-%s", observer)
+}
@@ -0,0 +1,139 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rbac defines the permissions the operator requires. The manifests in config/rbac/submariner-operator, which
+// subctl deploys, must match these definitions.
+package rbac
+
+import (
+	"github.com/submariner-io/admiral/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const ocpPrefix = "ocp-"
+
+// OperatorRules are the permissions the operator requires in its own namespace, where it deploys the Submariner
+// components.
+var OperatorRules = []rbacv1.PolicyRule{
+	rule([]string{""}, []string{
+		"pods", "services", "services/finalizers", "endpoints", "persistentvolumeclaims", "events", "configmaps", "secrets",
+	}, "*"),
+	// Service accounts of removed components and departed clusters are cleaned up, the broker RBAC is restored, and
+	// departed clusters are annotated with when they were found stale
+	rule([]string{""}, []string{"serviceaccounts"}, "get", "list", "watch", "create", "update", "patch", "delete"),
+	rule([]string{"rbac.authorization.k8s.io"}, []string{"roles", "rolebindings"}, "get", "list", "watch", "create", "update", "delete"),
+	rule([]string{"apps"}, []string{"deployments", "daemonsets", "replicasets", "statefulsets"}, "*"),
+	rule([]string{"monitoring.coreos.com"}, []string{"servicemonitors"}, "get", "create"),
+	{
+		APIGroups:     []string{"apps"},
+		ResourceNames: []string{names.OperatorComponent},
+		Resources:     []string{"deployments/finalizers"},
+		Verbs:         []string{"update"},
+	},
+	rule([]string{"submariner.io"}, []string{"*"}, "*"),
+	// Clusterset DNS records are published through external-dns
+	rule([]string{"externaldns.k8s.io"}, []string{"dnsendpoints"}, "get", "list", "create", "update", "delete", "deletecollection"),
+	// Verification suites run as Jobs
+	rule([]string{"batch"}, []string{"jobs"}, "get", "list", "watch", "create"),
+	// Imported services are mirrored as Istio ServiceEntries
+	rule([]string{"networking.istio.io"}, []string{"serviceentries"}, "get", "list", "create", "update", "delete", "deletecollection"),
+	// Gateways and route agents can be protected against eviction
+	rule([]string{"policy"}, []string{"poddisruptionbudgets"}, "get", "create", "update", "delete"),
+	// Restoring the broker roles requires holding the permissions they grant, and broker objects are counted
+	rule([]string{"multicluster.x-k8s.io"}, []string{"*"}, "create", "get", "list", "watch", "patch", "update", "delete"),
+	rule([]string{"discovery.k8s.io"}, []string{"endpointslices", "endpointslices/restricted"},
+		"create", "get", "list", "watch", "patch", "update", "delete"),
+}
+
+// OperatorClusterRules are the permissions the operator requires across the cluster. These are limited to discovering
+// the cluster's network settings, labeling its gateway nodes, configuring its DNS, and managing the CRDs.
+var OperatorClusterRules = []rbacv1.PolicyRule{
+	// The CoreDNS configuration is updated to forward clusterset.local requests to Lighthouse, and existing ConfigMaps
+	// are inspected to figure out network settings
+	rule([]string{""}, []string{"configmaps"}, "create", "get", "list", "watch", "update"),
+	rule([]string{"apiextensions.k8s.io"}, []string{"customresourcedefinitions"}, "get", "list", "create", "update", "patch", "delete",
+		"watch"),
+	rule([]string{"apiextensions.k8s.io"}, []string{"customresourcedefinitions/status"}, "update"),
+	// Resources are rewritten when the storage version of their CRD changes
+	rule([]string{"submariner.io", "multicluster.x-k8s.io"}, []string{"*"}, "list", "update"),
+	// Pods, services and nodes are looked up to figure out network settings
+	rule([]string{""}, []string{"pods", "services", "nodes"}, "get", "list", "watch"),
+	// Gateway nodes are labeled when joining through a JoinRequest, and while their gateway is drained
+	rule([]string{""}, []string{"nodes"}, "patch"),
+	rule([]string{"operator.openshift.io"}, []string{"dnses"}, "get", "list", "watch", "update"),
+	rule([]string{"config.openshift.io"}, []string{"networks"}, "get", "list"),
+	rule([]string{""}, []string{"namespaces"}, "get", "list", "watch"),
+	// On OpenShift, the ServiceMonitors are created in the openshift-monitoring namespace
+	rule([]string{"monitoring.coreos.com"}, []string{"servicemonitors"}, "get", "create"),
+	rule([]string{"apps"}, []string{"daemonsets"}, "list"),
+	// The RBAC of removed components is cleaned up
+	rule([]string{"rbac.authorization.k8s.io"}, []string{"clusterroles", "clusterrolebindings"}, "delete"),
+}
+
+// OperatorOpenShiftClusterRules are the additional permissions the operator requires on OpenShift.
+var OperatorOpenShiftClusterRules = []rbacv1.PolicyRule{
+	{
+		APIGroups:     []string{"security.openshift.io"},
+		ResourceNames: []string{"privileged"},
+		Resources:     []string{"securitycontextconstraints"},
+		Verbs:         []string{"use"},
+	},
+	rule([]string{"config.openshift.io"}, []string{"infrastructures"}, "get"),
+}
+
+func rule(apiGroups, resources []string, verbs ...string) rbacv1.PolicyRule {
+	return rbacv1.PolicyRule{APIGroups: apiGroups, Resources: resources, Verbs: verbs}
+}
+
+// OperatorObjects returns the service account, roles and bindings the operator requires when deployed in the given
+// namespace, including the OpenShift-specific ones if requested.
+func OperatorObjects(namespace string, openShift bool) []client.Object {
+	name := names.OperatorComponent
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: namespace}}
+
+	objs := []client.Object{
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}, Rules: OperatorRules},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Subjects:   subjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name},
+		},
+		&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: name}, Rules: OperatorClusterRules},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Subjects:   subjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: name},
+		},
+	}
+
+	if openShift {
+		objs = append(objs,
+			&rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: ocpPrefix + name}, Rules: OperatorOpenShiftClusterRules},
+			&rbacv1.ClusterRoleBinding{
+				ObjectMeta: metav1.ObjectMeta{Name: ocpPrefix + name},
+				Subjects:   subjects,
+				RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: ocpPrefix + name},
+			})
+	}
+
+	return objs
+}
@@ -0,0 +1,78 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rbac_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/submariner-io/submariner-operator/pkg/rbac"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const manifestsDir = "../../config/rbac/submariner-operator/"
+
+var _ = Describe("Operator RBAC manifests", func() {
+	It("should match the Role", func() {
+		role := &rbacv1.Role{}
+		readManifest("role.yaml", role)
+		Expect(role.Rules).To(Equal(rbac.OperatorRules))
+	})
+
+	It("should match the ClusterRole", func() {
+		clusterRole := &rbacv1.ClusterRole{}
+		readManifest("cluster_role.yaml", clusterRole)
+		Expect(clusterRole.Rules).To(Equal(rbac.OperatorClusterRules))
+	})
+
+	It("should match the OpenShift ClusterRole", func() {
+		clusterRole := &rbacv1.ClusterRole{}
+		readManifest("ocp_cluster_role.yaml", clusterRole)
+		Expect(clusterRole.Rules).To(Equal(rbac.OperatorOpenShiftClusterRules))
+	})
+})
+
+var _ = Describe("OperatorObjects", func() {
+	It("should bind the roles to the operator's service account in the given namespace", func() {
+		objs := rbac.OperatorObjects("test-ns", true)
+		Expect(objs).To(HaveLen(7))
+
+		for _, obj := range objs {
+			switch binding := obj.(type) {
+			case *rbacv1.RoleBinding:
+				Expect(binding.Namespace).To(Equal("test-ns"))
+				Expect(binding.Subjects).To(ConsistOf(HaveField("Namespace", "test-ns")))
+			case *rbacv1.ClusterRoleBinding:
+				Expect(binding.Subjects).To(ConsistOf(HaveField("Namespace", "test-ns")))
+			}
+		}
+	})
+
+	It("should omit the OpenShift roles if not requested", func() {
+		Expect(rbac.OperatorObjects("test-ns", false)).To(HaveLen(5))
+	})
+})
+
+func readManifest(name string, into interface{}) {
+	data, err := os.ReadFile(manifestsDir + name)
+	Expect(err).To(Succeed())
+	Expect(yaml.UnmarshalStrict(data, into)).To(Succeed())
+}
@@ -0,0 +1,48 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/submariner-io/submariner-operator/pkg/rbac"
+)
+
+const rbacCommand = "rbac"
+
+// runRBAC implements the rbac sub-command, which prints the service account, roles and bindings the operator requires,
+// e.g. to have them approved before installing in restricted environments.
+func runRBAC(args []string) int {
+	flags := flag.NewFlagSet(rbacCommand, flag.ContinueOnError)
+	namespace := flags.String("namespace", "submariner-operator", "The namespace the operator is deployed in")
+	openShift := flags.Bool("openshift", false, "Include the permissions required on OpenShift")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := writeManifests(rbac.OperatorObjects(*namespace, *openShift), os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
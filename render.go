@@ -0,0 +1,135 @@
+/*
+SPDX-License-Identifier: Apache-2.0
+
+Copyright Contributors to the Submariner project.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/submariner-io/submariner-operator/api/v1alpha1"
+	"github.com/submariner-io/submariner-operator/controllers/submariner"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+)
+
+const renderCommand = "render"
+
+// runRender implements the render sub-command, which prints the resources the operator would deploy
+// for a Submariner resource read from a file, without contacting a cluster.
+func runRender(args []string) int {
+	flags := flag.NewFlagSet(renderCommand, flag.ContinueOnError)
+	file := flags.String("f", "", "The file containing the Submariner resource to render, or - to read it from standard input")
+	namespace := flags.String("namespace", "submariner-operator",
+		"The namespace to render the resources in, if the Submariner resource doesn't specify one")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "the file containing the Submariner resource must be specified with -f")
+		flags.PrintDefaults()
+
+		return 2
+	}
+
+	if err := render(*file, *namespace, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
+
+func render(file, namespace string, out io.Writer) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "error reading %q", file)
+	}
+
+	instance := &v1alpha1.Submariner{}
+
+	if err := yaml.UnmarshalStrict(data, instance); err != nil {
+		return errors.Wrapf(err, "error parsing the Submariner resource in %q", file)
+	}
+
+	if instance.Kind != "" && instance.Kind != "Submariner" {
+		return fmt.Errorf("%q contains a %s resource, expected a Submariner", file, instance.Kind)
+	}
+
+	if instance.Namespace == "" {
+		instance.Namespace = namespace
+	}
+
+	return writeManifests(submariner.Render(instance), out)
+}
+
+// writeManifests writes the given objects as a multi-document YAML manifest.
+func writeManifests(objs []client.Object, out io.Writer) error {
+	renderScheme := apiruntime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(renderScheme))
+	utilruntime.Must(v1alpha1.AddToScheme(renderScheme))
+
+	for _, obj := range objs {
+		gvk, err := apiutil.GVKForObject(obj, renderScheme)
+		if err != nil {
+			return errors.Wrap(err, "error determining the resource type")
+		}
+
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+		content, err := apiruntime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return errors.Wrapf(err, "error converting %s %q", gvk.Kind, obj.GetName())
+		}
+
+		// Neither of these make sense in a static manifest
+		unstructured.RemoveNestedField(content, "status")
+		unstructured.RemoveNestedField(content, "metadata", "creationTimestamp")
+
+		manifest, err := yaml.Marshal(content)
+		if err != nil {
+			return errors.Wrapf(err, "error marshalling %s %q", gvk.Kind, obj.GetName())
+		}
+
+		if _, err := fmt.Fprintf(out, "---\n%s", manifest); err != nil {
+			return errors.Wrap(err, "error writing the rendered resources")
+		}
+	}
+
+	return nil
+}